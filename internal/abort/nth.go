@@ -0,0 +1,62 @@
+// Package abort contains the proofs revealed by the CMP presign and sign protocols
+// to identify the culprits when an execution aborts.
+package abort
+
+import (
+	"io"
+
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/arith"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	zknth "github.com/taurusgroup/multi-party-sig/pkg/zk/nth"
+)
+
+// Nth for a given ciphertext c = end(m,r) contains
+// - the message m,
+// - the "hidden" nonce r^N % N^2, equal to enc(0,r)
+// - a proof of knowledge of r
+type Nth struct {
+	Plaintext *saferith.Int
+	Nonce     *saferith.Nat
+	Proof     *zknth.Proof
+}
+
+// ProveNth decypts the message and the nonce contained in the ciphertext c, using the private key.
+// Returns an Nth proving knowledge of the nonce
+func ProveNth(rand io.Reader, hash *hash.Hash, paillierSecret *paillier.SecretKey, c *paillier.Ciphertext) *Nth {
+	NSquared := paillierSecret.ModulusSquared()
+	N := paillierSecret.Modulus()
+	deltaShareAlpha, deltaNonce, _ := paillierSecret.DecWithRandomness(c)
+	deltaNonceHidden := NSquared.Exp(deltaNonce, N.Nat())
+	proof := zknth.NewProof(rand, hash, zknth.Public{
+		N: paillierSecret.PublicKey,
+		R: deltaNonceHidden,
+	}, zknth.Private{Rho: deltaNonce})
+	return &Nth{
+		Plaintext: deltaShareAlpha,
+		Nonce:     deltaNonceHidden,
+		Proof:     proof,
+	}
+}
+
+// Verify returns true if msg contains the plaintext of c, and a valid proof for the nonce of c.
+func (msg *Nth) Verify(hash *hash.Hash, paillierPublic *paillier.PublicKey, c *paillier.Ciphertext) bool {
+	if msg == nil || !arith.IsValidNatModN(paillierPublic.ModulusSquared().Modulus, msg.Nonce) || msg.Plaintext == nil {
+		return false
+	}
+	one := new(saferith.Nat).SetUint64(1)
+	cExpected := c.Nat()
+	cActual := paillierPublic.EncWithNonce(msg.Plaintext, one).Nat()
+	cActual.ModMul(cActual, msg.Nonce, paillierPublic.ModulusSquared().Modulus)
+	if cExpected.Eq(cActual) != 1 {
+		return false
+	}
+	if !msg.Proof.Verify(hash, zknth.Public{
+		N: paillierPublic,
+		R: msg.Nonce,
+	}) {
+		return false
+	}
+	return true
+}
@@ -1,7 +1,7 @@
 package mta
 
 import (
-	"crypto/rand"
+	"io"
 
 	"github.com/cronokirby/saferith"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
@@ -23,11 +23,11 @@ import (
 // - D = (aⱼ ⊙ Bᵢ) ⊕ encᵢ(- β, s)
 // - F = encⱼ(-β, r)
 // - Proof = zkaffg proof of correct encryption.
-func ProveAffG(group curve.Curve, h *hash.Hash,
+func ProveAffG(rand io.Reader, group curve.Curve, h *hash.Hash,
 	senderSecretShare *saferith.Int, senderSecretSharePoint curve.Point, receiverEncryptedShare *paillier.Ciphertext,
 	sender *paillier.SecretKey, receiver *paillier.PublicKey, verifier *pedersen.Parameters) (Beta *saferith.Int, D, F *paillier.Ciphertext, Proof *zkaffg.Proof) {
-	D, F, S, R, BetaNeg := newMta(senderSecretShare, receiverEncryptedShare, sender, receiver)
-	Proof = zkaffg.NewProof(group, h, zkaffg.Public{
+	D, F, S, R, BetaNeg := newMta(rand, senderSecretShare, receiverEncryptedShare, sender, receiver)
+	Proof = zkaffg.NewProof(rand, group, h, zkaffg.Public{
 		Kv:       receiverEncryptedShare,
 		Dv:       D,
 		Fp:       F,
@@ -56,12 +56,12 @@ func ProveAffG(group curve.Curve, h *hash.Hash,
 // - D = (aⱼ ⊙ Bᵢ) ⊕ encᵢ(-β, s)
 // - F = encⱼ(-β, r)
 // - Proof = zkaffp proof of correct encryption.
-func ProveAffP(group curve.Curve, h *hash.Hash,
+func ProveAffP(rand io.Reader, group curve.Curve, h *hash.Hash,
 	senderSecretShare *saferith.Int, senderEncryptedShare *paillier.Ciphertext, senderEncryptedShareNonce *saferith.Nat,
 	receiverEncryptedShare *paillier.Ciphertext,
 	sender *paillier.SecretKey, receiver *paillier.PublicKey, verifier *pedersen.Parameters) (Beta *saferith.Int, D, F *paillier.Ciphertext, Proof *zkaffp.Proof) {
-	D, F, S, R, BetaNeg := newMta(senderSecretShare, receiverEncryptedShare, sender, receiver)
-	Proof = zkaffp.NewProof(group, h, zkaffp.Public{
+	D, F, S, R, BetaNeg := newMta(rand, senderSecretShare, receiverEncryptedShare, sender, receiver)
+	Proof = zkaffp.NewProof(rand, group, h, zkaffp.Public{
 		Kv:       receiverEncryptedShare,
 		Dv:       D,
 		Fp:       F,
@@ -81,13 +81,13 @@ func ProveAffP(group curve.Curve, h *hash.Hash,
 	return
 }
 
-func newMta(senderSecretShare *saferith.Int, receiverEncryptedShare *paillier.Ciphertext,
+func newMta(rand io.Reader, senderSecretShare *saferith.Int, receiverEncryptedShare *paillier.Ciphertext,
 	sender *paillier.SecretKey, receiver *paillier.PublicKey) (D, F *paillier.Ciphertext, S, R *saferith.Nat, BetaNeg *saferith.Int) {
-	BetaNeg = sample.IntervalLPrime(rand.Reader)
+	BetaNeg = sample.IntervalLPrime(rand)
 
-	F, R = sender.Enc(BetaNeg) // F = encᵢ(-β, r)
+	F, R = sender.Enc(rand, BetaNeg) // F = encᵢ(-β, r)
 
-	D, S = receiver.Enc(BetaNeg)
+	D, S = receiver.Enc(rand, BetaNeg)
 	tmp := receiverEncryptedShare.Clone().Mul(receiver, senderSecretShare) // tmp = aᵢ ⊙ Bⱼ
 	D.Add(receiver, tmp)                                                   // D = encⱼ(-β;s) ⊕ (aᵢ ⊙ Bⱼ) = encⱼ(aᵢ•bⱼ-β)
 
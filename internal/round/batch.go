@@ -0,0 +1,341 @@
+package round
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+)
+
+// Batch executes several sessions of the same protocol together,
+// so that each round of the batch sends a single message to each party, containing the messages of all sessions.
+//
+// Sessions which have produced a result do not send or expect any more messages,
+// and all other sessions must be in the same round.
+// The sessions are finalized and verify their messages in parallel, on the Pool of the batch.
+type Batch struct {
+	*Helper
+
+	// Sessions[i] is the current round of the i-th session.
+	Sessions []Session
+
+	output func(results []interface{}) interface{}
+}
+
+// BroadcastBatch is a Batch whose sessions expect a broadcast message.
+type BroadcastBatch struct {
+	*Batch
+}
+
+type batchMessage struct {
+	number Number
+	// Contents[i] is the encoded content for the i-th session, and is empty if none was sent.
+	Contents [][]byte
+	// decoded[i] is set by VerifyMessage to the decoded content of Contents[i].
+	decoded []Content
+}
+
+type batchBroadcastMessage struct {
+	batchMessage
+	// reliable is not sent, and is set from the sessions of the round by both the sender and the receiver.
+	reliable bool
+}
+
+// NewBatch returns the first round of a Batch executing the given sessions, whose helper is used to send messages.
+//
+// Once all sessions have produced a result, the batch's result is given by output, called with the results in order.
+// If a session aborts, the batch aborts with the same culprits.
+func NewBatch(helper *Helper, sessions []Session, output func(results []interface{}) interface{}) Session {
+	return (&Batch{
+		Helper:   helper,
+		Sessions: sessions,
+		output:   output,
+	}).withBroadcast()
+}
+
+// NewBatchHelpers returns a Helper for each of the count sessions executed by the batch.
+//
+// The hash state of a session is derived from the SSID of the batch and the index of the session,
+// so that its proofs cannot be used in another session.
+// Since the sessions are finalized concurrently, info.Rand is shared through a pool.LockedReader.
+// The sessions do not use a pool themselves.
+func NewBatchHelpers(batch *Helper, info Info, count int) ([]*Helper, error) {
+	if info.Rand != nil {
+		info.Rand = pool.NewLockedReader(info.Rand)
+	}
+	helpers := make([]*Helper, count)
+	for i := range helpers {
+		var err error
+		helpers[i], err = NewSession(info, batch.SSID(), nil, hash.BytesWithDomain{
+			TheDomain: "Batch Index",
+			Bytes:     binary.BigEndian.AppendUint64(nil, uint64(i)),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return helpers, nil
+}
+
+// StoreBroadcastMessage implements BroadcastRound.
+//
+// - store the broadcast of each session.
+func (r *BroadcastBatch) StoreBroadcastMessage(msg Message) error {
+	body, ok := msg.Content.(*batchBroadcastMessage)
+	if !ok || body == nil {
+		return ErrInvalidContent
+	}
+	return r.forEach(&body.batchMessage, func(i int, s Session, data []byte) error {
+		b, ok := s.(BroadcastRound)
+		if !ok {
+			return nil
+		}
+		content := b.BroadcastContent()
+		if err := cbor.Unmarshal(data, content); err != nil {
+			return err
+		}
+		return b.StoreBroadcastMessage(Message{
+			From:      msg.From,
+			To:        msg.To,
+			Broadcast: true,
+			Content:   content,
+		})
+	})
+}
+
+// VerifyMessage implements Round.
+//
+// - verify the message of each session.
+func (r *Batch) VerifyMessage(msg Message) error {
+	body, ok := msg.Content.(*batchMessage)
+	if !ok || body == nil {
+		return ErrInvalidContent
+	}
+	body.decoded = make([]Content, len(r.Sessions))
+	return r.forEach(body, func(i int, s Session, data []byte) error {
+		content := s.MessageContent()
+		if content == nil {
+			return nil
+		}
+		if err := cbor.Unmarshal(data, content); err != nil {
+			return err
+		}
+		if err := s.VerifyMessage(Message{
+			From:    msg.From,
+			To:      msg.To,
+			Content: content,
+		}); err != nil {
+			return err
+		}
+		body.decoded[i] = content
+		return nil
+	})
+}
+
+// StoreMessage implements Round.
+//
+// - store the message of each session, which was decoded by VerifyMessage.
+func (r *Batch) StoreMessage(msg Message) error {
+	body, ok := msg.Content.(*batchMessage)
+	if !ok || body == nil || len(body.decoded) != len(r.Sessions) {
+		return ErrInvalidContent
+	}
+	return r.forEach(body, func(i int, s Session, _ []byte) error {
+		if body.decoded[i] == nil {
+			return nil
+		}
+		return s.StoreMessage(Message{
+			From:    msg.From,
+			To:      msg.To,
+			Content: body.decoded[i],
+		})
+	})
+}
+
+// forEach calls f in parallel for each session which has not finished, with its content from body.
+// The error of the first session which failed is returned.
+func (r *Batch) forEach(body *batchMessage, f func(i int, s Session, data []byte) error) error {
+	if len(body.Contents) != len(r.Sessions) {
+		return fmt.Errorf("batch: got %d sessions, expected %d", len(body.Contents), len(r.Sessions))
+	}
+	errs := r.Pool.Parallelize(len(r.Sessions), func(i int) interface{} {
+		s := r.Sessions[i]
+		if _, done := s.(*Output); done {
+			return nil
+		}
+		if err := f(i, s, body.Contents[i]); err != nil {
+			return fmt.Errorf("batch[%d]: %w", i, err)
+		}
+		return nil
+	})
+	for _, err := range errs {
+		if err != nil {
+			return err.(error)
+		}
+	}
+	return nil
+}
+
+type finalizedSession struct {
+	next     Session
+	messages []*Message
+	err      error
+}
+
+// Finalize implements Round
+//
+// - finalize all sessions in parallel, and send their messages together.
+// - abort if any session aborted, blaming the culprits it identified.
+// - output the combined result once all sessions have finished.
+func (r *Batch) Finalize(out chan<- *Message) (Session, error) {
+	results := r.Pool.Parallelize(len(r.Sessions), func(i int) interface{} {
+		s := r.Sessions[i]
+		if _, done := s.(*Output); done {
+			return finalizedSession{next: s}
+		}
+		sessionOut := make(chan *Message, s.N()+1)
+		next, err := s.Finalize(sessionOut)
+		close(sessionOut)
+		result := finalizedSession{next: next, err: err}
+		for msg := range sessionOut {
+			result.messages = append(result.messages, msg)
+		}
+		return result
+	})
+
+	sessions := make([]Session, len(r.Sessions))
+	for i, result := range results {
+		f := result.(finalizedSession)
+		if f.err != nil {
+			return r, fmt.Errorf("batch[%d]: %w", i, f.err)
+		}
+		if abort, ok := f.next.(*Abort); ok {
+			return r.AbortRound(fmt.Errorf("batch[%d]: %w", i, abort.Err), abort.Culprits...), nil
+		}
+		sessions[i] = f.next
+	}
+
+	var (
+		broadcast *batchBroadcastMessage
+		messages  = make(map[party.ID]*batchMessage, r.N())
+		outputs   = make([]interface{}, 0, len(sessions))
+	)
+	for i, result := range results {
+		f := result.(finalizedSession)
+		if output, ok := f.next.(*Output); ok {
+			outputs = append(outputs, output.Result)
+		}
+		for _, msg := range f.messages {
+			data, err := cbor.Marshal(msg.Content)
+			if err != nil {
+				return r, fmt.Errorf("batch[%d]: %w", i, err)
+			}
+			var body *batchMessage
+			if msg.Broadcast {
+				if broadcast == nil {
+					broadcast = &batchBroadcastMessage{batchMessage: r.newMessage(msg.Content.RoundNumber())}
+				}
+				body = &broadcast.batchMessage
+			} else {
+				if messages[msg.To] == nil {
+					m := r.newMessage(msg.Content.RoundNumber())
+					messages[msg.To] = &m
+				}
+				body = messages[msg.To]
+			}
+			body.Contents[i] = data
+		}
+	}
+
+	if len(outputs) == len(sessions) {
+		return r.ResultRound(r.output(outputs)), nil
+	}
+
+	next := &Batch{
+		Helper:   r.Helper,
+		Sessions: sessions,
+		output:   r.output,
+	}
+	number := next.Number()
+	for _, s := range sessions {
+		if _, done := s.(*Output); !done && s.Number() != number {
+			return r, fmt.Errorf("batch: sessions are in rounds %d and %d", number, s.Number())
+		}
+	}
+
+	if broadcast != nil {
+		broadcast.reliable = next.reliable()
+		if err := r.BroadcastMessage(out, broadcast); err != nil {
+			return r, err
+		}
+	}
+	for to, msg := range messages {
+		if err := r.SendMessage(out, msg, to); err != nil {
+			return r, err
+		}
+	}
+	return next.withBroadcast(), nil
+}
+
+// withBroadcast returns r as a BroadcastBatch if one of its sessions expects a broadcast message.
+func (r *Batch) withBroadcast() Session {
+	for _, s := range r.Sessions {
+		if _, ok := s.(BroadcastRound); ok {
+			return &BroadcastBatch{r}
+		}
+	}
+	return r
+}
+
+func (r *Batch) newMessage(number Number) batchMessage {
+	return batchMessage{
+		number:   number,
+		Contents: make([][]byte, len(r.Sessions)),
+	}
+}
+
+// MessageContent implements Round.
+func (r *Batch) MessageContent() Content {
+	for _, s := range r.Sessions {
+		if s.MessageContent() != nil {
+			return &batchMessage{number: r.Number()}
+		}
+	}
+	return nil
+}
+
+// RoundNumber implements Content.
+func (m *batchMessage) RoundNumber() Number { return m.number }
+
+// Reliable implements BroadcastContent.
+func (m *batchBroadcastMessage) Reliable() bool { return m.reliable }
+
+// BroadcastContent implements BroadcastRound.
+func (r *BroadcastBatch) BroadcastContent() BroadcastContent {
+	return &batchBroadcastMessage{batchMessage: batchMessage{number: r.Number()}, reliable: r.reliable()}
+}
+
+// reliable returns true if the broadcast message of any session in this round requires reliable broadcast.
+func (r *Batch) reliable() bool {
+	for _, s := range r.Sessions {
+		if b, ok := s.(BroadcastRound); ok && b.BroadcastContent().Reliable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Number implements Round.
+//
+// It is the round of the sessions which have not finished.
+func (r *Batch) Number() Number {
+	for _, s := range r.Sessions {
+		if _, done := s.(*Output); !done {
+			return s.Number()
+		}
+	}
+	return 0
+}
@@ -1,8 +1,11 @@
 package round
 
 import (
+	"bytes"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"sync"
 
@@ -30,6 +33,8 @@ type Helper struct {
 	ssid []byte
 
 	hash *hash.Hash
+	// updates contains the values written with UpdateHashState, so that the hash state can be restored.
+	updates []hash.BytesWithDomain
 
 	mtx sync.Mutex
 }
@@ -133,7 +138,13 @@ func (h *Helper) HashForID(id party.ID) *hash.Hash {
 func (h *Helper) UpdateHashState(value hash.WriterToWithDomain) {
 	h.mtx.Lock()
 	defer h.mtx.Unlock()
-	_ = h.hash.WriteAny(value)
+	var buf bytes.Buffer
+	if _, err := value.WriteTo(&buf); err != nil {
+		return
+	}
+	update := hash.BytesWithDomain{TheDomain: value.Domain(), Bytes: buf.Bytes()}
+	_ = h.hash.WriteAny(update)
+	h.updates = append(h.updates, update)
 }
 
 // BroadcastMessage constructs a Message from the broadcast Content, and sets the header correctly.
@@ -220,5 +231,14 @@ func (h *Helper) Threshold() int { return h.info.Threshold }
 // N returns the number of participants.
 func (h *Helper) N() int { return len(h.info.PartyIDs) }
 
+// Rand returns the source of randomness for this protocol execution,
+// which defaults to crypto/rand.Reader.
+func (h *Helper) Rand() io.Reader {
+	if h.info.Rand == nil {
+		return rand.Reader
+	}
+	return h.info.Rand
+}
+
 // Group returns the curve used for this protocol.
 func (h *Helper) Group() curve.Curve { return h.info.Group }
@@ -1,6 +1,8 @@
 package round
 
 import (
+	"io"
+
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
@@ -19,6 +21,21 @@ type Info struct {
 	Threshold int
 	// Group returns the group used for this protocol execution.
 	Group curve.Curve
+	// Rand is the source of randomness for this protocol execution.
+	// If nil, crypto/rand.Reader is used.
+	Rand io.Reader
+}
+
+// Option modifies the Info of a protocol execution before it is started.
+type Option func(*Info)
+
+// Apply applies all options to info.
+func (info *Info) Apply(opts ...Option) {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(info)
+		}
+	}
 }
 
 // Session represents the current execution of a round-based protocol.
@@ -46,4 +63,6 @@ type Session interface {
 	Threshold() int
 	// N returns the total number of parties participating in the protocol.
 	N() int
+	// Rand returns the source of randomness for this protocol execution.
+	Rand() io.Reader
 }
@@ -0,0 +1,156 @@
+package round
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// Snapshotter is implemented by the rounds of a protocol whose execution can be saved, and later resumed.
+type Snapshotter interface {
+	// Snapshot returns the state of the round, except for the hash state of the session.
+	// It is called when the round is returned by Finalize, before any message for this round is stored.
+	Snapshot() ([]byte, error)
+}
+
+// Restorer is implemented by the first round of a protocol whose rounds implement Snapshotter.
+type Restorer interface {
+	// Restore returns the round with the given number, from the data returned by its Snapshot method.
+	// The receiver is the first round of a new execution, created with the same arguments as the saved one.
+	Restore(number Number, data []byte) (Session, error)
+}
+
+// ErrSnapshotUnsupported is returned by MarshalSnapshot when the round does not implement Snapshotter.
+var ErrSnapshotUnsupported = errors.New("round: protocol does not support snapshots")
+
+// snapshot is the serialized state of a round.
+type snapshot struct {
+	Number Number
+	// Hash contains all values written to the hash state of the session with UpdateHashState.
+	Hash  []hash.BytesWithDomain
+	Round []byte
+}
+
+// withHelper is implemented by all rounds embedding a Helper.
+type withHelper interface {
+	helper() *Helper
+}
+
+func (h *Helper) helper() *Helper { return h }
+
+// MarshalSnapshot returns the state of r, which must implement Snapshotter, along with the hash state of the session.
+func MarshalSnapshot(r Session) ([]byte, error) {
+	s, ok := r.(Snapshotter)
+	if !ok {
+		return nil, ErrSnapshotUnsupported
+	}
+	h, ok := r.(withHelper)
+	if !ok {
+		return nil, ErrSnapshotUnsupported
+	}
+	data, err := s.Snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("round %d: snapshot: %w", r.Number(), err)
+	}
+	helper := h.helper()
+	helper.mtx.Lock()
+	updates := append([]hash.BytesWithDomain{}, helper.updates...)
+	helper.mtx.Unlock()
+	return cbor.Marshal(&snapshot{
+		Number: r.Number(),
+		Hash:   updates,
+		Round:  data,
+	})
+}
+
+// UnmarshalSnapshot returns the round saved by MarshalSnapshot.
+// first is the first round of a new execution, created with the same arguments as the saved one,
+// and must implement Restorer.
+func UnmarshalSnapshot(first Session, data []byte) (Session, error) {
+	restorer, ok := first.(Restorer)
+	if !ok {
+		return nil, ErrSnapshotUnsupported
+	}
+	h, ok := first.(withHelper)
+	if !ok {
+		return nil, ErrSnapshotUnsupported
+	}
+	var s snapshot
+	if err := cbor.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("round: snapshot: %w", err)
+	}
+	helper := h.helper()
+	helper.mtx.Lock()
+	updated := len(helper.updates) > 0
+	helper.mtx.Unlock()
+	if updated {
+		return nil, errors.New("round: snapshot: the session has already been updated")
+	}
+	for i := range s.Hash {
+		helper.UpdateHashState(&s.Hash[i])
+	}
+	r, err := restorer.Restore(s.Number, s.Round)
+	if err != nil {
+		return nil, fmt.Errorf("round %d: restore: %w", s.Number, err)
+	}
+	if r.Number() != s.Number {
+		return nil, fmt.Errorf("round %d: restore: got round %d", s.Number, r.Number())
+	}
+	return r, nil
+}
+
+// MarshalScalars encodes each scalar of a map, for use in a snapshot.
+func MarshalScalars(scalars map[party.ID]curve.Scalar) (map[party.ID][]byte, error) {
+	out := make(map[party.ID][]byte, len(scalars))
+	for j, scalar := range scalars {
+		data, err := scalar.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out[j] = data
+	}
+	return out, nil
+}
+
+// UnmarshalScalars decodes the scalars encoded by MarshalScalars.
+func UnmarshalScalars(group curve.Curve, data map[party.ID][]byte) (map[party.ID]curve.Scalar, error) {
+	out := make(map[party.ID]curve.Scalar, len(data))
+	for j, d := range data {
+		scalar := group.NewScalar()
+		if err := scalar.UnmarshalBinary(d); err != nil {
+			return nil, fmt.Errorf("%s: %w", j, err)
+		}
+		out[j] = scalar
+	}
+	return out, nil
+}
+
+// MarshalPoints encodes each point of a map, for use in a snapshot.
+func MarshalPoints(points map[party.ID]curve.Point) (map[party.ID][]byte, error) {
+	out := make(map[party.ID][]byte, len(points))
+	for j, point := range points {
+		data, err := point.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out[j] = data
+	}
+	return out, nil
+}
+
+// UnmarshalPoints decodes the points encoded by MarshalPoints.
+func UnmarshalPoints(group curve.Curve, data map[party.ID][]byte) (map[party.ID]curve.Point, error) {
+	out := make(map[party.ID]curve.Point, len(data))
+	for j, d := range data {
+		point := group.NewPoint()
+		if err := point.UnmarshalBinary(d); err != nil {
+			return nil, fmt.Errorf("%s: %w", j, err)
+		}
+		out[j] = point
+	}
+	return out, nil
+}
@@ -20,7 +20,7 @@ func GenerateConfig(group curve.Curve, N, T int, source io.Reader, pl *pool.Pool
 	configs := make(map[party.ID]*config.Config, N)
 	public := make(map[party.ID]*config.Public, N)
 
-	f := polynomial.NewPolynomial(group, T, sample.Scalar(source, group))
+	f := polynomial.NewPolynomial(source, group, T, sample.Scalar(source, group))
 
 	rid, err := types.NewRID(source)
 	if err != nil {
@@ -32,7 +32,7 @@ func GenerateConfig(group curve.Curve, N, T int, source io.Reader, pl *pool.Pool
 	}
 
 	for _, pid := range partyIDs {
-		paillierSecret := paillier.NewSecretKey(pl)
+		paillierSecret := paillier.NewSecretKey(source, pl)
 		s, t, _ := sample.Pedersen(source, paillierSecret.Phi(), paillierSecret.N())
 		pedersenPublic := pedersen.New(paillierSecret.Modulus(), s, t)
 		elGamalSecret := sample.Scalar(source, group)
@@ -44,6 +44,8 @@ func EmptyPreSignature(group curve.Curve) *PreSignature {
 type SignatureShare = curve.Scalar
 
 // SignatureShare returns this party's share σᵢ = kᵢm+rχᵢ, where s = ∑ⱼσⱼ.
+//
+// The presignature must have been checked with Validate, which rejects groups that do not support ECDSA.
 func (sig *PreSignature) SignatureShare(hash []byte) curve.Scalar {
 	m := curve.FromHash(sig.Group(), hash)
 	r := sig.R.XScalar()
@@ -69,6 +71,13 @@ func (sig *PreSignature) Signature(shares map[party.ID]SignatureShare) *Signatur
 // It returns the list of parties whose shares are invalid.
 func (sig *PreSignature) VerifySignatureShares(shares map[party.ID]SignatureShare, hash []byte) (culprits []party.ID) {
 	r := sig.R.XScalar()
+	if r == nil {
+		// no share can be verified if the group does not support ECDSA
+		for j := range shares {
+			culprits = append(culprits, j)
+		}
+		return culprits
+	}
 	m := curve.FromHash(sig.Group(), hash)
 	for j, share := range shares {
 		Rj, Sj := sig.RBar.Points[j], sig.S.Points[j]
@@ -101,6 +110,9 @@ func (sig *PreSignature) Validate() error {
 	if sig.R.IsIdentity() {
 		return errors.New("presignature: R is identity")
 	}
+	if sig.R.XScalar() == nil {
+		return fmt.Errorf("presignature: group %s does not support ECDSA", sig.Group().Name())
+	}
 	if err := sig.ID.Validate(); err != nil {
 		return fmt.Errorf("presignature: %w", err)
 	}
@@ -108,3 +108,25 @@ func TestPreSignature_Fail(t *testing.T) {
 		}
 	}
 }
+
+func TestPreSignature_UnsupportedGroup(t *testing.T) {
+	group := curve.Edwards25519{}
+	message := []byte("HELLO WORLD")
+	_, X, preSignatures := NewPreSignatures(group, 2)
+	sigmaShares := make(map[party.ID]SignatureShare, len(preSignatures))
+	for id := range preSignatures {
+		sigmaShares[id] = group.NewScalar()
+	}
+	for _, preSignature := range preSignatures {
+		if err := preSignature.Validate(); err == nil {
+			t.Error("presignature without XScalar should be invalid")
+		}
+		if culprits := preSignature.VerifySignatureShares(sigmaShares, message); len(culprits) != len(sigmaShares) {
+			t.Error("shares without XScalar should all be invalid")
+		}
+	}
+	signature := Signature{R: group.NewBasePoint(), S: sample.Scalar(mrand.New(mrand.NewSource(1)), group)}
+	if signature.Verify(X, message) {
+		t.Error("signature without XScalar should not verify")
+	}
+}
@@ -0,0 +1,159 @@
+// Package presignstore keeps ECDSA presignatures, as produced by the CMP and Doerner protocols,
+// until they are used, and ensures that each of them is used for at most one message.
+package presignstore
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var (
+	// ErrNotFound is returned when a Store does not contain a presignature.
+	ErrNotFound = errors.New("presignstore: presignature not found")
+	// ErrConsumed is returned when a presignature was already consumed or expired, and must not be used again.
+	ErrConsumed = errors.New("presignstore: presignature already consumed")
+)
+
+// Store holds presignatures until they are used, and ensures that each is used for at most one message.
+// Signing two messages with the same presignature leaks the secret key.
+//
+// Presignatures are indexed by their ID, which is the same for all signers of a presignature.
+// Once a presignature was consumed or expired, its ID is remembered so that it cannot be stored again.
+type Store interface {
+	// Put stores a new presignature.
+	// ErrConsumed is returned if a presignature with the same ID was consumed or expired.
+	Put(preSignature *ecdsa.PreSignature) error
+
+	// Consume marks the presignature with the given ID as consumed, and returns it.
+	// This is atomic, so that the presignature is returned at most once, even if Consume is called concurrently.
+	// ErrConsumed is returned if it was already consumed or expired, and ErrNotFound if it was never stored.
+	Consume(id []byte) (*ecdsa.PreSignature, error)
+
+	// List returns the IDs of the presignatures generated by exactly the given signers
+	// which have not been consumed, from the oldest to the newest.
+	List(signers []party.ID) ([][]byte, error)
+
+	// Expire discards the presignatures generated by exactly the given signers which were stored before the given time,
+	// and returns how many were discarded.
+	Expire(signers []party.ID, before time.Time) (int, error)
+}
+
+type storedPreSignature struct {
+	preSignature *ecdsa.PreSignature
+	signers      party.IDSlice
+	stored       time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore is a Store which keeps the presignatures in memory, and loses them when the process exits.
+type MemoryStore struct {
+	available map[string]*storedPreSignature
+	consumed  map[string]struct{}
+	mtx       sync.Mutex
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		available: map[string]*storedPreSignature{},
+		consumed:  map[string]struct{}{},
+	}
+}
+
+// Put implements Store.
+func (s *MemoryStore) Put(preSignature *ecdsa.PreSignature) error {
+	if err := preSignature.Validate(); err != nil {
+		return err
+	}
+	key := string(preSignature.ID)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.consumed[key]; ok {
+		return ErrConsumed
+	}
+	if _, ok := s.available[key]; ok {
+		return fmt.Errorf("presignstore: presignature %x already stored", []byte(preSignature.ID))
+	}
+	s.available[key] = &storedPreSignature{
+		preSignature: preSignature,
+		signers:      preSignature.SignerIDs(),
+		stored:       time.Now(),
+	}
+	return nil
+}
+
+// Consume implements Store.
+func (s *MemoryStore) Consume(id []byte) (*ecdsa.PreSignature, error) {
+	key := string(id)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.consumed[key]; ok {
+		return nil, ErrConsumed
+	}
+	stored, ok := s.available[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	delete(s.available, key)
+	s.consumed[key] = struct{}{}
+	return stored.preSignature, nil
+}
+
+// List implements Store.
+func (s *MemoryStore) List(signers []party.ID) ([][]byte, error) {
+	ids := party.NewIDSlice(signers)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var matching []*storedPreSignature
+	for _, stored := range s.available {
+		if sameSigners(stored.signers, ids) {
+			matching = append(matching, stored)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool { return matching[i].stored.Before(matching[j].stored) })
+	list := make([][]byte, len(matching))
+	for i, stored := range matching {
+		list[i] = append([]byte(nil), stored.preSignature.ID...)
+	}
+	return list, nil
+}
+
+// Expire implements Store.
+func (s *MemoryStore) Expire(signers []party.ID, before time.Time) (int, error) {
+	ids := party.NewIDSlice(signers)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	expired := 0
+	for key, stored := range s.available {
+		if sameSigners(stored.signers, ids) && stored.stored.Before(before) {
+			delete(s.available, key)
+			s.consumed[key] = struct{}{}
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// sameSigners returns true if both sorted slices contain the same parties.
+func sameSigners(a, b party.IDSlice) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
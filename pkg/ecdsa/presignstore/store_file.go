@@ -0,0 +1,236 @@
+package presignstore
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// consumedSuffix is appended to the file name of a presignature once it is consumed or expired.
+const consumedSuffix = ".consumed"
+
+var _ Store = (*FileStore)(nil)
+
+// FileStore is a Store which keeps each presignature in its own file, in a single directory.
+//
+// A presignature is consumed by atomically renaming its file, so that the directory can be shared
+// by several processes on the same host. The file of a consumed presignature is emptied, and kept
+// so that the presignature cannot be stored again.
+// The directory is synced after a presignature is stored or consumed, so that a consumed presignature
+// does not become available again after a crash.
+type FileStore struct {
+	dir   string
+	group curve.Curve
+}
+
+// NewFileStore returns a FileStore for presignatures of the given group, in dir.
+// The directory is created if it does not exist.
+func NewFileStore(dir string, group curve.Curve) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("presignstore: %w", err)
+	}
+	return &FileStore{dir: dir, group: group}, nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(preSignature *ecdsa.PreSignature) error {
+	if err := preSignature.Validate(); err != nil {
+		return err
+	}
+	data, err := cbor.Marshal(preSignature)
+	if err != nil {
+		return fmt.Errorf("presignstore: %w", err)
+	}
+	path := s.path(preSignature.ID)
+	if s.consumed(path) {
+		return ErrConsumed
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("presignstore: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("presignstore: %w", err)
+	}
+
+	// unlike a rename, a link does not replace an existing presignature.
+	if err = os.Link(tmp.Name(), path); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("presignstore: presignature %x already stored", []byte(preSignature.ID))
+		}
+		return fmt.Errorf("presignstore: %w", err)
+	}
+	if err = s.sync(); err != nil {
+		return err
+	}
+	// the presignature may have been consumed by another process in the meantime.
+	if s.consumed(path) {
+		_ = os.Remove(path)
+		return ErrConsumed
+	}
+	return nil
+}
+
+// Consume implements Store.
+func (s *FileStore) Consume(id []byte) (*ecdsa.PreSignature, error) {
+	if len(id) == 0 {
+		return nil, ErrNotFound
+	}
+	path := s.path(id)
+	if err := s.discard(path); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path + consumedSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("presignstore: %w", err)
+	}
+	// the secret shares are not kept once the presignature was consumed.
+	if err = os.WriteFile(path+consumedSuffix, nil, 0o600); err != nil {
+		return nil, fmt.Errorf("presignstore: %w", err)
+	}
+	preSignature := ecdsa.EmptyPreSignature(s.group)
+	if err = cbor.Unmarshal(data, preSignature); err != nil {
+		return nil, fmt.Errorf("presignstore: %w", err)
+	}
+	return preSignature, nil
+}
+
+// List implements Store.
+func (s *FileStore) List(signers []party.ID) ([][]byte, error) {
+	stored, err := s.load(signers)
+	if err != nil {
+		return nil, err
+	}
+	list := make([][]byte, len(stored))
+	for i, stored := range stored {
+		list[i] = stored.preSignature.ID
+	}
+	return list, nil
+}
+
+// Expire implements Store.
+func (s *FileStore) Expire(signers []party.ID, before time.Time) (int, error) {
+	stored, err := s.load(signers)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, stored := range stored {
+		if !stored.stored.Before(before) {
+			break
+		}
+		path := s.path(stored.preSignature.ID)
+		if err = s.discard(path); err != nil {
+			if errors.Is(err, ErrConsumed) {
+				continue
+			}
+			return expired, err
+		}
+		if err = os.WriteFile(path+consumedSuffix, nil, 0o600); err != nil {
+			return expired, fmt.Errorf("presignstore: %w", err)
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// discard renames the file at path so that the presignature cannot be used again.
+// Since only one rename can succeed, ErrConsumed is returned to all other callers.
+func (s *FileStore) discard(path string) error {
+	if err := os.Rename(path, path+consumedSuffix); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("presignstore: %w", err)
+		}
+		if s.consumed(path) {
+			return ErrConsumed
+		}
+		return ErrNotFound
+	}
+	// the rename must be durable before the presignature is used.
+	return s.sync()
+}
+
+// sync commits the entries of the directory to stable storage.
+func (s *FileStore) sync() error {
+	dir, err := os.Open(s.dir)
+	if err != nil {
+		return fmt.Errorf("presignstore: %w", err)
+	}
+	err = dir.Sync()
+	if closeErr := dir.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("presignstore: sync %s: %w", s.dir, err)
+	}
+	return nil
+}
+
+// load returns the presignatures generated by exactly the given signers which have not been consumed,
+// from the oldest to the newest.
+func (s *FileStore) load(signers []party.ID) ([]*storedPreSignature, error) {
+	ids := party.NewIDSlice(signers)
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("presignstore: %w", err)
+	}
+	var matching []*storedPreSignature
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, consumedSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// the presignature was consumed in the meantime
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			continue
+		}
+		preSignature := ecdsa.EmptyPreSignature(s.group)
+		if err = cbor.Unmarshal(data, preSignature); err != nil {
+			return nil, fmt.Errorf("presignstore: %s: %w", name, err)
+		}
+		if signers := preSignature.SignerIDs(); sameSigners(signers, ids) {
+			matching = append(matching, &storedPreSignature{
+				preSignature: preSignature,
+				signers:      signers,
+				stored:       info.ModTime(),
+			})
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool { return matching[i].stored.Before(matching[j].stored) })
+	return matching, nil
+}
+
+// consumed returns true if the presignature stored at path was consumed or expired.
+func (s *FileStore) consumed(path string) bool {
+	_, err := os.Stat(path + consumedSuffix)
+	return err == nil
+}
+
+// path returns the path of the file containing the presignature with the given ID.
+func (s *FileStore) path(id []byte) string {
+	return filepath.Join(s.dir, hex.EncodeToString(id))
+}
@@ -0,0 +1,123 @@
+package presignstore
+
+import (
+	mrand "math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var (
+	group    = curve.Secp256k1{}
+	partyIDs = party.NewIDSlice([]party.ID{"a", "b", "c", "d"})
+	others   = partyIDs[:3]
+)
+
+// randomPreSignature returns a valid, but meaningless, presignature between the given signers.
+func randomPreSignature(rand *mrand.Rand, signers []party.ID) *ecdsa.PreSignature {
+	id, _ := types.NewRID(rand)
+	RBar := make(map[party.ID]curve.Point, len(signers))
+	S := make(map[party.ID]curve.Point, len(signers))
+	for _, j := range signers {
+		RBar[j] = sample.Scalar(rand, group).ActOnBase()
+		S[j] = sample.Scalar(rand, group).ActOnBase()
+	}
+	return &ecdsa.PreSignature{
+		ID:       id,
+		R:        sample.Scalar(rand, group).ActOnBase(),
+		RBar:     party.NewPointMap(RBar),
+		S:        party.NewPointMap(S),
+		KShare:   sample.Scalar(rand, group),
+		ChiShare: sample.Scalar(rand, group),
+	}
+}
+
+func TestStore(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(*testing.T) Store { return NewMemoryStore() },
+		"file": func(t *testing.T) Store {
+			s, err := NewFileStore(t.TempDir(), group)
+			require.NoError(t, err)
+			return s
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			rand := mrand.New(mrand.NewSource(1))
+			s := newStore(t)
+
+			first := randomPreSignature(rand, partyIDs)
+			second := randomPreSignature(rand, partyIDs)
+			other := randomPreSignature(rand, others)
+			require.NoError(t, s.Put(first))
+			// the file store orders presignatures by modification time
+			time.Sleep(10 * time.Millisecond)
+			require.NoError(t, s.Put(second))
+			require.NoError(t, s.Put(other))
+			assert.Error(t, s.Put(first), "a presignature should only be stored once")
+
+			ids, err := s.List(partyIDs)
+			require.NoError(t, err)
+			assert.Equal(t, [][]byte{first.ID, second.ID}, ids)
+			ids, err = s.List(others)
+			require.NoError(t, err)
+			assert.Equal(t, [][]byte{other.ID}, ids)
+
+			preSignature, err := s.Consume(first.ID)
+			require.NoError(t, err)
+			assert.True(t, first.R.Equal(preSignature.R))
+			assert.True(t, first.ChiShare.Equal(preSignature.ChiShare))
+			_, err = s.Consume(first.ID)
+			assert.ErrorIs(t, err, ErrConsumed)
+			assert.ErrorIs(t, s.Put(first), ErrConsumed, "a consumed presignature should not be stored again")
+			_, err = s.Consume(randomPreSignature(rand, partyIDs).ID)
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			expired, err := s.Expire(partyIDs, time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, 1, expired)
+			_, err = s.Consume(second.ID)
+			assert.ErrorIs(t, err, ErrConsumed)
+			ids, err = s.List(partyIDs)
+			require.NoError(t, err)
+			assert.Empty(t, ids)
+			ids, err = s.List(others)
+			require.NoError(t, err)
+			assert.Len(t, ids, 1, "expiring should only apply to the given signers")
+		})
+	}
+}
+
+func TestStoreConcurrentConsume(t *testing.T) {
+	s, err := NewFileStore(t.TempDir(), group)
+	require.NoError(t, err)
+	preSignature := randomPreSignature(mrand.New(mrand.NewSource(2)), partyIDs)
+	require.NoError(t, s.Put(preSignature))
+
+	var (
+		wg       sync.WaitGroup
+		mtx      sync.Mutex
+		consumed int
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Consume(preSignature.ID); err == nil {
+				mtx.Lock()
+				consumed++
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, consumed, "a presignature should be consumed exactly once")
+}
@@ -19,7 +19,7 @@ func (sig Signature) Verify(X curve.Point, hash []byte) bool {
 	group := X.Curve()
 
 	r := sig.R.XScalar()
-	if r.IsZero() || sig.S.IsZero() {
+	if r == nil || r.IsZero() || sig.S.IsZero() {
 		return false
 	}
 
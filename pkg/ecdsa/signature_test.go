@@ -1,7 +1,11 @@
 package ecdsa
 
 import (
+	stdecdsa "crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
 	"testing"
 
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
@@ -59,3 +63,32 @@ func TestSignature_Verify_Zero(t *testing.T) {
 		t.Error("zero R/S signature should not verify")
 	}
 }
+
+func TestSignature_VerifyP256(t *testing.T) {
+	group := curve.P256{}
+
+	hash := sha256.Sum256([]byte("hello"))
+	x := sample.Scalar(rand.Reader, group)
+	X := x.ActOnBase()
+	sig := NewSignature(x, hash[:], nil)
+	if !sig.Verify(X, hash[:]) {
+		t.Fatal("verify failed")
+	}
+
+	// the signature should also be accepted by the standard library
+	XBytes, err := X.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkX, pkY := elliptic.UnmarshalCompressed(elliptic.P256(), XBytes)
+	if pkX == nil {
+		t.Fatal("failed to decode public key")
+	}
+	pk := &stdecdsa.PublicKey{Curve: elliptic.P256(), X: pkX, Y: pkY}
+	rBytes, _ := sig.R.XScalar().MarshalBinary()
+	sBytes, _ := sig.S.MarshalBinary()
+	r, s := new(big.Int).SetBytes(rBytes), new(big.Int).SetBytes(sBytes)
+	if !stdecdsa.Verify(pk, hash[:], r, s) {
+		t.Error("crypto/ecdsa rejected the signature")
+	}
+}
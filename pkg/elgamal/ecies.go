@@ -0,0 +1,118 @@
+package elgamal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+)
+
+const (
+	// eciesKeySize is the size of the AES-256 key.
+	eciesKeySize = 32
+	// eciesNonceSize is the size of the AES-GCM nonce.
+	eciesNonceSize = 12
+)
+
+// ECIESCiphertext is the encryption of an arbitrary payload, using ElGamal as a key encapsulation mechanism.
+//
+// The payload is encrypted with AES-256-GCM, under a key derived from R and K = r⋅X = x⋅R,
+// where X = x⋅G is the public key of the recipient.
+type ECIESCiphertext struct {
+	// R = r⋅G is the ephemeral public key.
+	R curve.Point
+	// Data is the payload encrypted with AES-GCM, including the authentication tag.
+	Data []byte
+}
+
+// EmptyECIESCiphertext returns an ECIESCiphertext with the given group, ready for unmarshalling.
+func EmptyECIESCiphertext(group curve.Curve) *ECIESCiphertext {
+	return &ECIESCiphertext{R: group.NewPoint()}
+}
+
+// EncryptECIES encrypts plaintext to the public key, using randomness from rand.
+//
+// associatedData is authenticated but not encrypted, and must be passed again to decrypt.
+func EncryptECIES(rand io.Reader, public PublicKey, plaintext, associatedData []byte) (*ECIESCiphertext, error) {
+	if public == nil || public.IsIdentity() {
+		return nil, errors.New("elgamal: invalid public key")
+	}
+	r, R := sample.ScalarPointPair(rand, public.Curve())
+	aead, nonce, err := eciesAEAD(R, r.Act(public))
+	if err != nil {
+		return nil, err
+	}
+	return &ECIESCiphertext{
+		R:    R,
+		Data: aead.Seal(nil, nonce, plaintext, associatedData),
+	}, nil
+}
+
+// Decrypt returns the payload, using the private key x of the recipient.
+func (c *ECIESCiphertext) Decrypt(secret curve.Scalar, associatedData []byte) ([]byte, error) {
+	if !c.Valid() {
+		return nil, errors.New("elgamal: invalid ECIES ciphertext")
+	}
+	return c.Open(secret.Act(c.R), associatedData)
+}
+
+// Open returns the payload, given shared = x⋅R which was computed by the holder of the private key,
+// or jointly by the holders of its shares.
+func (c *ECIESCiphertext) Open(shared curve.Point, associatedData []byte) ([]byte, error) {
+	if !c.Valid() {
+		return nil, errors.New("elgamal: invalid ECIES ciphertext")
+	}
+	aead, nonce, err := eciesAEAD(c.R, shared)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, c.Data, associatedData)
+	if err != nil {
+		return nil, errors.New("elgamal: failed to authenticate ECIES ciphertext")
+	}
+	return plaintext, nil
+}
+
+// Valid returns true if the ciphertext passes basic validation.
+func (c *ECIESCiphertext) Valid() bool {
+	return c != nil && c.R != nil && !c.R.IsIdentity()
+}
+
+// eciesAEAD derives the AES-GCM key and nonce from the ephemeral key R and the shared point K.
+//
+// Since a new ephemeral key is used for each encryption, the key and nonce are never reused.
+func eciesAEAD(R, K curve.Point) (cipher.AEAD, []byte, error) {
+	if K.IsIdentity() {
+		return nil, nil, errors.New("elgamal: shared point is identity")
+	}
+	RBytes, err := R.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	KBytes, err := K.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	h := hash.New(
+		&hash.BytesWithDomain{TheDomain: "ECIES Ephemeral Key", Bytes: RBytes},
+		&hash.BytesWithDomain{TheDomain: "ECIES Shared Point", Bytes: KBytes},
+	)
+	keyAndNonce := make([]byte, eciesKeySize+eciesNonceSize)
+	if _, err = io.ReadFull(h.Digest(), keyAndNonce); err != nil {
+		return nil, nil, err
+	}
+
+	block, err := aes.NewCipher(keyAndNonce[:eciesKeySize])
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, keyAndNonce[eciesKeySize:], nil
+}
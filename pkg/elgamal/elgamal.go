@@ -0,0 +1,119 @@
+// Package elgamal implements ElGamal encryption over the curves of pkg/math/curve.
+//
+// Messages can be encrypted to any public key, such as the PublicPoint() of a cmp.Config or the
+// PublicKey of a frost.Config, in which case the holders of the shares can decrypt them jointly,
+// without reconstructing the private key (see protocols/ecdh).
+//
+// Encrypt encrypts a scalar "in the exponent", which is used by the zero-knowledge proofs of CMP,
+// but cannot be decrypted in general. EncryptPoint encrypts a point, and EncryptECIES
+// uses ElGamal as a key encapsulation mechanism to encrypt arbitrary payloads with AES-GCM.
+package elgamal
+
+import (
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+)
+
+type (
+	PublicKey = curve.Point
+	Nonce     = curve.Scalar
+)
+
+// Ciphertext is an ElGamal ciphertext.
+type Ciphertext struct {
+	// L = nonce⋅G
+	L curve.Point
+	// M = message⋅G + nonce⋅public
+	M curve.Point
+}
+
+// Encrypt returns the encryption of `message` as (L=nonce⋅G, M=message⋅G + nonce⋅public), as well as the `nonce`,
+// which is sampled from rand.
+func Encrypt(rand io.Reader, public PublicKey, message curve.Scalar) (*Ciphertext, Nonce) {
+	group := public.Curve()
+	nonce := sample.Scalar(rand, group)
+	L := nonce.ActOnBase()
+	M := message.ActOnBase().Add(nonce.Act(public))
+	return &Ciphertext{
+		L: L,
+		M: M,
+	}, nonce
+}
+
+// EncryptPoint returns the encryption of `message` as (L=nonce⋅G, M=message + nonce⋅public), as well as the `nonce`,
+// which is sampled from rand.
+func EncryptPoint(rand io.Reader, public PublicKey, message curve.Point) (*Ciphertext, Nonce) {
+	group := public.Curve()
+	nonce := sample.Scalar(rand, group)
+	L := nonce.ActOnBase()
+	M := message.Add(nonce.Act(public))
+	return &Ciphertext{
+		L: L,
+		M: M,
+	}, nonce
+}
+
+// Decrypt returns M - secret⋅L, where secret is the private key matching the public key of the encryption.
+//
+// For a ciphertext produced by Encrypt, this returns message⋅G.
+func (c *Ciphertext) Decrypt(secret curve.Scalar) curve.Point {
+	return c.Open(secret.Act(c.L))
+}
+
+// Open returns M - shared, where shared = secret⋅L was computed by the holder of the private key,
+// or jointly by the holders of its shares.
+func (c *Ciphertext) Open(shared curve.Point) curve.Point {
+	return c.M.Sub(shared)
+}
+
+// Valid returns true if the ciphertext passes basic validation.
+func (c *Ciphertext) Valid() bool {
+	if c == nil || c.L == nil || c.L.IsIdentity() ||
+		c.M == nil || c.M.IsIdentity() {
+		return false
+	}
+	return true
+}
+
+// Empty returns a Ciphertext with the given group, ready for unmarshalling.
+func Empty(group curve.Curve) *Ciphertext {
+	return &Ciphertext{
+		L: group.NewPoint(),
+		M: group.NewPoint(),
+	}
+}
+
+// WriteTo implements io.WriterTo interface.
+func (c *Ciphertext) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	var n int
+
+	buf, err := c.L.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err = w.Write(buf)
+	total += int64(n)
+	if err != nil {
+		return total, err
+	}
+
+	buf, err = c.M.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err = w.Write(buf)
+	total += int64(n)
+	if err != nil {
+		return total, err
+	}
+
+	return total, nil
+}
+
+// Domain implements hash.WriterToWithDomain.
+func (Ciphertext) Domain() string {
+	return "ElGamal Ciphertext"
+}
@@ -0,0 +1,60 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+)
+
+func TestEncryptPoint(t *testing.T) {
+	for _, group := range []curve.Curve{curve.Secp256k1{}, curve.P256{}} {
+		secret, public := sample.ScalarPointPair(rand.Reader, group)
+		_, message := sample.ScalarPointPair(rand.Reader, group)
+
+		ciphertext, _ := EncryptPoint(rand.Reader, public, message)
+		require.True(t, ciphertext.Valid())
+		assert.True(t, message.Equal(ciphertext.Decrypt(secret)))
+
+		m := sample.Scalar(rand.Reader, group)
+		ciphertext, _ = Encrypt(rand.Reader, public, m)
+		assert.True(t, m.ActOnBase().Equal(ciphertext.Decrypt(secret)))
+	}
+}
+
+func TestECIES(t *testing.T) {
+	group := curve.Secp256k1{}
+	secret, public := sample.ScalarPointPair(rand.Reader, group)
+	plaintext := []byte("vault backup")
+	associatedData := []byte("backup-1")
+
+	ciphertext, err := EncryptECIES(rand.Reader, public, plaintext, associatedData)
+	require.NoError(t, err)
+
+	data, err := cbor.Marshal(ciphertext)
+	require.NoError(t, err)
+	ciphertext2 := EmptyECIESCiphertext(group)
+	require.NoError(t, cbor.Unmarshal(data, ciphertext2))
+
+	decrypted, err := ciphertext2.Decrypt(secret, associatedData)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	_, err = ciphertext2.Decrypt(secret, []byte("backup-2"))
+	assert.Error(t, err, "decryption with different associated data should fail")
+
+	otherSecret := sample.Scalar(rand.Reader, group)
+	_, err = ciphertext2.Decrypt(otherSecret, associatedData)
+	assert.Error(t, err, "decryption with a different key should fail")
+
+	ciphertext2.Data[0] ^= 1
+	_, err = ciphertext2.Decrypt(secret, associatedData)
+	assert.Error(t, err, "decryption of a modified payload should fail")
+
+	_, err = EncryptECIES(rand.Reader, group.NewPoint(), plaintext, nil)
+	assert.Error(t, err, "encryption to the identity should fail")
+}
@@ -2,7 +2,6 @@ package hash
 
 import (
 	"bytes"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
@@ -69,11 +68,12 @@ func (d Decommitment) Validate() error {
 
 // Commit creates a commitment to data, and returns a commitment hash, and a decommitment string such that
 // commitment = h(data, decommitment).
-func (hash *Hash) Commit(data ...interface{}) (Commitment, Decommitment, error) {
+// The decommitment is sampled from rand.
+func (hash *Hash) Commit(rand io.Reader, data ...interface{}) (Commitment, Decommitment, error) {
 	var err error
 	decommitment := Decommitment(make([]byte, params.SecBytes))
 
-	if _, err = rand.Read(decommitment); err != nil {
+	if _, err = io.ReadFull(rand, decommitment); err != nil {
 		return nil, nil, fmt.Errorf("hash.Commit: failed to generate decommitment: %w", err)
 	}
 
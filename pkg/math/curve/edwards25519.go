@@ -0,0 +1,276 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"github.com/cronokirby/saferith"
+)
+
+// Edwards25519 is the prime order subgroup of the twisted Edwards curve used by Ed25519.
+type Edwards25519 struct{}
+
+func (Edwards25519) NewPoint() Point {
+	return &Edwards25519Point{value: *edwards25519.NewIdentityPoint()}
+}
+
+func (Edwards25519) NewBasePoint() Point {
+	return &Edwards25519Point{value: *edwards25519.NewGeneratorPoint()}
+}
+
+func (Edwards25519) NewScalar() Scalar {
+	return &Edwards25519Scalar{value: *edwards25519.NewScalar()}
+}
+
+func (Edwards25519) ScalarBits() int {
+	return 253
+}
+
+func (Edwards25519) SafeScalarBytes() int {
+	return 64
+}
+
+var edwards25519OrderNat, _ = new(saferith.Nat).SetHex("1000000000000000000000000000000014DEF9DEA2F79CD65812631A5CF5D3ED")
+var edwards25519Order = saferith.ModulusFromNat(edwards25519OrderNat)
+var edwards25519HalfOrder = new(saferith.Nat).Rsh(edwards25519OrderNat, 1, edwards25519Order.BitLen())
+
+func (Edwards25519) Order() *saferith.Modulus {
+	return edwards25519Order
+}
+
+func (Edwards25519) Name() string {
+	return "edwards25519"
+}
+
+// Edwards25519Scalar is an element of the scalar field of Edwards25519.
+//
+// To satisfy the Scalar interface, the binary encoding is Big Endian, which is the reverse
+// of the Little Endian encoding used by Ed25519. Use BytesLE for the latter.
+type Edwards25519Scalar struct {
+	value edwards25519.Scalar
+}
+
+func edwards25519CastScalar(generic Scalar) *Edwards25519Scalar {
+	out, ok := generic.(*Edwards25519Scalar)
+	if !ok {
+		panic(fmt.Sprintf("failed to convert to edwards25519Scalar: %v", generic))
+	}
+	return out
+}
+
+// reverse returns a reversed copy of data, used to switch endianness.
+func reverse(data []byte) []byte {
+	out := make([]byte, len(data))
+	for i := range data {
+		out[len(data)-1-i] = data[i]
+	}
+	return out
+}
+
+func (*Edwards25519Scalar) Curve() Curve {
+	return Edwards25519{}
+}
+
+// BytesLE returns the canonical 32 byte Little Endian encoding of this Scalar, as used by Ed25519.
+func (s *Edwards25519Scalar) BytesLE() []byte {
+	return s.value.Bytes()
+}
+
+// SetBytesLE sets this Scalar from its canonical 32 byte Little Endian encoding.
+func (s *Edwards25519Scalar) SetBytesLE(data []byte) (*Edwards25519Scalar, error) {
+	if _, err := s.value.SetCanonicalBytes(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetUniformBytesLE sets this Scalar by reducing 64 Little Endian bytes modulo the group order,
+// as done with the output of SHA-512 in Ed25519.
+func (s *Edwards25519Scalar) SetUniformBytesLE(data []byte) (*Edwards25519Scalar, error) {
+	if _, err := s.value.SetUniformBytes(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Edwards25519Scalar) MarshalBinary() ([]byte, error) {
+	return reverse(s.value.Bytes()), nil
+}
+
+func (s *Edwards25519Scalar) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return fmt.Errorf("invalid length for edwards25519 scalar: %d", len(data))
+	}
+	if _, err := s.value.SetCanonicalBytes(reverse(data)); err != nil {
+		return errors.New("invalid bytes for edwards25519 scalar")
+	}
+	return nil
+}
+
+func (s *Edwards25519Scalar) Add(that Scalar) Scalar {
+	other := edwards25519CastScalar(that)
+
+	s.value.Add(&s.value, &other.value)
+	return s
+}
+
+func (s *Edwards25519Scalar) Sub(that Scalar) Scalar {
+	other := edwards25519CastScalar(that)
+
+	s.value.Subtract(&s.value, &other.value)
+	return s
+}
+
+func (s *Edwards25519Scalar) Mul(that Scalar) Scalar {
+	other := edwards25519CastScalar(that)
+
+	s.value.Multiply(&s.value, &other.value)
+	return s
+}
+
+func (s *Edwards25519Scalar) Invert() Scalar {
+	s.value.Invert(&s.value)
+	return s
+}
+
+func (s *Edwards25519Scalar) Negate() Scalar {
+	s.value.Negate(&s.value)
+	return s
+}
+
+func (s *Edwards25519Scalar) IsOverHalfOrder() bool {
+	gt, _, _ := new(saferith.Nat).SetBytes(reverse(s.value.Bytes())).Cmp(edwards25519HalfOrder)
+	return gt == 1
+}
+
+func (s *Edwards25519Scalar) Equal(that Scalar) bool {
+	other := edwards25519CastScalar(that)
+
+	return s.value.Equal(&other.value) == 1
+}
+
+func (s *Edwards25519Scalar) IsZero() bool {
+	return s.value.Equal(edwards25519.NewScalar()) == 1
+}
+
+func (s *Edwards25519Scalar) Set(that Scalar) Scalar {
+	other := edwards25519CastScalar(that)
+
+	s.value.Set(&other.value)
+	return s
+}
+
+func (s *Edwards25519Scalar) SetNat(x *saferith.Nat) Scalar {
+	reduced := new(saferith.Nat).Mod(x, edwards25519Order)
+	data := make([]byte, 32)
+	reduced.FillBytes(data)
+	if _, err := s.value.SetCanonicalBytes(reverse(data)); err != nil {
+		panic(fmt.Sprintf("edwards25519Scalar.SetNat: %v", err))
+	}
+	return s
+}
+
+func (s *Edwards25519Scalar) Act(that Point) Point {
+	other := edwards25519CastPoint(that)
+	out := new(Edwards25519Point)
+	out.value.ScalarMult(&s.value, &other.value)
+	return out
+}
+
+func (s *Edwards25519Scalar) ActOnBase() Point {
+	out := new(Edwards25519Point)
+	out.value.ScalarBaseMult(&s.value)
+	return out
+}
+
+// Edwards25519Point is an element of the prime order subgroup of Edwards25519.
+//
+// Points use the 32 byte encoding of RFC 8032. Decoding rejects points with a torsion component.
+type Edwards25519Point struct {
+	value edwards25519.Point
+}
+
+func edwards25519CastPoint(generic Point) *Edwards25519Point {
+	out, ok := generic.(*Edwards25519Point)
+	if !ok {
+		panic(fmt.Sprintf("failed to convert to edwards25519Point: %v", generic))
+	}
+	return out
+}
+
+func (*Edwards25519Point) Curve() Curve {
+	return Edwards25519{}
+}
+
+func (p *Edwards25519Point) MarshalBinary() ([]byte, error) {
+	return p.value.Bytes(), nil
+}
+
+// edwards25519OrderMinusOne is ℓ - 1, used to check membership in the prime order subgroup.
+var edwards25519OrderMinusOne = func() *edwards25519.Scalar {
+	one := make([]byte, 32)
+	one[0] = 1
+	minusOne, _ := edwards25519.NewScalar().SetCanonicalBytes(one)
+	return minusOne.Negate(minusOne)
+}()
+
+func (p *Edwards25519Point) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return fmt.Errorf("invalid length for edwards25519Point: %d", len(data))
+	}
+	if _, err := p.value.SetBytes(data); err != nil {
+		return fmt.Errorf("edwards25519Point.UnmarshalBinary: %w", err)
+	}
+	// [ℓ]P = [ℓ - 1]P + P is the identity if and only if P is in the prime order subgroup.
+	check := new(edwards25519.Point).ScalarMult(edwards25519OrderMinusOne, &p.value)
+	check.Add(check, &p.value)
+	if check.Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return errors.New("edwards25519Point.UnmarshalBinary: point is not in the prime order subgroup")
+	}
+	return nil
+}
+
+func (p *Edwards25519Point) Add(that Point) Point {
+	other := edwards25519CastPoint(that)
+
+	out := new(Edwards25519Point)
+	out.value.Add(&p.value, &other.value)
+	return out
+}
+
+func (p *Edwards25519Point) Sub(that Point) Point {
+	other := edwards25519CastPoint(that)
+
+	out := new(Edwards25519Point)
+	out.value.Subtract(&p.value, &other.value)
+	return out
+}
+
+func (p *Edwards25519Point) Set(that Point) Point {
+	other := edwards25519CastPoint(that)
+
+	p.value.Set(&other.value)
+	return p
+}
+
+func (p *Edwards25519Point) Negate() Point {
+	out := new(Edwards25519Point)
+	out.value.Negate(&p.value)
+	return out
+}
+
+func (p *Edwards25519Point) Equal(that Point) bool {
+	other := edwards25519CastPoint(that)
+
+	return p.value.Equal(&other.value) == 1
+}
+
+func (p *Edwards25519Point) IsIdentity() bool {
+	return p == nil || p.value.Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// XScalar is not available for Edwards25519, since it is not used with ECDSA.
+func (*Edwards25519Point) XScalar() Scalar {
+	return nil
+}
@@ -0,0 +1,253 @@
+package curve
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"filippo.io/nistec"
+	"github.com/cronokirby/saferith"
+)
+
+// P256 is the NIST P-256 curve, also known as secp256r1 or prime256v1.
+type P256 struct{}
+
+func (P256) NewPoint() Point {
+	return &P256Point{value: nistec.NewP256Point()}
+}
+
+func (P256) NewBasePoint() Point {
+	return &P256Point{value: nistec.NewP256Point().SetGenerator()}
+}
+
+func (P256) NewScalar() Scalar {
+	return &P256Scalar{value: new(saferith.Nat).Resize(p256Order.BitLen())}
+}
+
+func (P256) ScalarBits() int {
+	return 256
+}
+
+// SafeScalarBytes returns 64, since the order of P-256 is far enough from 2^256
+// that reducing 32 random bytes would produce biased scalars.
+func (P256) SafeScalarBytes() int {
+	return 64
+}
+
+var p256OrderNat, _ = new(saferith.Nat).SetHex("FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551")
+var p256Order = saferith.ModulusFromNat(p256OrderNat)
+var p256HalfOrder = new(saferith.Nat).Rsh(p256OrderNat, 1, p256Order.BitLen())
+
+func (P256) Order() *saferith.Modulus {
+	return p256Order
+}
+
+func (P256) Name() string {
+	return "P-256"
+}
+
+// P256Scalar is an element of the scalar field of P-256.
+//
+// All arithmetic is performed on saferith.Nat, so it runs in constant time.
+type P256Scalar struct {
+	value *saferith.Nat
+}
+
+func p256CastScalar(generic Scalar) *P256Scalar {
+	out, ok := generic.(*P256Scalar)
+	if !ok {
+		panic(fmt.Sprintf("failed to convert to p256Scalar: %v", generic))
+	}
+	return out
+}
+
+// nat returns the value of s, treating an uninitialized scalar as 0.
+func (s *P256Scalar) nat() *saferith.Nat {
+	if s.value == nil {
+		s.value = new(saferith.Nat).Resize(p256Order.BitLen())
+	}
+	return s.value
+}
+
+func (*P256Scalar) Curve() Curve {
+	return P256{}
+}
+
+func (s *P256Scalar) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 32)
+	s.nat().FillBytes(out)
+	return out, nil
+}
+
+func (s *P256Scalar) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return fmt.Errorf("invalid length for p256 scalar: %d", len(data))
+	}
+	value := new(saferith.Nat).SetBytes(data)
+	if _, _, lt := value.CmpMod(p256Order); lt != 1 {
+		return errors.New("invalid bytes for p256 scalar")
+	}
+	s.value = value.Resize(p256Order.BitLen())
+	return nil
+}
+
+func (s *P256Scalar) Add(that Scalar) Scalar {
+	other := p256CastScalar(that)
+
+	s.value = s.nat().ModAdd(s.nat(), other.nat(), p256Order)
+	return s
+}
+
+func (s *P256Scalar) Sub(that Scalar) Scalar {
+	other := p256CastScalar(that)
+
+	s.value = s.nat().ModSub(s.nat(), other.nat(), p256Order)
+	return s
+}
+
+func (s *P256Scalar) Mul(that Scalar) Scalar {
+	other := p256CastScalar(that)
+
+	s.value = s.nat().ModMul(s.nat(), other.nat(), p256Order)
+	return s
+}
+
+func (s *P256Scalar) Invert() Scalar {
+	s.value = s.nat().ModInverse(s.nat(), p256Order)
+	return s
+}
+
+func (s *P256Scalar) Negate() Scalar {
+	s.value = s.nat().ModNeg(s.nat(), p256Order)
+	return s
+}
+
+func (s *P256Scalar) IsOverHalfOrder() bool {
+	gt, _, _ := s.nat().Cmp(p256HalfOrder)
+	return gt == 1
+}
+
+func (s *P256Scalar) Equal(that Scalar) bool {
+	other := p256CastScalar(that)
+
+	return s.nat().Eq(other.nat()) == 1
+}
+
+func (s *P256Scalar) IsZero() bool {
+	return s.nat().EqZero() == 1
+}
+
+func (s *P256Scalar) Set(that Scalar) Scalar {
+	other := p256CastScalar(that)
+
+	s.value = new(saferith.Nat).SetNat(other.nat())
+	return s
+}
+
+func (s *P256Scalar) SetNat(x *saferith.Nat) Scalar {
+	s.value = new(saferith.Nat).Mod(x, p256Order)
+	return s
+}
+
+func (s *P256Scalar) Act(that Point) Point {
+	other := p256CastPoint(that)
+	scalar, _ := s.MarshalBinary()
+	out := nistec.NewP256Point()
+	if _, err := out.ScalarMult(other.point(), scalar); err != nil {
+		panic(fmt.Sprintf("p256Scalar.Act: %v", err))
+	}
+	return &P256Point{value: out}
+}
+
+func (s *P256Scalar) ActOnBase() Point {
+	scalar, _ := s.MarshalBinary()
+	out := nistec.NewP256Point()
+	if _, err := out.ScalarBaseMult(scalar); err != nil {
+		panic(fmt.Sprintf("p256Scalar.ActOnBase: %v", err))
+	}
+	return &P256Point{value: out}
+}
+
+// P256Point is a point on the P-256 curve.
+//
+// Points are encoded using SEC1 compressed form, with the identity encoded as a single 0 byte.
+type P256Point struct {
+	value *nistec.P256Point
+}
+
+func p256CastPoint(generic Point) *P256Point {
+	out, ok := generic.(*P256Point)
+	if !ok {
+		panic(fmt.Sprintf("failed to convert to p256Point: %v", generic))
+	}
+	return out
+}
+
+// point returns the underlying point, treating an uninitialized point as the identity.
+func (p *P256Point) point() *nistec.P256Point {
+	if p.value == nil {
+		p.value = nistec.NewP256Point()
+	}
+	return p.value
+}
+
+func (*P256Point) Curve() Curve {
+	return P256{}
+}
+
+func (p *P256Point) MarshalBinary() ([]byte, error) {
+	return p.point().BytesCompressed(), nil
+}
+
+func (p *P256Point) UnmarshalBinary(data []byte) error {
+	if len(data) != 33 && len(data) != 1 {
+		return fmt.Errorf("invalid length for p256Point: %d", len(data))
+	}
+	value, err := nistec.NewP256Point().SetBytes(data)
+	if err != nil {
+		return fmt.Errorf("p256Point.UnmarshalBinary: %w", err)
+	}
+	p.value = value
+	return nil
+}
+
+func (p *P256Point) Add(that Point) Point {
+	other := p256CastPoint(that)
+
+	return &P256Point{value: nistec.NewP256Point().Add(p.point(), other.point())}
+}
+
+func (p *P256Point) Sub(that Point) Point {
+	return p.Add(that.Negate())
+}
+
+func (p *P256Point) Set(that Point) Point {
+	other := p256CastPoint(that)
+
+	p.value = nistec.NewP256Point().Set(other.point())
+	return p
+}
+
+func (p *P256Point) Negate() Point {
+	return &P256Point{value: nistec.NewP256Point().Negate(p.point())}
+}
+
+func (p *P256Point) Equal(that Point) bool {
+	other := p256CastPoint(that)
+
+	return subtle.ConstantTimeCompare(p.point().Bytes(), other.point().Bytes()) == 1
+}
+
+func (p *P256Point) IsIdentity() bool {
+	return p == nil || len(p.point().Bytes()) == 1
+}
+
+func (p *P256Point) XScalar() Scalar {
+	out := P256{}.NewScalar()
+	// the identity has no affine x coordinate, we map it to 0, like secp256k1 does.
+	x, err := p.point().BytesX()
+	if err != nil {
+		return out
+	}
+	return out.SetNat(new(saferith.Nat).SetBytes(x))
+}
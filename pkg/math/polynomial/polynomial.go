@@ -1,8 +1,11 @@
 package polynomial
 
 import (
-	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
 )
@@ -14,8 +17,8 @@ type Polynomial struct {
 }
 
 // NewPolynomial generates a Polynomial f(X) = secret + a₁⋅X + … + aₜ⋅Xᵗ,
-// with coefficients in ℤₚ, and degree t.
-func NewPolynomial(group curve.Curve, degree int, constant curve.Scalar) *Polynomial {
+// with coefficients in ℤₚ sampled from rand, and degree t.
+func NewPolynomial(rand io.Reader, group curve.Curve, degree int, constant curve.Scalar) *Polynomial {
 	polynomial := &Polynomial{
 		group:        group,
 		coefficients: make([]curve.Scalar, degree+1),
@@ -28,7 +31,7 @@ func NewPolynomial(group curve.Curve, degree int, constant curve.Scalar) *Polyno
 	polynomial.coefficients[0] = constant
 
 	for i := 1; i <= degree; i++ {
-		polynomial.coefficients[i] = sample.Scalar(rand.Reader, group)
+		polynomial.coefficients[i] = sample.Scalar(rand, group)
 	}
 
 	return polynomial
@@ -59,3 +62,49 @@ func (p *Polynomial) Constant() curve.Scalar {
 func (p *Polynomial) Degree() uint32 {
 	return uint32(len(p.coefficients)) - 1
 }
+
+type rawPolynomialData struct {
+	Coefficients []curve.Scalar
+}
+
+// EmptyPolynomial returns an empty Polynomial over the given group, ready for unmarshalling.
+func EmptyPolynomial(group curve.Curve) *Polynomial {
+	return &Polynomial{group: group}
+}
+
+func (p *Polynomial) MarshalBinary() ([]byte, error) {
+	data, err := cbor.Marshal(rawPolynomialData{Coefficients: p.coefficients})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(out, uint32(len(p.coefficients)))
+	copy(out[4:], data)
+	return out, nil
+}
+
+func (p *Polynomial) UnmarshalBinary(data []byte) error {
+	if p == nil || p.group == nil {
+		return errors.New("can't unmarshal Polynomial with no group")
+	}
+	if len(data) < 4 {
+		return errors.New("polynomial: data too short")
+	}
+	size := binary.BigEndian.Uint32(data)
+	if size == 0 || int(size) > len(data) {
+		return errors.New("polynomial: invalid number of coefficients")
+	}
+	coefficients := make([]curve.Scalar, int(size))
+	for i := range coefficients {
+		coefficients[i] = p.group.NewScalar()
+	}
+	raw := rawPolynomialData{Coefficients: coefficients}
+	if err := cbor.Unmarshal(data[4:], &raw); err != nil {
+		return err
+	}
+	if len(raw.Coefficients) != int(size) {
+		return errors.New("polynomial: invalid number of coefficients")
+	}
+	p.coefficients = raw.Coefficients
+	return nil
+}
@@ -18,7 +18,7 @@ func TestPolynomial_Constant(t *testing.T) {
 
 	deg := 10
 	secret := sample.Scalar(rand.Reader, group)
-	poly := NewPolynomial(group, deg, secret)
+	poly := NewPolynomial(rand.Reader, group, deg, secret)
 	require.True(t, poly.Constant().Equal(secret))
 }
 
@@ -41,3 +41,18 @@ func TestPolynomial_Evaluate(t *testing.T) {
 		assert.True(t, expectedResult.Equal(computedResult))
 	}
 }
+
+func TestPolynomial_Marshal(t *testing.T) {
+	group := curve.Secp256k1{}
+
+	poly := NewPolynomial(rand.Reader, group, 5, sample.Scalar(rand.Reader, group))
+	data, err := poly.MarshalBinary()
+	require.NoError(t, err, "failed to Marshal")
+	poly2 := EmptyPolynomial(group)
+	require.NoError(t, poly2.UnmarshalBinary(data), "failed to Unmarshal")
+	require.Equal(t, poly.Degree(), poly2.Degree())
+	for i := range poly.coefficients {
+		assert.True(t, poly.coefficients[i].Equal(poly2.coefficients[i]), "should be the same")
+	}
+	assert.Error(t, poly2.UnmarshalBinary(data[:3]))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cronokirby/saferith"
 	"github.com/taurusgroup/multi-party-sig/internal/params"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/pool"
 )
 
@@ -19,6 +20,24 @@ func TestModN(t *testing.T) {
 	}
 }
 
+// countingReader counts the number of bytes read from it.
+type countingReader struct {
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.n += len(p)
+	return rand.Read(p)
+}
+
+func TestScalarP256(t *testing.T) {
+	r := &countingReader{}
+	_ = Scalar(r, curve.P256{})
+	if r.n <= 32 {
+		t.Errorf("Scalar read %d bytes for P-256, which biases the result", r.n)
+	}
+}
+
 const blumPrimeProbabilityIterations = 20
 
 func TestPaillier(t *testing.T) {
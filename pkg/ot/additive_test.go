@@ -11,9 +11,9 @@ import (
 	"github.com/taurusgroup/multi-party-sig/pkg/pool"
 )
 
-func runAdditiveOT(hash *hash.Hash, choices []byte, alpha [2]curve.Scalar, sendSetup *CorreOTSendSetup, receiveSetup *CorreOTReceiveSetup) (AdditiveOTSendResult, AdditiveOTReceiveResult, error) {
+func runAdditiveOT(hash *hash.Hash, choices []byte, alpha []curve.Scalar, sendSetup *CorreOTSendSetup, receiveSetup *CorreOTReceiveSetup) (AdditiveOTSendResult, AdditiveOTReceiveResult, error) {
 	sender := NewAdditiveOTSender(hash.Clone(), sendSetup, 8*len(choices), alpha)
-	receiver := NewAdditiveOTReceiver(hash.Clone(), receiveSetup, alpha[0].Curve(), choices)
+	receiver := NewAdditiveOTReceiver(rand.Reader, hash.Clone(), receiveSetup, alpha[0].Curve(), len(alpha), choices)
 	msgR1 := receiver.Round1()
 	msgS1, sendResult, err := sender.Round1(msgR1)
 	if err != nil {
@@ -40,9 +40,7 @@ func TestAdditiveOT(t *testing.T) {
 		_ = H.WriteAny([]byte{byte(i)})
 		choices := make([]byte, 11)
 		_, _ = rand.Read(choices)
-		var alpha [2]curve.Scalar
-		alpha[0] = sample.Scalar(rand.Reader, testGroup)
-		alpha[1] = sample.Scalar(rand.Reader, testGroup)
+		alpha := []curve.Scalar{sample.Scalar(rand.Reader, testGroup), sample.Scalar(rand.Reader, testGroup)}
 		sendResult, receiveResult, err := runAdditiveOT(H, choices, alpha, sendSetup, receiveSetup)
 		if err != nil {
 			t.Error(err)
@@ -70,9 +68,7 @@ func BenchmarkAdditiveOT(b *testing.B) {
 	b.StopTimer()
 	pl := pool.NewPool(0)
 	defer pl.TearDown()
-	var alpha [2]curve.Scalar
-	alpha[0] = sample.Scalar(rand.Reader, testGroup)
-	alpha[1] = sample.Scalar(rand.Reader, testGroup)
+	alpha := []curve.Scalar{sample.Scalar(rand.Reader, testGroup), sample.Scalar(rand.Reader, testGroup)}
 	sendSetup, receiveSetup, _ := runCorreOTSetup(pl, hash.New())
 	choices := make([]byte, 2*testGroup.ScalarBits()+2*params.StatParam)
 	_, _ = rand.Read(choices)
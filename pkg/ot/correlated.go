@@ -1,10 +1,12 @@
 package ot
 
 import (
-	"crypto/rand"
 	"errors"
+	"fmt"
+	"io"
 
 	"github.com/cronokirby/saferith"
+	"github.com/fxamacker/cbor/v2"
 	"github.com/taurusgroup/multi-party-sig/internal/params"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
@@ -21,11 +23,36 @@ type CorreOTSendSetup struct {
 	_K_Delta [params.OTParam][params.OTBytes]byte
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The setup is secret, and should be stored with the same care as a secret key share.
+func (s *CorreOTSendSetup) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, params.OTBytes*(params.OTParam+1))
+	out = append(out, s._Delta[:]...)
+	for i := 0; i < params.OTParam; i++ {
+		out = append(out, s._K_Delta[i][:]...)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *CorreOTSendSetup) UnmarshalBinary(data []byte) error {
+	if len(data) != params.OTBytes*(params.OTParam+1) {
+		return errors.New("CorreOTSendSetup: invalid length")
+	}
+	data = data[copy(s._Delta[:], data):]
+	for i := 0; i < params.OTParam; i++ {
+		data = data[copy(s._K_Delta[i][:], data):]
+	}
+	return nil
+}
+
 // CorreOTSetupSender contains all of the state to run the Sender's setup of a Correlated OT.
 //
 // This struct is needed, because there are multiple rounds in the setup.
 type CorreOTSetupSender struct {
 	// After setup
+	rand io.Reader
 	pl   *pool.Pool
 	hash *hash.Hash
 	// After Round 1
@@ -34,14 +61,16 @@ type CorreOTSetupSender struct {
 	// The correlation vector, sampled at random.
 	_Delta [params.OTBytes]byte
 	// We do multiple Random OTs, and each of them needs a receiver.
-	randomOTReceivers [params.OTParam]RandomOTReceiever
+	randomOTReceivers [params.OTParam]RandomOTReceiver
+	// The nonce of each Random OT, kept to restore the receivers from a saved state.
+	randomOTNonces [params.OTParam][32]byte
 }
 
 // NewCorreOTSetupSender initializes the state for setting up the Sender part of a Correlated OT.
 //
 // This follows the Initialize part of Figure 3, in https://eprint.iacr.org/2015/546.
-func NewCorreOTSetupSender(pl *pool.Pool, hash *hash.Hash) *CorreOTSetupSender {
-	return &CorreOTSetupSender{pl: pl, hash: hash}
+func NewCorreOTSetupSender(rand io.Reader, pl *pool.Pool, hash *hash.Hash) *CorreOTSetupSender {
+	return &CorreOTSetupSender{rand: rand, pl: pl, hash: hash}
 }
 
 // CorreOTSetupSendRound1Message is the first message sent by the Sender in the Correlated OT setup.
@@ -58,17 +87,18 @@ func (r *CorreOTSetupSender) Round1(msg *CorreOTSetupReceiveRound1Message) (*Cor
 		return nil, err
 	}
 
-	_, _ = rand.Read(r._Delta[:])
+	_, _ = io.ReadFull(r.rand, r._Delta[:])
 
 	randomOTNonces := r.hash.Fork(&hash.BytesWithDomain{
 		TheDomain: "CorreOT Random OT Nonces",
 		Bytes:     nil,
 	}).Digest()
+	// the receivers are run in parallel below
+	lockedRand := pool.NewLockedReader(r.rand)
 	for i := 0; i < params.OTParam; i++ {
 		choice := saferith.Choice(bitAt(i, r._Delta[:]))
-		nonce := make([]byte, 32)
-		_, _ = randomOTNonces.Read(nonce)
-		r.randomOTReceivers[i] = NewRandomOTReceiver(nonce, r.setup, choice)
+		_, _ = randomOTNonces.Read(r.randomOTNonces[i][:])
+		r.randomOTReceivers[i] = NewRandomOTReceiver(lockedRand, r.randomOTNonces[i][:], r.setup, choice)
 	}
 
 	outMsg := new(CorreOTSetupSendRound1Message)
@@ -86,7 +116,7 @@ func (r *CorreOTSetupSender) Round1(msg *CorreOTSetupReceiveRound1Message) (*Cor
 	return outMsg, nil
 }
 
-// CorreOTSetupSendRound1Message is the second message sent by the Sender in the Correlated OT setup.
+// CorreOTSetupSendRound2Message is the second message sent by the Sender in the Correlated OT setup.
 type CorreOTSetupSendRound2Message struct {
 	Msgs [params.OTParam]RandomOTReceiveRound2Message
 }
@@ -100,7 +130,7 @@ func (r *CorreOTSetupSender) Round2(msg *CorreOTSetupReceiveRound2Message) *Corr
 	return outMsg
 }
 
-// Round2 executes the Sender's final round of the Correlated OT setup.
+// Round3 executes the Sender's final round of the Correlated OT setup.
 func (r *CorreOTSetupSender) Round3(msg *CorreOTSetupReceiveRound3Message) (*CorreOTSendSetup, error) {
 	setup := new(CorreOTSendSetup)
 	setup._Delta = r._Delta
@@ -114,6 +144,63 @@ func (r *CorreOTSetupSender) Round3(msg *CorreOTSetupReceiveRound3Message) (*Cor
 	return setup, nil
 }
 
+// correOTSetupSenderState is the state of a CorreOTSetupSender, as encoded by MarshalBinary.
+type correOTSetupSenderState struct {
+	Delta  [params.OTBytes]byte
+	Nonces [params.OTParam][32]byte
+	// The state of each Random OT receiver, where ReceivedChallenge and HHRandChoice are empty until Round2 has been run.
+	RandChoice, ReceivedChallenge, HHRandChoice [params.OTParam][params.OTBytes]byte
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// This returns the state of the setup after Round1 or Round2, which can be restored by calling UnmarshalBinary
+// on a CorreOTSetupSender created with the same arguments.
+//
+// The state is secret, and should be stored with the same care as a secret key share.
+func (r *CorreOTSetupSender) MarshalBinary() ([]byte, error) {
+	// the receivers are created in Round1, and keep the hash keyed with their nonce
+	if r.randomOTReceivers[0].hash == nil {
+		return nil, errors.New("CorreOTSetupSender: Round1 has not been run")
+	}
+	s := &correOTSetupSenderState{Delta: r._Delta, Nonces: r.randomOTNonces}
+	for i := 0; i < params.OTParam; i++ {
+		s.RandChoice[i] = r.randomOTReceivers[i].randChoice
+		s.ReceivedChallenge[i] = r.randomOTReceivers[i].receivedChallenge
+		s.HHRandChoice[i] = r.randomOTReceivers[i].hh_randChoice
+	}
+	return cbor.Marshal(s)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// The setup of the Random OTs is only used in Round1, so the restored receivers only contain
+// what Round2 and Round3 need.
+func (r *CorreOTSetupSender) UnmarshalBinary(data []byte) error {
+	s := new(correOTSetupSenderState)
+	if err := cbor.Unmarshal(data, s); err != nil {
+		return fmt.Errorf("CorreOTSetupSender: %w", err)
+	}
+	r._Delta = s.Delta
+
+	r.randomOTNonces = s.Nonces
+	for i := 0; i < params.OTParam; i++ {
+		hasher, err := blake3.NewKeyed(r.randomOTNonces[i][:])
+		if err != nil {
+			return err
+		}
+		r.randomOTReceivers[i] = RandomOTReceiver{
+			rand:              r.rand,
+			hash:              hasher,
+			choice:            saferith.Choice(bitAt(i, r._Delta[:])),
+			randChoice:        s.RandChoice[i],
+			receivedChallenge: s.ReceivedChallenge[i],
+			hh_randChoice:     s.HHRandChoice[i],
+		}
+	}
+	return nil
+}
+
 // CorreOTReceiveSetup is the result of the Receiver's part of the Correlated OT setup.
 //
 // The Receiver gets two random matrices, and they know that the Sender has a
@@ -123,11 +210,40 @@ type CorreOTReceiveSetup struct {
 	_K_1 [params.OTParam][params.OTBytes]byte
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The setup is secret, and should be stored with the same care as a secret key share.
+func (s *CorreOTReceiveSetup) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, 2*params.OTBytes*params.OTParam)
+	for i := 0; i < params.OTParam; i++ {
+		out = append(out, s._K_0[i][:]...)
+	}
+	for i := 0; i < params.OTParam; i++ {
+		out = append(out, s._K_1[i][:]...)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *CorreOTReceiveSetup) UnmarshalBinary(data []byte) error {
+	if len(data) != 2*params.OTBytes*params.OTParam {
+		return errors.New("CorreOTReceiveSetup: invalid length")
+	}
+	for i := 0; i < params.OTParam; i++ {
+		data = data[copy(s._K_0[i][:], data):]
+	}
+	for i := 0; i < params.OTParam; i++ {
+		data = data[copy(s._K_1[i][:], data):]
+	}
+	return nil
+}
+
 // CorreOTSetupReceiver holds the Receiver's state on a Correlated OT Setup.
 //
 // This is necessary, because the setup process takes multiple rounds.
 type CorreOTSetupReceiver struct {
 	// After setup
+	rand  io.Reader
 	pl    *pool.Pool
 	hash  *hash.Hash
 	group curve.Curve
@@ -136,13 +252,15 @@ type CorreOTSetupReceiver struct {
 	setup *RandomOTSendSetup
 	// We need to keep the state for each instance.
 	randomOTSenders [params.OTParam]RandomOTSender
+	// The nonce of each Random OT, kept to restore the senders from a saved state.
+	randomOTNonces [params.OTParam][32]byte
 }
 
 // NewCorreOTSetupReceiver initializes the state for setting up the Receiver part of a Correlated OT.
 //
 // This follows the Initialize part of Figure 3, in https://eprint.iacr.org/2015/546.
-func NewCorreOTSetupReceiver(pl *pool.Pool, hash *hash.Hash, group curve.Curve) *CorreOTSetupReceiver {
-	return &CorreOTSetupReceiver{pl: pl, hash: hash, group: group}
+func NewCorreOTSetupReceiver(rand io.Reader, pl *pool.Pool, hash *hash.Hash, group curve.Curve) *CorreOTSetupReceiver {
+	return &CorreOTSetupReceiver{rand: rand, pl: pl, hash: hash, group: group}
 }
 
 // CorreOTSetupReceiveRound1Message is the first message sent by the Receiver in a Correlated OT Setup.
@@ -157,7 +275,7 @@ func EmptyCorreOTSetupReceiveRound1Message(group curve.Curve) *CorreOTSetupRecei
 
 // Round1 runs the first round of a Receiver's correlated OT Setup.
 func (r *CorreOTSetupReceiver) Round1() *CorreOTSetupReceiveRound1Message {
-	msg, setup := RandomOTSetupSend(r.hash, r.group)
+	msg, setup := RandomOTSetupSend(r.rand, r.hash, r.group)
 	r.setup = setup
 
 	randomOTNonces := r.hash.Fork(&hash.BytesWithDomain{
@@ -165,20 +283,19 @@ func (r *CorreOTSetupReceiver) Round1() *CorreOTSetupReceiveRound1Message {
 		Bytes:     nil,
 	}).Digest()
 	for i := 0; i < params.OTParam; i++ {
-		nonce := make([]byte, 32)
-		_, _ = randomOTNonces.Read(nonce)
-		r.randomOTSenders[i] = NewRandomOTSender(nonce, r.setup)
+		_, _ = randomOTNonces.Read(r.randomOTNonces[i][:])
+		r.randomOTSenders[i] = NewRandomOTSender(r.randomOTNonces[i][:], r.setup)
 	}
 
 	return &CorreOTSetupReceiveRound1Message{*msg}
 }
 
-// CorreOTSetupReceiveRound1Message is the second message sent by the Receiver in a Correlated OT Setup.
+// CorreOTSetupReceiveRound2Message is the second message sent by the Receiver in a Correlated OT Setup.
 type CorreOTSetupReceiveRound2Message struct {
 	Msgs [params.OTParam]RandomOTSendRound1Message
 }
 
-// Round1 runs the second round of a Receiver's correlated OT Setup.
+// Round2 runs the second round of a Receiver's correlated OT Setup.
 func (r *CorreOTSetupReceiver) Round2(msg *CorreOTSetupSendRound1Message) (*CorreOTSetupReceiveRound2Message, error) {
 	outMsg := new(CorreOTSetupReceiveRound2Message)
 
@@ -195,12 +312,12 @@ func (r *CorreOTSetupReceiver) Round2(msg *CorreOTSetupSendRound1Message) (*Corr
 	return outMsg, nil
 }
 
-// CorreOTSetupReceiveRound1Message is the third message sent by the Receiver in a Correlated OT Setup.
+// CorreOTSetupReceiveRound3Message is the third message sent by the Receiver in a Correlated OT Setup.
 type CorreOTSetupReceiveRound3Message struct {
 	Msgs [params.OTParam]RandomOTSendRound2Message
 }
 
-// Round1 runs the third round of a Receiver's correlated OT Setup.
+// Round3 runs the third round of a Receiver's correlated OT Setup.
 func (r *CorreOTSetupReceiver) Round3(msg *CorreOTSetupSendRound2Message) (*CorreOTSetupReceiveRound3Message, *CorreOTReceiveSetup, error) {
 	outMsg := new(CorreOTSetupReceiveRound3Message)
 	setup := new(CorreOTReceiveSetup)
@@ -216,6 +333,68 @@ func (r *CorreOTSetupReceiver) Round3(msg *CorreOTSetupSendRound2Message) (*Corr
 	return outMsg, setup, nil
 }
 
+// correOTSetupReceiverState is the state of a CorreOTSetupReceiver, as encoded by MarshalBinary.
+type correOTSetupReceiverState struct {
+	// The secret key of the Random OT setup.
+	B      []byte
+	Nonces [params.OTParam][32]byte
+	// The state of each Random OT sender, which is empty until Round2 has been run.
+	Rand0, Rand1, Decommit0, Decommit1, HDecommit0 [params.OTParam][params.OTBytes]byte
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// This returns the state of the setup after Round1 or Round2, which can be restored by calling UnmarshalBinary
+// on a CorreOTSetupReceiver created with the same arguments.
+//
+// The state is secret, and should be stored with the same care as a secret key share.
+func (r *CorreOTSetupReceiver) MarshalBinary() ([]byte, error) {
+	if r.setup == nil {
+		return nil, errors.New("CorreOTSetupReceiver: Round1 has not been run")
+	}
+	s := &correOTSetupReceiverState{Nonces: r.randomOTNonces}
+	var err error
+	if s.B, err = r.setup.b.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < params.OTParam; i++ {
+		s.Rand0[i] = r.randomOTSenders[i].rand0
+		s.Rand1[i] = r.randomOTSenders[i].rand1
+		s.Decommit0[i] = r.randomOTSenders[i].decommit0
+		s.Decommit1[i] = r.randomOTSenders[i].decommit1
+		s.HDecommit0[i] = r.randomOTSenders[i].h_decommit0
+	}
+	return cbor.Marshal(s)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *CorreOTSetupReceiver) UnmarshalBinary(data []byte) error {
+	s := new(correOTSetupReceiverState)
+	if err := cbor.Unmarshal(data, s); err != nil {
+		return fmt.Errorf("CorreOTSetupReceiver: %w", err)
+	}
+	b := r.group.NewScalar()
+	if err := b.UnmarshalBinary(s.B); err != nil {
+		return fmt.Errorf("CorreOTSetupReceiver: %w", err)
+	}
+	if b.IsZero() {
+		return errors.New("CorreOTSetupReceiver: zero secret key")
+	}
+	B := b.ActOnBase()
+	r.setup = &RandomOTSendSetup{b: b, _B: B, _bB: b.Act(B)}
+
+	r.randomOTNonces = s.Nonces
+	for i := 0; i < params.OTParam; i++ {
+		r.randomOTSenders[i] = NewRandomOTSender(r.randomOTNonces[i][:], r.setup)
+		r.randomOTSenders[i].rand0 = s.Rand0[i]
+		r.randomOTSenders[i].rand1 = s.Rand1[i]
+		r.randomOTSenders[i].decommit0 = s.Decommit0[i]
+		r.randomOTSenders[i].decommit1 = s.Decommit1[i]
+		r.randomOTSenders[i].h_decommit0 = s.HDecommit0[i]
+	}
+	return nil
+}
+
 // transposeBits transpose a matrix of bits.
 //
 // l is the number of elements in each row of the original matrix.
@@ -245,7 +424,6 @@ type CorreOTSendResult struct {
 // satsifying t_j = q_j ^ (choices_j * Delta).
 //
 // This follows the extend section of Figure 3 in https://eprint.iacr.org/2015/546.
-//
 func CorreOTSend(ctxHash *hash.Hash, setup *CorreOTSendSetup, batchSize int, msg *CorreOTReceiveMessage) (*CorreOTSendResult, error) {
 	batchSizeBytes := batchSize >> 3
 
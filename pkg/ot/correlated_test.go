@@ -6,14 +6,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/taurusgroup/multi-party-sig/internal/params"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/pool"
 )
 
 func runCorreOTSetup(pl *pool.Pool, hash *hash.Hash) (*CorreOTSendSetup, *CorreOTReceiveSetup, error) {
-	sender := NewCorreOTSetupSender(pl, hash.Clone())
-	receiver := NewCorreOTSetupReceiver(pl, hash.Clone(), testGroup)
+	sender := NewCorreOTSetupSender(rand.Reader, pl, hash.Clone())
+	receiver := NewCorreOTSetupReceiver(rand.Reader, pl, hash.Clone(), testGroup)
 	msgR1 := receiver.Round1()
 	msgS1, err := sender.Round1(msgR1)
 	if err != nil {
@@ -47,23 +48,66 @@ func TestCorreOTSetup(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
-		for i := 0; i < params.OTParam; i++ {
-			// This should only fail with negligeable probability normally
-			if bytes.Equal(receiveSetup._K_0[i][:], receiveSetup._K_1[i][:]) {
-				t.Error("K_0[i] == K_1[i]")
-			}
-			choice := bitAt(i, sendSetup._Delta[:]) == 1
-			array := receiveSetup._K_0[i][:]
-			if choice {
-				array = receiveSetup._K_1[i][:]
-			}
-			if !bytes.Equal(sendSetup._K_Delta[i][:], array) {
-				t.Error("K_Delta doesn't match")
-			}
+		checkCorreOTSetup(t, sendSetup, receiveSetup)
+	}
+}
+
+func checkCorreOTSetup(t *testing.T, sendSetup *CorreOTSendSetup, receiveSetup *CorreOTReceiveSetup) {
+	for i := 0; i < params.OTParam; i++ {
+		// This should only fail with negligeable probability normally
+		if bytes.Equal(receiveSetup._K_0[i][:], receiveSetup._K_1[i][:]) {
+			t.Error("K_0[i] == K_1[i]")
+		}
+		choice := bitAt(i, sendSetup._Delta[:]) == 1
+		array := receiveSetup._K_0[i][:]
+		if choice {
+			array = receiveSetup._K_1[i][:]
+		}
+		if !bytes.Equal(sendSetup._K_Delta[i][:], array) {
+			t.Error("K_Delta doesn't match")
 		}
 	}
 }
 
+func TestCorreOTSetupMarshal(t *testing.T) {
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+
+	h := hash.New()
+	sender := NewCorreOTSetupSender(rand.Reader, pl, h.Clone())
+	receiver := NewCorreOTSetupReceiver(rand.Reader, pl, h.Clone(), testGroup)
+	// restart both parties from their saved state
+	restart := func() {
+		senderData, err := sender.MarshalBinary()
+		require.NoError(t, err)
+		sender = NewCorreOTSetupSender(rand.Reader, pl, h.Clone())
+		require.NoError(t, sender.UnmarshalBinary(senderData))
+
+		receiverData, err := receiver.MarshalBinary()
+		require.NoError(t, err)
+		receiver = NewCorreOTSetupReceiver(rand.Reader, pl, h.Clone(), testGroup)
+		require.NoError(t, receiver.UnmarshalBinary(receiverData))
+	}
+
+	_, err := sender.MarshalBinary()
+	require.Error(t, err, "the sender has not started")
+	_, err = receiver.MarshalBinary()
+	require.Error(t, err, "the receiver has not started")
+
+	msgS1, err := sender.Round1(receiver.Round1())
+	require.NoError(t, err)
+	restart()
+	msgR2, err := receiver.Round2(msgS1)
+	require.NoError(t, err)
+	msgS2 := sender.Round2(msgR2)
+	restart()
+	msgR3, receiveSetup, err := receiver.Round3(msgS2)
+	require.NoError(t, err)
+	sendSetup, err := sender.Round3(msgR3)
+	require.NoError(t, err)
+	checkCorreOTSetup(t, sendSetup, receiveSetup)
+}
+
 func runCorreOT(hash *hash.Hash, choices []byte, sendSetup *CorreOTSendSetup, receiveSetup *CorreOTReceiveSetup) (*CorreOTSendResult, *CorreOTReceiveResult, error) {
 	msgR1, receiveResult := CorreOTReceive(hash.Clone(), receiveSetup, choices)
 	sendResult, err := CorreOTSend(hash.Clone(), sendSetup, 8*len(choices), msgR1)
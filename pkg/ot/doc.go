@@ -0,0 +1,33 @@
+// Package ot implements oblivious transfer, and the two-party multiplication built on top of it.
+//
+// The package is organized in layers, each building on the previous one:
+//
+//   - Random OT (random.go), following https://eprint.iacr.org/2015/267, used to set up a Correlated OT.
+//   - Correlated OT (correlated.go), following Figure 3 of https://eprint.iacr.org/2015/546.
+//     Its setup is expensive, but can be reused for many subsequent extensions.
+//   - Extended OT (extended.go), following Figure 7 of https://eprint.iacr.org/2015/546,
+//     which performs a large batch of random OTs from a single Correlated OT setup.
+//   - Additive OT (additive.go) and multiplication (multiply.go), following
+//     Protocols 9 and 5 of https://eprint.iacr.org/2018/499, which produce an additive sharing
+//     of the product of two secret scalars. The vector multiplication shares the products of a single
+//     scalar of the Receiver with several scalars of the Sender.
+//
+// Each layer exposes a Sender and a Receiver, whose methods consume the other party's message
+// for a given round, and return the next message. All messages can be serialized with cbor,
+// and the results of a setup implement encoding.BinaryMarshaler, so that they can be stored.
+//
+// A setup can be reused for many executions, but each execution must then use a different context hash,
+// for example by writing a nonce or a session identifier into it.
+//
+// StartSetupReceiver, StartSetupSender, StartMultiplyReceiver and StartMultiplySender run the setup
+// and the multiplication as protocols, which can be executed with a protocol.TwoPartyHandler.
+package ot
+
+import "github.com/taurusgroup/multi-party-sig/internal/params"
+
+const (
+	// SecurityParam is the number of bits of computational security, and the size of the messages in a Random OT.
+	SecurityParam = params.OTParam
+	// SecurityBytes is SecurityParam, in bytes.
+	SecurityBytes = params.OTBytes
+)
@@ -1,7 +1,6 @@
 package ot
 
 import (
-	"crypto/rand"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -157,6 +156,9 @@ type ExtendedOTSendResult struct {
 func ExtendedOTSend(ctxHash *hash.Hash, setup *CorreOTSendSetup, batchSize int, msg *ExtendedOTReceiveMessage) (*ExtendedOTSendResult, error) {
 	adjustedBatchSize := adjustBatchSize(batchSize)
 
+	if msg.CorreMsg == nil {
+		return nil, fmt.Errorf("ExtendedOTSend: missing correlated OT message")
+	}
 	correResult, err := CorreOTSend(ctxHash, setup, adjustedBatchSize, msg.CorreMsg)
 	if err != nil {
 		return nil, err
@@ -234,11 +236,11 @@ type ExtendedOTReceiveMessage struct {
 //
 // A single setup can be used for many invocations of this protocol, so long as the
 // hash is initialized with some kind of nonce.
-func ExtendedOTReceive(ctxHash *hash.Hash, setup *CorreOTReceiveSetup, choices []byte) (*ExtendedOTReceiveMessage, *ExtendedOTReceiveResult) {
+func ExtendedOTReceive(rand io.Reader, ctxHash *hash.Hash, setup *CorreOTReceiveSetup, choices []byte) (*ExtendedOTReceiveMessage, *ExtendedOTReceiveResult) {
 	adjustedBatchSize := adjustBatchSize(8 * len(choices))
 	extraChoices := make([]byte, adjustedBatchSize/8)
 	copy(extraChoices, choices)
-	_, _ = rand.Read(extraChoices[len(choices):])
+	_, _ = io.ReadFull(rand, extraChoices[len(choices):])
 
 	correMsg, correResult := CorreOTReceive(ctxHash, setup, extraChoices)
 
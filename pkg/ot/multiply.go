@@ -0,0 +1,348 @@
+package ot
+
+import (
+	"errors"
+	"io"
+
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/internal/params"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+)
+
+// scalarBytes returns the number of bytes needed to store a scalar in this group.
+func scalarBytes(group curve.Curve) int {
+	return (group.ScalarBits() + 7) & ^0b111
+}
+
+// encode converts a scalar to a sequence of bits, using a certain number of scalars for noise.
+//
+// The noise should be public, but the encoding will be unpredictable, but still decodable.
+//
+// The noise vector should have a length that's a multiple of 8
+func encode(rand io.Reader, beta curve.Scalar, noise []curve.Scalar) ([]byte, error) {
+	// This follows Algorithm 4 in Doerner's paper:
+	//   https://eprint.iacr.org/2018/499
+	group := beta.Curve()
+
+	gamma := make([]byte, len(noise)/8)
+	_, _ = io.ReadFull(rand, gamma)
+
+	acc := group.NewScalar().Set(beta)
+	mulNat := new(saferith.Nat)
+	mul := group.NewScalar()
+	for i := 0; i < len(noise); i++ {
+		mulNat.SetUint64(uint64((gamma[i>>3] >> (i & 0b111)) & 1))
+		acc.Sub(mul.SetNat(mulNat).Mul(noise[i]))
+	}
+
+	data, err := acc.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	data = append(data, gamma...)
+	return data, nil
+}
+
+// gadgetLen returns the length of the gadget vector, which is also the number of OTs in a multiplication.
+//
+// We have space for all the bytes of a scalar, and then noise vectors, padded to a multiple of 8.
+func gadgetLen(group curve.Curve) int {
+	return 8 * ((group.ScalarBits()+7)/8 + (group.ScalarBits()+2*params.StatParam+7)/8)
+}
+
+// makeGadget constructs the gadget vector, using the hash for random values.
+//
+// This vector is composed of the right powers of two to make our multiplication work,
+// along with some public random values for noise.
+func makeGadget(ctxHash *hash.Hash, group curve.Curve) []curve.Scalar {
+	scalarEnd := scalarBytes(group)
+	out := make([]curve.Scalar, gadgetLen(group))
+	// Handle powers of 2, in big endian order
+	acc := group.NewScalar().SetNat(new(saferith.Nat).SetUint64(1))
+	for i := (scalarEnd >> 3) - 1; i >= 0; i-- {
+		for j := 0; j < 8; j++ {
+			out[(i<<3)|j] = group.NewScalar().Set(acc)
+			acc.Add(acc)
+		}
+	}
+	// Generate random noise
+	digest := ctxHash.Fork(&hash.BytesWithDomain{TheDomain: "Multiply Gadget Sampling", Bytes: nil}).Digest()
+	for i := scalarEnd; i < len(out); i++ {
+		out[i] = sample.Scalar(digest, group)
+	}
+	return out
+}
+
+// VectorMultiplySender contains the state for the Sender of the vector multiplication protocol.
+type VectorMultiplySender struct {
+	// After setup
+	ctxHash *hash.Hash
+	group   curve.Curve
+	setup   *CorreOTSendSetup
+	// alpha contains the inputs of the Sender, followed by a random scalar for the integrity check.
+	alpha  []curve.Scalar
+	gadget []curve.Scalar
+	sender *AdditiveOTSender
+}
+
+// NewVectorMultiplySender initializes the Sender for the vector multiplication protocol.
+//
+// The Sender has a vector of scalars alpha, the Receiver a single scalar beta, and the goal is to create
+// an additive sharing of alpha[k] * beta for every k. All the products are obtained from the same
+// choice bits of the Receiver, so that the Receiver cannot use a different beta for each of them.
+//
+// This follows Protocol 5 of https://eprint.iacr.org/2018/499, where the Sender transfers
+// the whole vector in each OT, instead of a single scalar.
+func NewVectorMultiplySender(rand io.Reader, ctxHash *hash.Hash, setup *CorreOTSendSetup, alpha []curve.Scalar) *VectorMultiplySender {
+	group := alpha[0].Curve()
+	gadget := makeGadget(ctxHash, group)
+	paddedAlpha := make([]curve.Scalar, 0, len(alpha)+1)
+	paddedAlpha = append(paddedAlpha, alpha...)
+	paddedAlpha = append(paddedAlpha, sample.Scalar(rand, group))
+	return &VectorMultiplySender{
+		ctxHash: ctxHash,
+		group:   group,
+		setup:   setup,
+		gadget:  gadget,
+		alpha:   paddedAlpha,
+		sender:  NewAdditiveOTSender(ctxHash, setup, len(gadget), paddedAlpha),
+	}
+}
+
+// MultiplySendRound1Message is the first message from the Sender in the multiplication protocol.
+type MultiplySendRound1Message struct {
+	Msg    *AdditiveOTSendRound1Message
+	RCheck []curve.Scalar
+	UCheck curve.Scalar
+}
+
+// EmptyMultiplySendRound1Message initializes the message with the correct group and size, for unmarshalling.
+func EmptyMultiplySendRound1Message(group curve.Curve) *MultiplySendRound1Message {
+	RCheck := make([]curve.Scalar, gadgetLen(group))
+	for i := 0; i < len(RCheck); i++ {
+		RCheck[i] = group.NewScalar()
+	}
+	return &MultiplySendRound1Message{RCheck: RCheck, UCheck: group.NewScalar()}
+}
+
+// EmptyMultiplySendRound1Message initializes the message with the correct group and size, for unmarshalling.
+//
+// This is equivalent to calling the function of the same name, with the group of the receiver.
+func (r *VectorMultiplyReceiver) EmptyMultiplySendRound1Message() *MultiplySendRound1Message {
+	return EmptyMultiplySendRound1Message(r.group)
+}
+
+// Round1 runs the Sender's first round in the vector multiplication protocol.
+//
+// The k-th share returned is the Sender's share of alpha[k] * beta.
+func (r *VectorMultiplySender) Round1(msg *MultiplyReceiveRound1Message) (*MultiplySendRound1Message, []curve.Scalar, error) {
+	if msg.Msg == nil || msg.Msg.Msg == nil || msg.Msg.Msg.CorreMsg == nil {
+		return nil, nil, errors.New("multiply send round 1: malformed message")
+	}
+	additiveMsg, result, err := r.sender.Round1(msg.Msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	digest := r.ctxHash.Fork(&hash.BytesWithDomain{TheDomain: "Multiply Chi Sampling", Bytes: nil}).Digest()
+	chi := make([]curve.Scalar, len(r.alpha))
+	for k := range chi {
+		chi[k] = sample.Scalar(digest, r.group)
+	}
+
+	mul := r.group.NewScalar()
+
+	uCheck := r.group.NewScalar()
+	for k := range chi {
+		uCheck.Add(mul.Set(r.alpha[k]).Mul(chi[k]))
+	}
+
+	rCheck := make([]curve.Scalar, len(result))
+	for i := 0; i < len(rCheck); i++ {
+		rCheck[i] = r.group.NewScalar()
+		for k := range chi {
+			rCheck[i].Add(mul.Set(result[i][k]).Mul(chi[k]))
+		}
+	}
+
+	shares := make([]curve.Scalar, len(r.alpha)-1)
+	for k := range shares {
+		shares[k] = r.group.NewScalar()
+		for i := 0; i < len(result); i++ {
+			shares[k].Add(mul.Set(result[i][k]).Mul(r.gadget[i]))
+		}
+	}
+
+	return &MultiplySendRound1Message{
+		Msg:    additiveMsg,
+		RCheck: rCheck,
+		UCheck: uCheck,
+	}, shares, nil
+}
+
+// VectorMultiplyReceiver contains the state for the Receiver of the vector multiplication protocol.
+type VectorMultiplyReceiver struct {
+	// After setup
+	ctxHash *hash.Hash
+	group   curve.Curve
+	setup   *CorreOTReceiveSetup
+	beta    curve.Scalar
+	// size is the number of inputs of the Sender.
+	size     int
+	gadget   []curve.Scalar
+	choices  []byte
+	receiver *AdditiveOTReceiver
+}
+
+// NewVectorMultiplyReceiver initializes the Receiver for the vector multiplication protocol.
+//
+// The Sender has a vector of size scalars alpha, the Receiver a single scalar beta, and the goal is to create
+// an additive sharing of alpha[k] * beta for every k.
+//
+// This follows Protocol 5 of https://eprint.iacr.org/2018/499, where the Sender transfers
+// the whole vector in each OT, instead of a single scalar.
+func NewVectorMultiplyReceiver(rand io.Reader, ctxHash *hash.Hash, setup *CorreOTReceiveSetup, beta curve.Scalar, size int) (*VectorMultiplyReceiver, error) {
+	group := beta.Curve()
+	gadget := makeGadget(ctxHash, group)
+	choices, err := encode(rand, beta, gadget[scalarBytes(group):])
+	if err != nil {
+		return nil, err
+	}
+	return &VectorMultiplyReceiver{
+		ctxHash:  ctxHash,
+		group:    group,
+		setup:    setup,
+		beta:     beta,
+		size:     size,
+		gadget:   gadget,
+		choices:  choices,
+		receiver: NewAdditiveOTReceiver(rand, ctxHash, setup, group, size+1, choices),
+	}, nil
+}
+
+// MultiplyReceiveRound1Message is the first message from the Receiver in the multiplication protocol.
+type MultiplyReceiveRound1Message struct {
+	Msg *AdditiveOTReceiveRound1Message
+}
+
+// Round1 runs the first round for the Receiver in the vector multiplication protocol.
+func (r *VectorMultiplyReceiver) Round1() *MultiplyReceiveRound1Message {
+	msg := r.receiver.Round1()
+	return &MultiplyReceiveRound1Message{msg}
+}
+
+// Round2 runs the second round for the Receiver in the vector multiplication protocol.
+//
+// The k-th share returned is the Receiver's share of alpha[k] * beta.
+func (r *VectorMultiplyReceiver) Round2(msg *MultiplySendRound1Message) ([]curve.Scalar, error) {
+	if msg.Msg == nil || msg.UCheck == nil || len(msg.RCheck) != len(r.gadget) {
+		return nil, errors.New("multiply receive round 2: malformed message")
+	}
+	result, err := r.receiver.Round2(msg.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := r.ctxHash.Fork(&hash.BytesWithDomain{TheDomain: "Multiply Chi Sampling", Bytes: nil}).Digest()
+	chi := make([]curve.Scalar, r.size+1)
+	for k := range chi {
+		chi[k] = sample.Scalar(digest, r.group)
+	}
+
+	mul := r.group.NewScalar()
+	checkLeft := r.group.NewScalar()
+	checkRight := r.group.NewScalar()
+	choiceNat := new(saferith.Nat)
+
+	for i := 0; i < len(result); i++ {
+		checkLeft.SetNat(new(saferith.Nat))
+		for k := range chi {
+			checkLeft.Add(mul.Set(result[i][k]).Mul(chi[k]))
+		}
+
+		checkRight.SetNat(choiceNat.SetUint64(uint64((r.choices[i>>3] >> (i & 0b111)) & 1)))
+		checkRight.Mul(msg.UCheck)
+		checkRight.Sub(msg.RCheck[i])
+
+		if !checkLeft.Equal(checkRight) {
+			return nil, errors.New("multiply receive round 2: integrity check failed")
+		}
+	}
+
+	shares := make([]curve.Scalar, r.size)
+	for k := range shares {
+		shares[k] = r.group.NewScalar()
+		for i := 0; i < len(result); i++ {
+			shares[k].Add(mul.Set(result[i][k]).Mul(r.gadget[i]))
+		}
+	}
+
+	return shares, nil
+}
+
+// MultiplySender contains the state for the Sender of the multiplication protocol.
+type MultiplySender struct {
+	sender *VectorMultiplySender
+}
+
+// NewMultiplySender initializes the Sender for the multiplication protocol.
+//
+// The Sender has a scalar alpha, the Receiver beta, and the goal is to create an additive
+// sharing of alpha * beta.
+//
+// This follows Protocol 5 of https://eprint.iacr.org/2018/499.
+func NewMultiplySender(rand io.Reader, ctxHash *hash.Hash, setup *CorreOTSendSetup, alpha curve.Scalar) *MultiplySender {
+	return &MultiplySender{sender: NewVectorMultiplySender(rand, ctxHash, setup, []curve.Scalar{alpha})}
+}
+
+// Round1 runs the Sender's first round in the multiplication protocol.
+func (r *MultiplySender) Round1(msg *MultiplyReceiveRound1Message) (*MultiplySendRound1Message, curve.Scalar, error) {
+	outMsg, shares, err := r.sender.Round1(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outMsg, shares[0], nil
+}
+
+// MultiplyReceiver contains the state for the Receiver of the multiplication protocol.
+type MultiplyReceiver struct {
+	receiver *VectorMultiplyReceiver
+}
+
+// NewMultiplyReceiver initializes the Receiver for the multiplication protocol.
+//
+// The Sender has a scalar alpha, the Receiver beta, and the goal is to create an additive
+// sharing of alpha * beta.
+//
+// This follows Protocol 5 of https://eprint.iacr.org/2018/499.
+func NewMultiplyReceiver(rand io.Reader, ctxHash *hash.Hash, setup *CorreOTReceiveSetup, beta curve.Scalar) (*MultiplyReceiver, error) {
+	receiver, err := NewVectorMultiplyReceiver(rand, ctxHash, setup, beta, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiplyReceiver{receiver: receiver}, nil
+}
+
+// EmptyMultiplySendRound1Message initializes the message with the correct group and size, for unmarshalling.
+//
+// This is equivalent to calling the function of the same name, with the group of the receiver.
+func (r *MultiplyReceiver) EmptyMultiplySendRound1Message() *MultiplySendRound1Message {
+	return r.receiver.EmptyMultiplySendRound1Message()
+}
+
+// Round1 runs the first round for the Receiver in the multiplication protocol.
+func (r *MultiplyReceiver) Round1() *MultiplyReceiveRound1Message {
+	return r.receiver.Round1()
+}
+
+// Round2 runs the second round for the Receiver in the multiplication protocol.
+func (r *MultiplyReceiver) Round2(msg *MultiplySendRound1Message) (curve.Scalar, error) {
+	shares, err := r.receiver.Round2(msg)
+	if err != nil {
+		return nil, err
+	}
+	return shares[0], nil
+}
@@ -11,8 +11,8 @@ import (
 )
 
 func runMultiply(hash *hash.Hash, sendSetup *CorreOTSendSetup, receiveSetup *CorreOTReceiveSetup, alpha, beta curve.Scalar) (curve.Scalar, curve.Scalar, error) {
-	sender := NewMultiplySender(hash.Clone(), sendSetup, alpha)
-	receiver, err := NewMultiplyReceiver(hash.Clone(), receiveSetup, beta)
+	sender := NewMultiplySender(rand.Reader, hash.Clone(), sendSetup, alpha)
+	receiver, err := NewMultiplyReceiver(rand.Reader, hash.Clone(), receiveSetup, beta)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -51,6 +51,51 @@ func TestMultiply(t *testing.T) {
 	}
 }
 
+func TestVectorMultiply(t *testing.T) {
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+
+	sendSetup, receiveSetup, err := runCorreOTSetup(pl, hash.New())
+	if err != nil {
+		t.Error(err)
+	}
+
+	H := hash.New()
+	alpha := []curve.Scalar{sample.Scalar(rand.Reader, testGroup), sample.Scalar(rand.Reader, testGroup)}
+	beta := sample.Scalar(rand.Reader, testGroup)
+	sender := NewVectorMultiplySender(rand.Reader, H.Clone(), sendSetup, alpha)
+	receiver, err := NewVectorMultiplyReceiver(rand.Reader, H.Clone(), receiveSetup, beta, len(alpha))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgS1, sharesA, err := sender.Round1(receiver.Round1())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharesB, err := receiver.Round2(msgS1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range alpha {
+		alphabeta := testGroup.NewScalar().Set(alpha[k]).Mul(beta)
+		ab := testGroup.NewScalar().Set(sharesA[k]).Add(sharesB[k])
+		if !alphabeta.Equal(ab) {
+			t.Errorf("multiply failed to produce valid shares for input %d", k)
+		}
+	}
+
+	// a receiver expecting a single input rejects the vector
+	receiver, _ = NewVectorMultiplyReceiver(rand.Reader, H.Clone(), receiveSetup, beta, 1)
+	sender = NewVectorMultiplySender(rand.Reader, H.Clone(), sendSetup, alpha)
+	msgS1, _, err = sender.Round1(receiver.Round1())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = receiver.Round2(msgS1); err == nil {
+		t.Error("receiver should reject pads of the wrong width")
+	}
+}
+
 func BenchmarkMultiply(b *testing.B) {
 	b.StopTimer()
 	pl := pool.NewPool(0)
@@ -1,9 +1,9 @@
 package ot
 
 import (
-	"crypto/rand"
 	"crypto/subtle"
 	"fmt"
+	"io"
 
 	"github.com/cronokirby/saferith"
 	"github.com/taurusgroup/multi-party-sig/internal/params"
@@ -22,6 +22,7 @@ type RandomOTSetupSendMessage struct {
 	BProof *zksch.Proof
 }
 
+// EmptyRandomOTSetupSendMessage initializes a message with a given group, so that it can be unmarshalled.
 func EmptyRandomOTSetupSendMessage(group curve.Curve) *RandomOTSetupSendMessage {
 	return &RandomOTSetupSendMessage{B: group.NewPoint(), BProof: zksch.EmptyProof(group)}
 }
@@ -44,10 +45,10 @@ type RandomOTSendSetup struct {
 // if that's desired.
 //
 // This setup can be done once and then used for multiple executions.
-func RandomOTSetupSend(hash *hash.Hash, group curve.Curve) (*RandomOTSetupSendMessage, *RandomOTSendSetup) {
-	b := sample.Scalar(rand.Reader, group)
+func RandomOTSetupSend(rand io.Reader, hash *hash.Hash, group curve.Curve) (*RandomOTSetupSendMessage, *RandomOTSendSetup) {
+	b := sample.Scalar(rand, group)
 	B := b.ActOnBase()
-	BProof := zksch.NewProof(hash, B, b, nil)
+	BProof := zksch.NewProof(rand, hash, B, b, nil)
 	return &RandomOTSetupSendMessage{B: B, BProof: BProof}, &RandomOTSendSetup{_B: B, b: b, _bB: b.Act(B)}
 }
 
@@ -74,8 +75,9 @@ func RandomOTSetupReceive(hash *hash.Hash, msg *RandomOTSetupSendMessage) (*Rand
 // RandomOTReceiver contains the state needed for a single execution of a Random OT.
 //
 // This should be created from a saved setup, for each execution.
-type RandomOTReceiever struct {
+type RandomOTReceiver struct {
 	// After setup
+	rand  io.Reader
 	hash  *blake3.Hasher
 	group curve.Curve
 	// Which random message we want to receive.
@@ -98,14 +100,16 @@ type RandomOTReceiever struct {
 //
 // The nonce should be 32 bytes, and must be different if a single setup is used for multiple OTs.
 //
-// choice indicates which of the two random messages should be received.
-func NewRandomOTReceiver(nonce []byte, result *RandomOTReceiveSetup, choice saferith.Choice) (out RandomOTReceiever) {
+// choice indicates which of the two random messages should be received,
+// and the randomness of the first round is read from rand.
+func NewRandomOTReceiver(rand io.Reader, nonce []byte, result *RandomOTReceiveSetup, choice saferith.Choice) (out RandomOTReceiver) {
 	// This will only error if the nonce has the wrong length, which is a programmer error
 	var err error
 	out.hash, err = blake3.NewKeyed(nonce)
 	if err != nil {
 		panic(err)
 	}
+	out.rand = rand
 	out.group = result._B.Curve()
 	out.choice = choice
 	out._B = result._B
@@ -121,11 +125,11 @@ type RandomOTReceiveRound1Message struct {
 // Round1 executes the receiver's side of round 1 of a Random OT.
 //
 // This is the starting point for a Random OT.
-func (r *RandomOTReceiever) Round1() (outMsg RandomOTReceiveRound1Message, err error) {
+func (r *RandomOTReceiver) Round1() (outMsg RandomOTReceiveRound1Message, err error) {
 	// We sample a <- Z_q, and then compute
 	//   A = a * G + w * B
 	//   randChoice = H(a * B)
-	a := sample.Scalar(rand.Reader, r.group)
+	a := sample.Scalar(r.rand, r.group)
 	A := a.ActOnBase()
 	outMsg.ABytes, err = A.MarshalBinary()
 	if err != nil {
@@ -158,7 +162,7 @@ type RandomOTReceiveRound2Message struct {
 }
 
 // Round2 executes the receiver's side of round 2 of a Random OT.
-func (r *RandomOTReceiever) Round2(msg *RandomOTSendRound1Message) (outMsg RandomOTReceiveRound2Message) {
+func (r *RandomOTReceiver) Round2(msg *RandomOTSendRound1Message) (outMsg RandomOTReceiveRound2Message) {
 	// response = H(H(randW)) ^ (w * challenge).
 	r.receivedChallenge = msg.Challenge
 
@@ -182,7 +186,7 @@ func (r *RandomOTReceiever) Round2(msg *RandomOTSendRound1Message) (outMsg Rando
 // Round3 finalizes the result for the receiver, performing verification.
 //
 // The random choice is returned as the first argument, upon success.
-func (r *RandomOTReceiever) Round3(msg *RandomOTSendRound2Message) ([params.OTBytes]byte, error) {
+func (r *RandomOTReceiver) Round3(msg *RandomOTSendRound2Message) ([params.OTBytes]byte, error) {
 	var actualChallenge, h_decommit0, h_decommit1 [params.OTBytes]byte
 	r.hash.Reset()
 	_, _ = r.hash.Write(msg.Decommit0[:])
@@ -233,7 +237,7 @@ type RandomOTSender struct {
 	h_decommit0 [params.OTBytes]byte
 }
 
-// NewRandomOTSender sets up the receiver's state for a single Random OT.
+// NewRandomOTSender sets up the sender's state for a single Random OT.
 //
 // The nonce should be 32 bytes, and must be different if a single setup is used for multiple OTs.
 func NewRandomOTSender(nonce []byte, result *RandomOTSendSetup) (out RandomOTSender) {
@@ -2,6 +2,7 @@ package ot
 
 import (
 	"bytes"
+	"crypto/rand"
 	"testing"
 	"testing/quick"
 
@@ -19,12 +20,12 @@ func runRandomOT(choice bool, hash *hash.Hash) (*RandomOTSendResult, []byte, err
 	if choice {
 		safeChoice = 1
 	}
-	msgS0, setupS := RandomOTSetupSend(hash.Clone(), testGroup)
+	msgS0, setupS := RandomOTSetupSend(rand.Reader, hash.Clone(), testGroup)
 	setupR, err := RandomOTSetupReceive(hash.Clone(), msgS0)
 	if err != nil {
 		return nil, nil, err
 	}
-	receiver := NewRandomOTReceiver(nonce, setupR, safeChoice)
+	receiver := NewRandomOTReceiver(rand.Reader, nonce, setupR, safeChoice)
 	sender := NewRandomOTSender(nonce, setupS)
 
 	msgR1, err := receiver.Round1()
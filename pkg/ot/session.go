@@ -0,0 +1,109 @@
+package ot
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+// newSession creates the helper shared by both parties of a two-party protocol.
+func newSession(protocolID string, finalRound round.Number, group curve.Curve, selfID, otherID party.ID, sessionID []byte, pl *pool.Pool, opts []protocol.Option) (*round.Helper, error) {
+	info := round.Info{
+		ProtocolID:       protocolID,
+		FinalRoundNumber: finalRound,
+		SelfID:           selfID,
+		PartyIDs:         party.NewIDSlice([]party.ID{selfID, otherID}),
+		Threshold:        1,
+		Group:            group,
+	}
+	info.Apply(opts...)
+	return round.NewSession(info, sessionID, pl)
+}
+
+// StartSetupReceiver runs the Receiver's side of a Correlated OT setup as a protocol.
+//
+// The Receiver sends the first message, so its handler should be the leader.
+// The result is a *CorreOTReceiveSetup, to be used with StartMultiplyReceiver, or the lower level functions
+// of this package.
+func StartSetupReceiver(group curve.Curve, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		helper, err := newSession("ot/setup", 3, group, selfID, otherID, sessionID, pl, opts)
+		if err != nil {
+			return nil, fmt.Errorf("ot.StartSetupReceiver: %w", err)
+		}
+		return &setup1R{
+			Helper:   helper,
+			receiver: NewCorreOTSetupReceiver(helper.Rand(), pl, helper.Hash(), group),
+		}, nil
+	}
+}
+
+// StartSetupSender runs the Sender's side of a Correlated OT setup as a protocol.
+//
+// The result is a *CorreOTSendSetup. See StartSetupReceiver.
+func StartSetupSender(group curve.Curve, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		helper, err := newSession("ot/setup", 3, group, selfID, otherID, sessionID, pl, opts)
+		if err != nil {
+			return nil, fmt.Errorf("ot.StartSetupSender: %w", err)
+		}
+		return &setup1S{
+			Helper: helper,
+			sender: NewCorreOTSetupSender(helper.Rand(), pl, helper.Hash()),
+		}, nil
+	}
+}
+
+// StartMultiplyReceiver runs the Receiver's side of the multiplication protocol, with input beta.
+//
+// The Sender has input alpha, and the result for both parties is a curve.Scalar,
+// such that both results sum to alpha * beta.
+//
+// The Receiver sends the first message, so its handler should be the leader.
+// The setup can be reused for multiple executions, as long as the session ID is different each time.
+// Since the extended OTs are derived from the session ID, an empty one is rejected.
+func StartMultiplyReceiver(setup *CorreOTReceiveSetup, beta curve.Scalar, selfID, otherID party.ID, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if setup == nil || beta == nil {
+			return nil, fmt.Errorf("ot.StartMultiplyReceiver: setup or beta is nil")
+		}
+		if len(sessionID) == 0 {
+			return nil, fmt.Errorf("ot.StartMultiplyReceiver: sessionID is empty")
+		}
+		helper, err := newSession("ot/multiply", 2, beta.Curve(), selfID, otherID, sessionID, nil, opts)
+		if err != nil {
+			return nil, fmt.Errorf("ot.StartMultiplyReceiver: %w", err)
+		}
+		receiver, err := NewMultiplyReceiver(helper.Rand(), helper.Hash(), setup, beta)
+		if err != nil {
+			return nil, fmt.Errorf("ot.StartMultiplyReceiver: %w", err)
+		}
+		return &multiply1R{Helper: helper, receiver: receiver}, nil
+	}
+}
+
+// StartMultiplySender runs the Sender's side of the multiplication protocol, with input alpha.
+//
+// See StartMultiplyReceiver.
+func StartMultiplySender(setup *CorreOTSendSetup, alpha curve.Scalar, selfID, otherID party.ID, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if setup == nil || alpha == nil {
+			return nil, fmt.Errorf("ot.StartMultiplySender: setup or alpha is nil")
+		}
+		if len(sessionID) == 0 {
+			return nil, fmt.Errorf("ot.StartMultiplySender: sessionID is empty")
+		}
+		helper, err := newSession("ot/multiply", 2, alpha.Curve(), selfID, otherID, sessionID, nil, opts)
+		if err != nil {
+			return nil, fmt.Errorf("ot.StartMultiplySender: %w", err)
+		}
+		return &multiply1S{
+			Helper: helper,
+			sender: NewMultiplySender(helper.Rand(), helper.Hash(), setup, alpha),
+		}, nil
+	}
+}
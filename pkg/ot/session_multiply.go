@@ -0,0 +1,105 @@
+package ot
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+)
+
+// multiplyMessageR is the only message of the Receiver in the multiplication protocol.
+type multiplyMessageR struct {
+	Msg *MultiplyReceiveRound1Message
+}
+
+func (multiplyMessageR) RoundNumber() round.Number { return 1 }
+
+// multiplyMessageS is the only message of the Sender in the multiplication protocol.
+type multiplyMessageS struct {
+	Msg *MultiplySendRound1Message
+}
+
+func (multiplyMessageS) RoundNumber() round.Number { return 2 }
+
+type multiply1R struct {
+	*round.Helper
+	receiver *MultiplyReceiver
+}
+
+func (r *multiply1R) VerifyMessage(round.Message) error { return nil }
+
+func (r *multiply1R) StoreMessage(round.Message) error { return nil }
+
+func (r *multiply1R) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.SendMessage(out, &multiplyMessageR{Msg: r.receiver.Round1()}, ""); err != nil {
+		return r, err
+	}
+	return &multiply2R{multiply1R: r}, nil
+}
+
+func (multiply1R) MessageContent() round.Content { return nil }
+
+func (multiply1R) Number() round.Number { return 1 }
+
+type multiply2R struct {
+	*multiply1R
+	share curve.Scalar
+}
+
+func (r *multiply2R) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*multiplyMessageS)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Msg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+func (r *multiply2R) StoreMessage(msg round.Message) (err error) {
+	r.share, err = r.receiver.Round2(msg.Content.(*multiplyMessageS).Msg)
+	return
+}
+
+func (r *multiply2R) Finalize(chan<- *round.Message) (round.Session, error) {
+	return r.ResultRound(r.share), nil
+}
+
+func (r *multiply2R) MessageContent() round.Content {
+	return &multiplyMessageS{Msg: r.receiver.EmptyMultiplySendRound1Message()}
+}
+
+func (multiply2R) Number() round.Number { return 2 }
+
+type multiply1S struct {
+	*round.Helper
+	sender *MultiplySender
+	msg    *MultiplySendRound1Message
+	share  curve.Scalar
+}
+
+func (r *multiply1S) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*multiplyMessageR)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Msg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+func (r *multiply1S) StoreMessage(msg round.Message) (err error) {
+	r.msg, r.share, err = r.sender.Round1(msg.Content.(*multiplyMessageR).Msg)
+	return
+}
+
+func (r *multiply1S) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.SendMessage(out, &multiplyMessageS{Msg: r.msg}, ""); err != nil {
+		return r, err
+	}
+	return r.ResultRound(r.share), nil
+}
+
+func (multiply1S) MessageContent() round.Content { return &multiplyMessageR{} }
+
+func (multiply1S) Number() round.Number { return 1 }
@@ -0,0 +1,221 @@
+package ot
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+)
+
+// setupMessage1R is the first message of the Receiver in the setup protocol.
+type setupMessage1R struct {
+	Msg *CorreOTSetupReceiveRound1Message
+}
+
+func (setupMessage1R) RoundNumber() round.Number { return 1 }
+
+// setupMessage1S is the first message of the Sender in the setup protocol.
+type setupMessage1S struct {
+	Msg *CorreOTSetupSendRound1Message
+}
+
+func (setupMessage1S) RoundNumber() round.Number { return 2 }
+
+// setupMessage2R is the second message of the Receiver in the setup protocol.
+type setupMessage2R struct {
+	Msg *CorreOTSetupReceiveRound2Message
+}
+
+func (setupMessage2R) RoundNumber() round.Number { return 2 }
+
+// setupMessage2S is the second message of the Sender in the setup protocol.
+type setupMessage2S struct {
+	Msg *CorreOTSetupSendRound2Message
+}
+
+func (setupMessage2S) RoundNumber() round.Number { return 3 }
+
+// setupMessage3R is the last message of the Receiver in the setup protocol.
+type setupMessage3R struct {
+	Msg *CorreOTSetupReceiveRound3Message
+}
+
+func (setupMessage3R) RoundNumber() round.Number { return 3 }
+
+type setup1R struct {
+	*round.Helper
+	receiver *CorreOTSetupReceiver
+}
+
+func (r *setup1R) VerifyMessage(round.Message) error { return nil }
+
+func (r *setup1R) StoreMessage(round.Message) error { return nil }
+
+func (r *setup1R) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.SendMessage(out, &setupMessage1R{Msg: r.receiver.Round1()}, ""); err != nil {
+		return r, err
+	}
+	return &setup2R{setup1R: r}, nil
+}
+
+func (setup1R) MessageContent() round.Content { return nil }
+
+func (setup1R) Number() round.Number { return 1 }
+
+type setup2R struct {
+	*setup1R
+	msg *CorreOTSetupReceiveRound2Message
+}
+
+func (r *setup2R) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*setupMessage1S)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Msg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+func (r *setup2R) StoreMessage(msg round.Message) (err error) {
+	r.msg, err = r.receiver.Round2(msg.Content.(*setupMessage1S).Msg)
+	return
+}
+
+func (r *setup2R) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.SendMessage(out, &setupMessage2R{Msg: r.msg}, ""); err != nil {
+		return r, err
+	}
+	return &setup3R{setup1R: r.setup1R}, nil
+}
+
+func (setup2R) MessageContent() round.Content { return &setupMessage1S{} }
+
+func (setup2R) Number() round.Number { return 2 }
+
+type setup3R struct {
+	*setup1R
+	msg   *CorreOTSetupReceiveRound3Message
+	setup *CorreOTReceiveSetup
+}
+
+func (r *setup3R) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*setupMessage2S)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Msg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+func (r *setup3R) StoreMessage(msg round.Message) (err error) {
+	r.msg, r.setup, err = r.receiver.Round3(msg.Content.(*setupMessage2S).Msg)
+	return
+}
+
+func (r *setup3R) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.SendMessage(out, &setupMessage3R{Msg: r.msg}, ""); err != nil {
+		return r, err
+	}
+	return r.ResultRound(r.setup), nil
+}
+
+func (setup3R) MessageContent() round.Content { return &setupMessage2S{} }
+
+func (setup3R) Number() round.Number { return 3 }
+
+type setup1S struct {
+	*round.Helper
+	sender *CorreOTSetupSender
+	msg    *CorreOTSetupSendRound1Message
+}
+
+func (r *setup1S) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*setupMessage1R)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Msg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+func (r *setup1S) StoreMessage(msg round.Message) (err error) {
+	r.msg, err = r.sender.Round1(msg.Content.(*setupMessage1R).Msg)
+	return
+}
+
+func (r *setup1S) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.SendMessage(out, &setupMessage1S{Msg: r.msg}, ""); err != nil {
+		return r, err
+	}
+	return &setup2S{setup1S: r}, nil
+}
+
+func (r *setup1S) MessageContent() round.Content {
+	return &setupMessage1R{Msg: EmptyCorreOTSetupReceiveRound1Message(r.Group())}
+}
+
+func (setup1S) Number() round.Number { return 1 }
+
+type setup2S struct {
+	*setup1S
+	msg *CorreOTSetupSendRound2Message
+}
+
+func (r *setup2S) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*setupMessage2R)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Msg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+func (r *setup2S) StoreMessage(msg round.Message) error {
+	r.msg = r.sender.Round2(msg.Content.(*setupMessage2R).Msg)
+	return nil
+}
+
+func (r *setup2S) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.SendMessage(out, &setupMessage2S{Msg: r.msg}, ""); err != nil {
+		return r, err
+	}
+	return &setup3S{setup1S: r.setup1S}, nil
+}
+
+func (setup2S) MessageContent() round.Content { return &setupMessage2R{} }
+
+func (setup2S) Number() round.Number { return 2 }
+
+type setup3S struct {
+	*setup1S
+	setup *CorreOTSendSetup
+}
+
+func (r *setup3S) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*setupMessage3R)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Msg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+func (r *setup3S) StoreMessage(msg round.Message) (err error) {
+	r.setup, err = r.sender.Round3(msg.Content.(*setupMessage3R).Msg)
+	return
+}
+
+func (r *setup3S) Finalize(chan<- *round.Message) (round.Session, error) {
+	return r.ResultRound(r.setup), nil
+}
+
+func (setup3S) MessageContent() round.Content { return &setupMessage3R{} }
+
+func (setup3S) Number() round.Number { return 3 }
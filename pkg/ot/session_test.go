@@ -0,0 +1,171 @@
+package ot
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+// runSession runs both sides of a two-party protocol, the Receiver being the leader.
+func runSession(partyIDs party.IDSlice, sessionID []byte, receiver, sender protocol.StartFunc) (interface{}, interface{}, error) {
+	h0, err := protocol.NewTwoPartyHandler(receiver, sessionID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	h1, err := protocol.NewTwoPartyHandler(sender, sessionID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	var wg sync.WaitGroup
+	network := test.NewNetwork(partyIDs)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		test.HandlerLoop(partyIDs[0], h0, network)
+	}()
+	go func() {
+		defer wg.Done()
+		test.HandlerLoop(partyIDs[1], h1, network)
+	}()
+	wg.Wait()
+
+	result0, err := h0.Result()
+	if err != nil {
+		return nil, nil, err
+	}
+	result1, err := h1.Result()
+	if err != nil {
+		return nil, nil, err
+	}
+	return result0, result1, nil
+}
+
+func runSetupSession(t testing.TB, pl *pool.Pool, partyIDs party.IDSlice) (*CorreOTSendSetup, *CorreOTReceiveSetup) {
+	result0, result1, err := runSession(partyIDs, []byte("setup"),
+		StartSetupReceiver(testGroup, partyIDs[0], partyIDs[1], pl),
+		StartSetupSender(testGroup, partyIDs[1], partyIDs[0], pl))
+	require.NoError(t, err)
+	require.IsType(t, &CorreOTReceiveSetup{}, result0)
+	require.IsType(t, &CorreOTSendSetup{}, result1)
+	return result1.(*CorreOTSendSetup), result0.(*CorreOTReceiveSetup)
+}
+
+func runMultiplySession(partyIDs party.IDSlice, sessionID []byte, sendSetup *CorreOTSendSetup, receiveSetup *CorreOTReceiveSetup, alpha, beta curve.Scalar) (curve.Scalar, curve.Scalar, error) {
+	result0, result1, err := runSession(partyIDs, sessionID,
+		StartMultiplyReceiver(receiveSetup, beta, partyIDs[0], partyIDs[1]),
+		StartMultiplySender(sendSetup, alpha, partyIDs[1], partyIDs[0]))
+	if err != nil {
+		return nil, nil, err
+	}
+	return result1.(curve.Scalar), result0.(curve.Scalar), nil
+}
+
+func TestSession(t *testing.T) {
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+	partyIDs := test.PartyIDs(2)
+
+	sendSetup, receiveSetup := runSetupSession(t, pl, partyIDs)
+
+	for i := 0; i < 3; i++ {
+		alpha := sample.Scalar(rand.Reader, testGroup)
+		beta := sample.Scalar(rand.Reader, testGroup)
+		shareA, shareB, err := runMultiplySession(partyIDs, []byte(fmt.Sprintf("multiply %d", i)), sendSetup, receiveSetup, alpha, beta)
+		require.NoError(t, err)
+		alphabeta := testGroup.NewScalar().Set(alpha).Mul(beta)
+		require.True(t, alphabeta.Equal(shareA.Add(shareB)), "multiply failed to produce valid shares")
+	}
+
+	alpha := sample.Scalar(rand.Reader, testGroup)
+	_, err := StartMultiplyReceiver(receiveSetup, alpha, partyIDs[0], partyIDs[1])(nil)
+	require.Error(t, err, "a multiplication without session ID should be rejected")
+	_, err = StartMultiplySender(sendSetup, alpha, partyIDs[1], partyIDs[0])(nil)
+	require.Error(t, err, "a multiplication without session ID should be rejected")
+}
+
+func TestSetupMarshal(t *testing.T) {
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+	partyIDs := test.PartyIDs(2)
+
+	sendSetup, receiveSetup := runSetupSession(t, pl, partyIDs)
+
+	data, err := cbor.Marshal(sendSetup)
+	require.NoError(t, err)
+	sendSetup2 := new(CorreOTSendSetup)
+	require.NoError(t, cbor.Unmarshal(data, sendSetup2))
+	require.Equal(t, sendSetup, sendSetup2)
+
+	data, err = cbor.Marshal(receiveSetup)
+	require.NoError(t, err)
+	receiveSetup2 := new(CorreOTReceiveSetup)
+	require.NoError(t, cbor.Unmarshal(data, receiveSetup2))
+	require.Equal(t, receiveSetup, receiveSetup2)
+
+	require.Error(t, new(CorreOTSendSetup).UnmarshalBinary(data))
+
+	alpha := sample.Scalar(rand.Reader, testGroup)
+	beta := sample.Scalar(rand.Reader, testGroup)
+	shareA, shareB, err := runMultiplySession(partyIDs, []byte("multiply"), sendSetup2, receiveSetup2, alpha, beta)
+	require.NoError(t, err)
+	require.True(t, testGroup.NewScalar().Set(alpha).Mul(beta).Equal(shareA.Add(shareB)))
+}
+
+func TestMultiplyMessageMarshal(t *testing.T) {
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+	sendSetup, receiveSetup, err := runCorreOTSetup(pl, hash.New())
+	require.NoError(t, err)
+
+	alpha := sample.Scalar(rand.Reader, testGroup)
+	beta := sample.Scalar(rand.Reader, testGroup)
+	sender := NewMultiplySender(rand.Reader, hash.New(), sendSetup, alpha)
+	receiver, err := NewMultiplyReceiver(rand.Reader, hash.New(), receiveSetup, beta)
+	require.NoError(t, err)
+
+	data, err := cbor.Marshal(receiver.Round1())
+	require.NoError(t, err)
+	msgR1 := new(MultiplyReceiveRound1Message)
+	require.NoError(t, cbor.Unmarshal(data, msgR1))
+
+	msgS1, shareA, err := sender.Round1(msgR1)
+	require.NoError(t, err)
+	data, err = cbor.Marshal(msgS1)
+	require.NoError(t, err)
+	msgS1 = EmptyMultiplySendRound1Message(testGroup)
+	require.NoError(t, cbor.Unmarshal(data, msgS1))
+
+	truncated := *msgS1
+	truncated.RCheck = truncated.RCheck[1:]
+	_, err = receiver.Round2(&truncated)
+	require.Error(t, err, "a malformed message should be rejected")
+
+	shareB, err := receiver.Round2(msgS1)
+	require.NoError(t, err)
+	require.True(t, testGroup.NewScalar().Set(alpha).Mul(beta).Equal(shareA.Add(shareB)))
+}
+
+func BenchmarkMultiplySession(b *testing.B) {
+	b.StopTimer()
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+	partyIDs := test.PartyIDs(2)
+	sendSetup, receiveSetup := runSetupSession(b, pl, partyIDs)
+	alpha := sample.Scalar(rand.Reader, testGroup)
+	beta := sample.Scalar(rand.Reader, testGroup)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = runMultiplySession(partyIDs, []byte("multiply"), sendSetup, receiveSetup, alpha, beta)
+	}
+}
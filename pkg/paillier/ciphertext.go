@@ -1,7 +1,6 @@
 package paillier
 
 import (
-	"crypto/rand"
 	"io"
 
 	"github.com/cronokirby/saferith"
@@ -52,11 +51,11 @@ func (ct Ciphertext) Clone() *Ciphertext {
 
 // Randomize multiplies the ciphertext's nonce by a newly generated one.
 // ct ← ct ⋅ nonceᴺ (mod N²).
-// If nonce is nil, a random one is generated.
+// If nonce is nil, a random one is sampled from rand.
 // The receiver is updated, and the nonce update is returned.
-func (ct *Ciphertext) Randomize(pk *PublicKey, nonce *saferith.Nat) *saferith.Nat {
+func (ct *Ciphertext) Randomize(rand io.Reader, pk *PublicKey, nonce *saferith.Nat) *saferith.Nat {
 	if nonce == nil {
-		nonce = sample.UnitModN(rand.Reader, pk.n.Modulus)
+		nonce = sample.UnitModN(rand, pk.n.Modulus)
 	}
 	// c = c*r^N
 	tmp := pk.nSquared.Exp(nonce, pk.nNat)
@@ -1,7 +1,6 @@
 package paillier
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
@@ -72,13 +71,13 @@ func ValidateN(n *saferith.Modulus) error {
 }
 
 // Enc returns the encryption of m under the public key pk.
-// The nonce used to encrypt is returned.
+// The nonce used to encrypt is sampled from rand, and returned.
 //
 // The message m must be in the range [-(N-1)/2, …, (N-1)/2] and panics otherwise.
 //
 // ct = (1+N)ᵐρᴺ (mod N²).
-func (pk PublicKey) Enc(m *saferith.Int) (*Ciphertext, *saferith.Nat) {
-	nonce := sample.UnitModN(rand.Reader, pk.n.Modulus)
+func (pk PublicKey) Enc(rand io.Reader, m *saferith.Int) (*Ciphertext, *saferith.Nat) {
+	nonce := sample.UnitModN(rand, pk.n.Modulus)
 	return pk.EncWithNonce(m, nonce), nonce
 }
 
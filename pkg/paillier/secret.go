@@ -1,9 +1,9 @@
 package paillier
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/cronokirby/saferith"
 	"github.com/taurusgroup/multi-party-sig/internal/params"
@@ -56,16 +56,16 @@ func (sk *SecretKey) Phi() *saferith.Nat {
 }
 
 // KeyGen generates a new PublicKey and it's associated SecretKey.
-func KeyGen(pl *pool.Pool) (pk *PublicKey, sk *SecretKey) {
-	sk = NewSecretKey(pl)
+func KeyGen(rand io.Reader, pl *pool.Pool) (pk *PublicKey, sk *SecretKey) {
+	sk = NewSecretKey(rand, pl)
 	pk = sk.PublicKey
 	return
 }
 
 // NewSecretKey generates primes p and q suitable for the scheme, and returns the initialized SecretKey.
-func NewSecretKey(pl *pool.Pool) *SecretKey {
-	// TODO maybe we could take the reader as argument?
-	return NewSecretKeyFromPrimes(sample.Paillier(rand.Reader, pl))
+// The primes are sampled from rand, and the result only depends on rand if pl is nil.
+func NewSecretKey(rand io.Reader, pl *pool.Pool) *SecretKey {
+	return NewSecretKeyFromPrimes(sample.Paillier(rand, pl))
 }
 
 // NewSecretKeyFromPrimes generates a new SecretKey. Assumes that P and Q are prime.
@@ -148,8 +148,10 @@ func (sk *SecretKey) DecWithRandomness(ct *Ciphertext) (*saferith.Int, *saferith
 	return m, r, nil
 }
 
-func (sk SecretKey) GeneratePedersen() (*pedersen.Parameters, *saferith.Nat) {
-	s, t, lambda := sample.Pedersen(rand.Reader, sk.phi, sk.n.Modulus)
+// GeneratePedersen samples Pedersen parameters from rand, for the modulus N of this key.
+// The secret λ such that s = tˡ mod N is also returned.
+func (sk SecretKey) GeneratePedersen(rand io.Reader) (*pedersen.Parameters, *saferith.Nat) {
+	s, t, lambda := sample.Pedersen(rand, sk.phi, sk.n.Modulus)
 	ped := pedersen.New(sk.n, s, t)
 	return ped, lambda
 }
@@ -135,7 +135,9 @@ func (p *Pool) Search(count int, f func() interface{}) []interface{} {
 	results := make([]interface{}, count)
 
 	ctr := int64(count)
-	ctrChanged := make(chan struct{})
+	// Every worker signals at most once after the counter drops to zero, so this
+	// buffer lets them finish even though we stop listening at that point.
+	ctrChanged := make(chan struct{}, count+p.workerCount)
 	cmd := command{
 		search:     true,
 		ctr:        &ctr,
@@ -169,7 +171,9 @@ func (p *Pool) Parallelize(count int, f func(int) interface{}) []interface{} {
 	results := make([]interface{}, count)
 
 	ctr := int64(count)
-	ctrChanged := make(chan struct{})
+	// Each command signals exactly once, possibly after we've seen the counter
+	// reach zero and returned, so the buffer must hold all of them.
+	ctrChanged := make(chan struct{}, count)
 	cmdI := 0
 	for cmdI < count {
 		cmd := command{
@@ -0,0 +1,67 @@
+package pool
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runWithTimeout fails the test if f does not return in time, which happens when the pool deadlocks.
+func runWithTimeout(t *testing.T, f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("pool deadlocked")
+	}
+}
+
+func TestParallelizeSingleWorker(t *testing.T) {
+	p := NewPool(1)
+	defer p.TearDown()
+
+	runWithTimeout(t, func() {
+		// the worker must never block on a call which has already returned
+		for call := 0; call < 1000; call++ {
+			results := p.Parallelize(8, func(i int) interface{} { return i })
+			for i, result := range results {
+				assert.Equal(t, i, result)
+			}
+		}
+	})
+}
+
+func TestSearchSingleWorker(t *testing.T) {
+	p := NewPool(1)
+	defer p.TearDown()
+
+	runWithTimeout(t, func() {
+		for call := 0; call < 1000; call++ {
+			var tries int64
+			results := p.Search(4, func() interface{} {
+				// only every other candidate is successful
+				if atomic.AddInt64(&tries, 1)%2 == 0 {
+					return struct{}{}
+				}
+				return nil
+			})
+			require.Len(t, results, 4)
+			for _, result := range results {
+				assert.NotNil(t, result)
+			}
+		}
+	})
+}
+
+func TestParallelizeNil(t *testing.T) {
+	var p *Pool
+	results := p.Parallelize(3, func(i int) interface{} { return i * i })
+	assert.Equal(t, []interface{}{0, 1, 4}, results)
+}
@@ -0,0 +1,180 @@
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// complaintRound is the RoundNumber of the messages containing the view of a party of a broadcast round.
+//
+// They are sent by a MultiHandler with an Identity when the echo broadcast fails,
+// in order to find out which party sent different messages to different parties.
+const complaintRound round.Number = math.MaxUint16
+
+// ErrBroadcastVerification is returned by Result when the parties did not receive the same broadcast messages.
+//
+// If the MultiHandler was given an Identity, the culprits are the parties which either sent different broadcast messages
+// to different parties, or sent an incorrect verification hash.
+var ErrBroadcastVerification = errors.New("broadcast verification failed")
+
+// complaint tracks the resolution of a failed echo broadcast.
+type complaint struct {
+	// number is the broadcast round whose verification failed.
+	number round.Number
+	// pending contains the messages with an incorrect BroadcastVerification,
+	// from the parties whose view we have not yet checked.
+	pending map[party.ID]*Message
+	// checked contains the parties whose view was already checked.
+	checked map[party.ID]bool
+	// culprits found so far, along with the evidence.
+	culprits map[party.ID]bool
+	evidence []*Message
+}
+
+// complain is called when some BroadcastVerification for the current round differs from our own,
+// and again whenever another one is received.
+//
+// Without an Identity, the culprit cannot be identified, and the protocol aborts.
+// Otherwise, we send our view of the broadcast round to all, and wait for the views of the parties whose
+// verification hash differs from ours.
+// Since broadcast messages are signed, two different messages from the same sender prove that it equivocated.
+// If the view of a party does not contain a different message, then it sent an incorrect verification hash.
+func (h *MultiHandler) complain() {
+	if h.identity == nil {
+		h.abort(ErrBroadcastVerification)
+		return
+	}
+
+	r := h.currentRound
+	number := r.Number()
+	expected := h.broadcastHashes[number-1]
+	c := h.complaint
+	first := c == nil
+	if first {
+		c = &complaint{
+			number:   number - 1,
+			pending:  map[party.ID]*Message{},
+			checked:  map[party.ID]bool{},
+			culprits: map[party.ID]bool{},
+		}
+		h.complaint = c
+	}
+	for _, q := range []map[party.ID]*Message{h.messages[number], h.broadcast[number]} {
+		for id, msg := range q {
+			if msg != nil && !bytes.Equal(expected, msg.BroadcastVerification) && !c.checked[id] && c.pending[id] == nil {
+				c.pending[id] = msg
+			}
+		}
+	}
+	if first && !h.sendView() {
+		return
+	}
+
+	for id := range c.pending {
+		if view := h.views[id]; view != nil {
+			h.checkView(view)
+		}
+	}
+	h.resolve()
+}
+
+// sendView sends our view of the broadcast round of the complaint to all parties.
+// It returns false if the execution was aborted.
+func (h *MultiHandler) sendView() bool {
+	r := h.currentRound
+	c := h.complaint
+
+	view := make([]*Message, 0, r.N())
+	for _, id := range r.PartyIDs() {
+		view = append(view, h.broadcast[c.number][id])
+	}
+	data, err := cbor.Marshal(view)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal broadcast view: %w", err))
+	}
+	msg := &Message{
+		SSID:        r.SSID(),
+		From:        r.SelfID(),
+		Protocol:    r.ProtocolID(),
+		RoundNumber: complaintRound,
+		Data:        data,
+	}
+	if err = msg.Sign(r.Rand(), h.identity); err != nil {
+		h.abort(err, r.SelfID())
+		return false
+	}
+	h.out <- msg
+	return true
+}
+
+// acceptView stores the view of another party, and checks it if we are resolving a complaint.
+func (h *MultiHandler) acceptView(msg *Message) {
+	if h.identity == nil || h.views[msg.From] != nil {
+		return
+	}
+	h.views[msg.From] = msg
+	if h.complaint == nil || h.complaint.pending[msg.From] == nil {
+		return
+	}
+	h.checkView(msg)
+	h.resolve()
+}
+
+// checkView compares the view of a party whose verification hash differs from ours with our own.
+func (h *MultiHandler) checkView(view *Message) {
+	c := h.complaint
+	from := view.From
+	sent := c.pending[from]
+	delete(c.pending, from)
+	c.checked[from] = true
+
+	r := h.currentRound
+	var msgs []*Message
+	if err := cbor.Unmarshal(view.Data, &msgs); err != nil || len(msgs) != r.N() {
+		c.blame(from, sent, view)
+		return
+	}
+	equivocation := false
+	for i, id := range r.PartyIDs() {
+		msg := msgs[i]
+		if msg == nil || msg.From != id || !msg.Broadcast || msg.RoundNumber != c.number ||
+			msg.Protocol != r.ProtocolID() || !bytes.Equal(msg.SSID, r.SSID()) || !msg.Verify(h.identity) {
+			c.blame(from, sent, view)
+			return
+		}
+		ours := h.broadcast[c.number][id]
+		if !bytes.Equal(ours.Hash(), msg.Hash()) {
+			c.blame(id, ours, msg)
+			equivocation = true
+		}
+	}
+	// the view is consistent with ours, so the verification hash of the sender is incorrect.
+	if !equivocation {
+		c.blame(from, sent, view)
+	}
+}
+
+// resolve aborts once the views of all parties with an incorrect verification hash have been checked.
+func (h *MultiHandler) resolve() {
+	c := h.complaint
+	if len(c.pending) > 0 || h.finished() {
+		return
+	}
+	culprits := make([]party.ID, 0, len(c.culprits))
+	for id := range c.culprits {
+		culprits = append(culprits, id)
+	}
+	h.abort(ErrBroadcastVerification, party.NewIDSlice(culprits)...)
+	h.err.Evidence = c.evidence
+}
+
+func (c *complaint) blame(culprit party.ID, evidence ...*Message) {
+	c.culprits[culprit] = true
+	c.evidence = append(c.evidence, evidence...)
+}
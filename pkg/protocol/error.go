@@ -13,6 +13,9 @@ type Error struct {
 	Culprits []party.ID
 	// Err is the underlying error.
 	Err error
+	// Evidence contains the messages received from the culprits which caused the abort, if any.
+	// When the handler was given an Identity, they are signed and can be verified by a third party with Message.Verify.
+	Evidence []*Message
 }
 
 // Error implement error.
@@ -2,9 +2,11 @@ package protocol
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
@@ -45,10 +47,177 @@ type MultiHandler struct {
 	broadcastHashes map[round.Number][]byte
 	out             chan *Message
 	mtx             sync.Mutex
+
+	handlerOptions
+	roundTimer *time.Timer
+	// done is closed once the execution has finished.
+	done chan struct{}
+
+	// views contains the views of the broadcast round sent by other parties when the echo broadcast failed.
+	views map[party.ID]*Message
+	// complaint is set once the echo broadcast failed.
+	complaint *complaint
+
+	// sessionID is the sessionID given to the StartFunc, and ssid the SSID of the session.
+	sessionID, ssid []byte
+	// snapshotter saves the current round so that the execution can be resumed.
+	snapshotter snapshotter
+}
+
+// ErrRoundTimeout is returned by Result when a MultiHandler created with WithRoundTimeout
+// did not receive all messages for a round in time.
+var ErrRoundTimeout = errors.New("round timed out")
+
+// handlerOptions contains the options given to NewMultiHandler or NewTwoPartyHandler.
+type handlerOptions struct {
+	// ctx bounds the whole execution, and is nil if no context was given.
+	ctx context.Context
+	// roundTimeout bounds the time spent waiting for the messages of a single round, if positive.
+	roundTimeout time.Duration
+	// identity signs outgoing messages and authenticates incoming ones, and is nil if no Identity was given.
+	identity Identity
+}
+
+// HandlerOption configures a MultiHandler or a TwoPartyHandler.
+type HandlerOption func(*handlerOptions)
+
+// WithContext bounds the execution of the protocol by ctx.
+//
+// When ctx is done before the protocol has finished, the handler aborts with an Error whose Culprits
+// are the parties from which messages for the current round are still missing, and which wraps ctx.Err().
+//
+// It is ignored by the TwoPartyHandler.
+func WithContext(ctx context.Context) HandlerOption {
+	return func(h *handlerOptions) {
+		h.ctx = ctx
+	}
+}
+
+// WithRoundTimeout bounds the time the handler waits for the messages of each round.
+//
+// When the timeout expires, the handler aborts with an Error whose Culprits
+// are the parties from which messages for the current round are still missing, and which wraps ErrRoundTimeout.
+//
+// It is ignored by the TwoPartyHandler.
+func WithRoundTimeout(timeout time.Duration) HandlerOption {
+	return func(h *handlerOptions) {
+		h.roundTimeout = timeout
+	}
+}
+
+// WithIdentity enables the authentication of messages.
+//
+// All messages output by the handler are signed with the long-term key of this party,
+// and received messages without a valid signature by their sender are ignored.
+// When the protocol aborts because of a message, it is included as evidence in the returned Error.
+func WithIdentity(identity Identity) HandlerOption {
+	return func(h *handlerOptions) {
+		h.identity = identity
+	}
 }
 
 // NewMultiHandler expects a StartFunc for the desired protocol. It returns a handler that the user can interact with.
-func NewMultiHandler(create StartFunc, sessionID []byte) (*MultiHandler, error) {
+func NewMultiHandler(create StartFunc, sessionID []byte, opts ...HandlerOption) (*MultiHandler, error) {
+	h, err := newMultiHandler(create, sessionID, opts)
+	if err != nil {
+		return nil, err
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.finalize()
+	h.start()
+	return h, nil
+}
+
+// ResumeMultiHandler recreates a handler from the state returned by MultiHandler.MarshalBinary,
+// for instance after the process was restarted.
+//
+// create must be the StartFunc given to NewMultiHandler, with the same arguments.
+// The current round is restored from its snapshot, and the messages which were received but not yet
+// processed are processed again.
+//
+// The messages for the current round are output again by Listen, since they may not have been delivered.
+// Other parties ignore them if they were already received.
+// Messages for earlier rounds are not output again, so the state should be saved after
+// the messages returned by Listen have been sent.
+func ResumeMultiHandler(create StartFunc, data []byte, opts ...HandlerOption) (*MultiHandler, error) {
+	st, err := unmarshalState(data)
+	if err != nil {
+		return nil, err
+	}
+	h, err := newMultiHandler(create, st.SessionID, opts)
+	if err != nil {
+		return nil, err
+	}
+	r, err := st.restore(h.currentRound)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.currentRound = r
+	h.rounds = map[round.Number]round.Session{r.Number(): r}
+	h.snapshotter = snapshotter{snapshot: st.Round, sent: st.Sent}
+	for number, hash := range st.BroadcastHashes {
+		h.broadcastHashes[number] = hash
+	}
+	for _, msg := range st.Queued {
+		h.store(msg)
+	}
+	for _, msg := range st.Views {
+		h.views[msg.From] = msg
+	}
+	for _, msg := range st.Sent {
+		h.out <- msg
+	}
+	if h.processQueued() {
+		h.finalize()
+	}
+	h.start()
+	return h, nil
+}
+
+// MarshalBinary returns the state of the handler, which can be given to ResumeMultiHandler.
+//
+// The rounds of the protocol must support snapshots, otherwise ErrSnapshotUnsupported is returned.
+// An error is also returned once the execution has finished, or while a failed echo broadcast is being resolved.
+//
+// The state contains secret data, such as the secret shares of this party, and must be encrypted by the caller.
+func (h *MultiHandler) MarshalBinary() ([]byte, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.finished() {
+		return nil, errors.New("protocol: execution has finished")
+	}
+	if h.complaint != nil {
+		return nil, errors.New("protocol: broadcast verification failed")
+	}
+	st := &state{
+		SessionID:       h.sessionID,
+		SSID:            h.ssid,
+		BroadcastHashes: h.broadcastHashes,
+	}
+	number := h.currentRound.Number()
+	for _, q := range []map[round.Number]map[party.ID]*Message{h.broadcast, h.messages} {
+		for n, msgs := range q {
+			if n < number {
+				continue
+			}
+			for _, msg := range msgs {
+				if msg != nil {
+					st.Queued = append(st.Queued, msg)
+				}
+			}
+		}
+	}
+	for _, msg := range h.views {
+		st.Views = append(st.Views, msg)
+	}
+	return h.snapshotter.marshal(st)
+}
+
+func newMultiHandler(create StartFunc, sessionID []byte, opts []HandlerOption) (*MultiHandler, error) {
 	r, err := create(sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("protocol: failed to create round: %w", err)
@@ -60,11 +229,28 @@ func NewMultiHandler(create StartFunc, sessionID []byte) (*MultiHandler, error)
 		broadcast:       newQueue(r.OtherPartyIDs(), r.FinalRoundNumber()),
 		broadcastHashes: map[round.Number][]byte{},
 		out:             make(chan *Message, 2*r.N()),
+		done:            make(chan struct{}),
+		views:           map[party.ID]*Message{},
+		sessionID:       sessionID,
+		ssid:            r.SSID(),
+	}
+	for _, opt := range opts {
+		opt(&h.handlerOptions)
 	}
-	h.finalize()
 	return h, nil
 }
 
+// start starts the deadlines of the execution, if any.
+func (h *MultiHandler) start() {
+	if h.finished() {
+		return
+	}
+	h.startRoundTimer()
+	if h.ctx != nil {
+		go h.watchContext()
+	}
+}
+
 // Result returns the protocol result if the protocol completed successfully. Otherwise an error is returned.
 func (h *MultiHandler) Result() (interface{}, error) {
 	h.mtx.Lock()
@@ -78,6 +264,18 @@ func (h *MultiHandler) Result() (interface{}, error) {
 	return nil, errors.New("protocol: not finished")
 }
 
+// SSID returns the session identifier included in all messages of this execution.
+func (h *MultiHandler) SSID() []byte {
+	return h.ssid
+}
+
+// ProtocolID returns the identifier of the protocol being executed.
+func (h *MultiHandler) ProtocolID() string {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.currentRound.ProtocolID()
+}
+
 // Listen returns a channel with outgoing messages that must be sent to other parties.
 // The message received should be _reliably_ broadcast if msg.Broadcast is true.
 // The channel is closed when either an error occurs or the protocol detects an error.
@@ -116,7 +314,7 @@ func (h *MultiHandler) CanAccept(msg *Message) bool {
 	}
 
 	// check if message for unexpected round
-	if msg.RoundNumber > r.FinalRoundNumber() {
+	if msg.RoundNumber > r.FinalRoundNumber() && msg.RoundNumber != complaintRound {
 		return false
 	}
 
@@ -134,15 +332,22 @@ func (h *MultiHandler) CanAccept(msg *Message) bool {
 func (h *MultiHandler) Accept(msg *Message) {
 	h.mtx.Lock()
 	defer h.mtx.Unlock()
+	h.accept(msg)
+}
 
+func (h *MultiHandler) accept(msg *Message) {
 	// exit early if the message is bad, or if we are already done
-	if !h.CanAccept(msg) || h.err != nil || h.result != nil || h.duplicate(msg) {
+	if !h.CanAccept(msg) || h.finished() || !h.authentic(msg) || h.duplicate(msg) {
+		return
+	}
+	if msg.RoundNumber == complaintRound {
+		h.acceptView(msg)
 		return
 	}
 
 	// a msg with roundNumber 0 is considered an abort from another party
 	if msg.RoundNumber == 0 {
-		h.abort(fmt.Errorf("aborted by other party with error: \"%s\"", msg.Data), msg.From)
+		h.blame(fmt.Errorf("aborted by other party with error: \"%s\"", msg.Data), msg)
 		return
 	}
 
@@ -150,15 +355,22 @@ func (h *MultiHandler) Accept(msg *Message) {
 	if h.currentRound.Number() != msg.RoundNumber {
 		return
 	}
+	// with an Identity, the content of a message is only verified if its sender had the same view of the previous
+	// broadcast round, so that an equivocation is identified instead of its consequences.
+	// Without one, verifying the content first may still identify a party which sent an invalid message.
+	if h.identity != nil && !h.checkBroadcastHash() {
+		h.complain()
+		return
+	}
 
 	if msg.Broadcast {
 		if err := h.verifyBroadcastMessage(msg); err != nil {
-			h.abort(err, msg.From)
+			h.blame(err, msg)
 			return
 		}
 	} else {
 		if err := h.verifyMessage(msg); err != nil {
-			h.abort(err, msg.From)
+			h.blame(err, msg)
 			return
 		}
 	}
@@ -236,7 +448,7 @@ func (h *MultiHandler) finalize() {
 		return
 	}
 	if !h.checkBroadcastHash() {
-		h.abort(errors.New("broadcast verification failed"))
+		h.complain()
 		return
 	}
 
@@ -252,6 +464,7 @@ func (h *MultiHandler) finalize() {
 	}
 
 	// forward messages with the correct header.
+	var sent []*Message
 	for roundMsg := range out {
 		data, err := cbor.Marshal(roundMsg.Content)
 		if err != nil {
@@ -267,9 +480,16 @@ func (h *MultiHandler) finalize() {
 			Broadcast:             roundMsg.Broadcast,
 			BroadcastVerification: h.broadcastHashes[r.Number()-1],
 		}
+		if h.identity != nil {
+			if err = msg.Sign(r.Rand(), h.identity); err != nil {
+				h.abort(err, r.SelfID())
+				return
+			}
+		}
 		if msg.Broadcast {
 			h.store(msg)
 		}
+		sent = append(sent, msg)
 		h.out <- msg
 	}
 
@@ -280,12 +500,17 @@ func (h *MultiHandler) finalize() {
 	}
 	h.rounds[roundNumber] = r
 	h.currentRound = r
+	h.startRoundTimer()
+	h.snapshotter.next(r, sent)
 
 	// either we get the current round, the next one, or one of the two final ones
 	switch R := r.(type) {
 	// An abort happened
 	case *round.Abort:
 		h.abort(R.Err, R.Culprits...)
+		if h.err != nil {
+			h.err.Evidence = h.receivedFrom(R.Culprits)
+		}
 		return
 	// We have the result
 	case *round.Output:
@@ -295,6 +520,23 @@ func (h *MultiHandler) finalize() {
 	default:
 	}
 
+	if !h.processQueued() {
+		return
+	}
+
+	// we only do this if the current round has changed
+	h.finalize()
+}
+
+// processQueued verifies the messages for the current round which were received before it started.
+// It returns false if the execution was aborted.
+func (h *MultiHandler) processQueued() bool {
+	r := h.currentRound
+	roundNumber := r.Number()
+	if h.identity != nil && !h.checkBroadcastHash() {
+		h.complain()
+		return false
+	}
 	if _, ok := r.(round.BroadcastRound); ok {
 		// handle queued broadcast messages, which will then check the subsequent normal message
 		for id, m := range h.broadcast[roundNumber] {
@@ -302,9 +544,9 @@ func (h *MultiHandler) finalize() {
 				continue
 			}
 			// if false, we aborted and so we return
-			if err = h.verifyBroadcastMessage(m); err != nil {
-				h.abort(err, m.From)
-				return
+			if err := h.verifyBroadcastMessage(m); err != nil {
+				h.blame(err, m)
+				return false
 			}
 		}
 	} else {
@@ -314,30 +556,111 @@ func (h *MultiHandler) finalize() {
 				continue
 			}
 			// if false, we aborted and so we return
-			if err = h.verifyMessage(m); err != nil {
-				h.abort(err, m.From)
-				return
+			if err := h.verifyMessage(m); err != nil {
+				h.blame(err, m)
+				return false
 			}
 		}
 	}
+	return true
+}
 
-	// we only do this if the current round has changed
-	h.finalize()
+// startRoundTimer restarts the timeout for the current round, if one was set.
+func (h *MultiHandler) startRoundTimer() {
+	if h.roundTimeout <= 0 {
+		return
+	}
+	if h.roundTimer != nil {
+		h.roundTimer.Stop()
+	}
+	number := h.currentRound.Number()
+	h.roundTimer = time.AfterFunc(h.roundTimeout, func() {
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		// the round may have been completed while waiting for the lock
+		if h.finished() || h.currentRound.Number() != number {
+			return
+		}
+		h.abort(fmt.Errorf("round %d: %w", number, ErrRoundTimeout), h.missing()...)
+	})
+}
+
+// watchContext aborts the execution once the context is done.
+func (h *MultiHandler) watchContext() {
+	select {
+	case <-h.ctx.Done():
+	case <-h.done:
+		return
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.finished() {
+		return
+	}
+	h.abort(fmt.Errorf("round %d: %w", h.currentRound.Number(), h.ctx.Err()), h.missing()...)
+}
+
+// finished returns true if the execution has stopped, either with a result or an error.
+func (h *MultiHandler) finished() bool {
+	select {
+	case <-h.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// missing returns the parties from which a message for the current round has not been received yet.
+func (h *MultiHandler) missing() []party.ID {
+	if h.complaint != nil {
+		pending := make([]party.ID, 0, len(h.complaint.pending))
+		for id := range h.complaint.pending {
+			pending = append(pending, id)
+		}
+		return party.NewIDSlice(pending)
+	}
+	r := h.currentRound
+	number := r.Number()
+	var missing party.IDSlice
+	for _, id := range r.OtherPartyIDs() {
+		if _, ok := r.(round.BroadcastRound); ok {
+			if q := h.broadcast[number]; q != nil && q[id] == nil {
+				missing = append(missing, id)
+				continue
+			}
+		}
+		if expectsNormalMessage(r) {
+			if q := h.messages[number]; q != nil && q[id] == nil {
+				missing = append(missing, id)
+			}
+		}
+	}
+	return missing
 }
 
 func (h *MultiHandler) abort(err error, culprits ...party.ID) {
+	if h.roundTimer != nil {
+		h.roundTimer.Stop()
+	}
+	close(h.done)
+
 	if err != nil {
 		h.err = &Error{
 			Culprits: culprits,
 			Err:      err,
 		}
-		select {
-		case h.out <- &Message{
+		msg := &Message{
 			SSID:     h.currentRound.SSID(),
 			From:     h.currentRound.SelfID(),
 			Protocol: h.currentRound.ProtocolID(),
 			Data:     []byte(h.err.Error()),
-		}:
+		}
+		if h.identity != nil {
+			// the other parties will ignore the message if it could not be signed
+			_ = msg.Sign(h.currentRound.Rand(), h.identity)
+		}
+		select {
+		case h.out <- msg:
 		default:
 		}
 
@@ -345,9 +668,41 @@ func (h *MultiHandler) abort(err error, culprits ...party.ID) {
 	close(h.out)
 }
 
+// blame aborts the execution with the sender of msg as culprit, and msg as evidence.
+func (h *MultiHandler) blame(err error, msg *Message) {
+	h.abort(err, msg.From)
+	h.err.Evidence = []*Message{msg}
+}
+
+// authentic returns true if msg was signed by its sender, or if no Identity was given.
+func (h *handlerOptions) authentic(msg *Message) bool {
+	if h.identity == nil {
+		return true
+	}
+	return msg.Verify(h.identity)
+}
+
+// receivedFrom returns all messages received from the given parties, ordered by round.
+func (h *MultiHandler) receivedFrom(ids []party.ID) []*Message {
+	var msgs []*Message
+	for number := round.Number(2); number <= h.currentRound.FinalRoundNumber(); number++ {
+		for _, id := range ids {
+			if msg := h.broadcast[number][id]; msg != nil {
+				msgs = append(msgs, msg)
+			}
+			if msg := h.messages[number][id]; msg != nil {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	return msgs
+}
+
 // Stop cancels the current execution of the protocol, and alerts the other users.
 func (h *MultiHandler) Stop() {
-	if h.err != nil || h.result != nil {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if !h.finished() {
 		h.abort(errors.New("aborted by user"), h.currentRound.SelfID())
 	}
 }
@@ -400,7 +755,7 @@ func (h *MultiHandler) receivedAll() bool {
 }
 
 func (h *MultiHandler) duplicate(msg *Message) bool {
-	if msg.RoundNumber == 0 {
+	if msg.RoundNumber == 0 || msg.RoundNumber == complaintRound {
 		return false
 	}
 	var q map[party.ID]*Message
@@ -458,7 +813,7 @@ func getRoundMessage(msg *Message, r round.Session) (round.Message, error) {
 	return roundMsg, nil
 }
 
-// checkBroadcastHash is run after receivedAll() and checks whether all provided verification hashes are correct.
+// checkBroadcastHash checks whether the verification hashes of all messages received for the current round are correct.
 func (h *MultiHandler) checkBroadcastHash() bool {
 	number := h.currentRound.Number()
 	// check BroadcastVerification
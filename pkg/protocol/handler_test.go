@@ -0,0 +1,394 @@
+package protocol_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	mrand "math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
+	"github.com/taurusgroup/multi-party-sig/protocols/example"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost"
+)
+
+// runWithout runs the xor protocol between all partyIDs, where the messages of absent are never delivered.
+func runWithout(t *testing.T, partyIDs party.IDSlice, absent party.ID, opts ...protocol.HandlerOption) map[party.ID]*protocol.MultiHandler {
+	handlers := make(map[party.ID]*protocol.MultiHandler, len(partyIDs))
+	for _, id := range partyIDs {
+		if id == absent {
+			continue
+		}
+		h, err := protocol.NewMultiHandler(example.StartXOR(id, partyIDs), nil, opts...)
+		require.NoError(t, err)
+		handlers[id] = h
+	}
+
+	var wg sync.WaitGroup
+	for id, h := range handlers {
+		wg.Add(1)
+		go func(id party.ID, h *protocol.MultiHandler) {
+			defer wg.Done()
+			for msg := range h.Listen() {
+				// each handler should detect the missing party by itself
+				if msg.RoundNumber == 0 {
+					continue
+				}
+				for otherID, other := range handlers {
+					if otherID != id && msg.IsFor(otherID) {
+						other.Accept(msg)
+					}
+				}
+			}
+		}(id, h)
+	}
+	wg.Wait()
+	return handlers
+}
+
+func checkCulprit(t *testing.T, h *protocol.MultiHandler, culprit party.ID, target error) {
+	_, err := h.Result()
+	require.Error(t, err)
+	var protocolErr protocol.Error
+	require.True(t, errors.As(err, &protocolErr))
+	assert.Equal(t, []party.ID{culprit}, protocolErr.Culprits)
+	assert.ErrorIs(t, err, target)
+}
+
+func TestMultiHandlerRoundTimeout(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	handlers := runWithout(t, partyIDs, "c", protocol.WithRoundTimeout(50*time.Millisecond))
+	for _, h := range handlers {
+		checkCulprit(t, h, "c", protocol.ErrRoundTimeout)
+	}
+}
+
+func TestMultiHandlerContext(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	handlers := runWithout(t, partyIDs, "c", protocol.WithContext(ctx))
+	for _, h := range handlers {
+		checkCulprit(t, h, "c", context.DeadlineExceeded)
+	}
+}
+
+func TestMultiHandlerTimeoutNotTriggered(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b"})
+	ctx, cancel := context.WithCancel(context.Background())
+	handlers := runWithout(t, partyIDs, "", protocol.WithContext(ctx), protocol.WithRoundTimeout(10*time.Millisecond))
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	for _, h := range handlers {
+		_, err := h.Result()
+		assert.NoError(t, err)
+	}
+}
+
+// deliver routes at most limit messages output by handlers, or all of them if limit is negative.
+func deliver(handlers map[party.ID]protocol.Handler, limit int) {
+	for progress := true; progress && limit != 0; {
+		progress = false
+		for id, h := range handlers {
+			select {
+			case msg, ok := <-h.Listen():
+				if !ok {
+					continue
+				}
+				progress = true
+				limit--
+				for otherID, other := range handlers {
+					if otherID != id && msg.IsFor(otherID) {
+						other.Accept(msg)
+					}
+				}
+			default:
+			}
+			if limit == 0 {
+				return
+			}
+		}
+	}
+}
+
+func TestResumeMultiHandler(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	handlers := map[party.ID]protocol.Handler{}
+	for _, id := range partyIDs {
+		h, err := protocol.NewMultiHandler(example.StartXOR(id, partyIDs), []byte("session"))
+		require.NoError(t, err)
+		handlers[id] = h
+	}
+	// only the message of a is delivered
+	msg := <-handlers["a"].Listen()
+	handlers["b"].Accept(msg)
+	handlers["c"].Accept(msg)
+
+	state, err := handlers["c"].(*protocol.MultiHandler).MarshalBinary()
+	require.NoError(t, err)
+
+	_, err = protocol.ResumeMultiHandler(example.StartXOR("a", partyIDs), state)
+	assert.Error(t, err, "resuming as a different party should fail")
+	_, err = protocol.ResumeMultiHandler(example.StartXOR("c", partyIDs[:2]), state)
+	assert.Error(t, err, "resuming a different session should fail")
+
+	// c restarts, and the messages it did not send yet are lost
+	handlers["c"], err = protocol.ResumeMultiHandler(example.StartXOR("c", partyIDs), state)
+	require.NoError(t, err)
+	_, err = handlers["c"].Result()
+	require.Error(t, err, "the protocol should not be finished yet")
+	deliver(handlers, -1)
+
+	var expected interface{}
+	for _, h := range handlers {
+		result, err := h.Result()
+		require.NoError(t, err)
+		if expected == nil {
+			expected = result
+		}
+		assert.Equal(t, expected, result)
+	}
+
+	_, err = handlers["c"].(*protocol.MultiHandler).MarshalBinary()
+	assert.Error(t, err, "a finished execution cannot be saved")
+}
+
+func TestResumeUnsupported(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b"})
+	h, err := protocol.NewMultiHandler(frost.Keygen(curve.Secp256k1{}, "a", partyIDs, 1), nil)
+	require.NoError(t, err)
+	_, err = h.MarshalBinary()
+	assert.ErrorIs(t, err, protocol.ErrSnapshotUnsupported)
+}
+
+func TestResumeTwoPartyHandler(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b"})
+	handlers := map[party.ID]protocol.Handler{}
+	for _, id := range partyIDs {
+		h, err := protocol.NewTwoPartyHandler(example.StartXOR(id, partyIDs), []byte("session"), id == "a")
+		require.NoError(t, err)
+		handlers[id] = h
+	}
+
+	state, err := handlers["a"].(*protocol.TwoPartyHandler).MarshalBinary()
+	require.NoError(t, err)
+
+	// a restarts before its first message was delivered
+	handlers["a"], err = protocol.ResumeTwoPartyHandler(example.StartXOR("a", partyIDs), state)
+	require.NoError(t, err)
+	deliver(handlers, -1)
+
+	var expected interface{}
+	for _, h := range handlers {
+		result, err := h.Result()
+		require.NoError(t, err)
+		if expected == nil {
+			expected = result
+		}
+		assert.Equal(t, expected, result)
+	}
+}
+
+func TestMultiHandlerIdentity(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b"})
+	edPublicKeys := map[party.ID]ed25519.PublicKey{}
+	edSecrets := map[party.ID]ed25519.PrivateKey{}
+	taprootPublicKeys := map[party.ID]taproot.PublicKey{}
+	taprootSecrets := map[party.ID]taproot.SecretKey{}
+	for _, id := range partyIDs {
+		var err error
+		edPublicKeys[id], edSecrets[id], err = ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		taprootSecrets[id], taprootPublicKeys[id], err = taproot.GenKey(rand.Reader)
+		require.NoError(t, err)
+	}
+	identities := map[string]func(party.ID) protocol.Identity{
+		"ed25519": func(id party.ID) protocol.Identity {
+			return protocol.NewEd25519Identity(edSecrets[id], edPublicKeys)
+		},
+		"taproot": func(id party.ID) protocol.Identity {
+			return protocol.NewTaprootIdentity(taprootSecrets[id], taprootPublicKeys)
+		},
+	}
+
+	for name, identity := range identities {
+		newHandlers := func() map[party.ID]*protocol.MultiHandler {
+			handlers := map[party.ID]*protocol.MultiHandler{}
+			for _, id := range partyIDs {
+				h, err := protocol.NewMultiHandler(example.StartXOR(id, partyIDs), nil, protocol.WithIdentity(identity(id)))
+				require.NoError(t, err)
+				handlers[id] = h
+			}
+			return handlers
+		}
+
+		t.Run(name+"/unauthenticated", func(t *testing.T) {
+			handlers := newHandlers()
+			msg := <-handlers["a"].Listen()
+			require.True(t, msg.Verify(identity("b")))
+
+			forged := *msg
+			forged.Signature = nil
+			handlers["b"].Accept(&forged)
+			require.NoError(t, forged.Sign(rand.Reader, identity("b")))
+			forged.From = "a"
+			handlers["b"].Accept(&forged)
+			_, err := handlers["b"].Result()
+			assert.EqualError(t, err, "protocol: not finished", "unauthenticated messages should be ignored")
+
+			handlers["b"].Accept(msg)
+			_, err = handlers["b"].Result()
+			assert.NoError(t, err)
+		})
+
+		t.Run(name+"/evidence", func(t *testing.T) {
+			handlers := newHandlers()
+			msg := *<-handlers["a"].Listen()
+			msg.Data = []byte{0xff}
+			require.NoError(t, msg.Sign(rand.Reader, identity("a")))
+			handlers["b"].Accept(&msg)
+
+			_, err := handlers["b"].Result()
+			var protocolErr protocol.Error
+			require.True(t, errors.As(err, &protocolErr))
+			assert.Equal(t, []party.ID{"a"}, protocolErr.Culprits)
+			require.Len(t, protocolErr.Evidence, 1)
+			assert.True(t, protocolErr.Evidence[0].Verify(identity("b")), "a third party should be able to verify the evidence")
+		})
+
+		t.Run(name+"/deterministic", func(t *testing.T) {
+			sign := func() []byte {
+				start := example.StartXOR("a", partyIDs, protocol.WithRand(mrand.New(mrand.NewSource(1))))
+				h, err := protocol.NewMultiHandler(start, nil, protocol.WithIdentity(identity("a")))
+				require.NoError(t, err)
+				return (<-h.Listen()).Signature
+			}
+			assert.Equal(t, sign(), sign(), "signatures should only depend on the source of randomness")
+		})
+	}
+}
+
+func TestTwoPartyHandlerIdentity(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b"})
+	publicKeys := map[party.ID]ed25519.PublicKey{}
+	secrets := map[party.ID]ed25519.PrivateKey{}
+	for _, id := range partyIDs {
+		var err error
+		publicKeys[id], secrets[id], err = ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+	}
+	identity := func(id party.ID) protocol.Identity {
+		return protocol.NewEd25519Identity(secrets[id], publicKeys)
+	}
+	newHandlers := func() map[party.ID]*protocol.TwoPartyHandler {
+		handlers := map[party.ID]*protocol.TwoPartyHandler{}
+		for _, id := range partyIDs {
+			h, err := protocol.NewTwoPartyHandler(example.StartXOR(id, partyIDs), nil, id == "a", protocol.WithIdentity(identity(id)))
+			require.NoError(t, err)
+			handlers[id] = h
+		}
+		return handlers
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		handlers := newHandlers()
+		msg := <-handlers["a"].Listen()
+		require.True(t, msg.Verify(identity("b")))
+
+		forged := *msg
+		forged.Signature = nil
+		handlers["b"].Accept(&forged)
+		require.NoError(t, forged.Sign(rand.Reader, identity("b")))
+		forged.From = "a"
+		handlers["b"].Accept(&forged)
+		_, err := handlers["b"].Result()
+		assert.EqualError(t, err, "protocol: not finished", "unauthenticated messages should be ignored")
+
+		handlers["b"].Accept(msg)
+		_, err = handlers["b"].Result()
+		assert.NoError(t, err)
+		reply := <-handlers["b"].Listen()
+		assert.True(t, reply.Verify(identity("a")), "outgoing messages should be signed")
+	})
+
+	t.Run("evidence", func(t *testing.T) {
+		handlers := newHandlers()
+		msg := *<-handlers["a"].Listen()
+		msg.Data = []byte{0xff}
+		require.NoError(t, msg.Sign(rand.Reader, identity("a")))
+		handlers["b"].Accept(&msg)
+
+		_, err := handlers["b"].Result()
+		var protocolErr protocol.Error
+		require.True(t, errors.As(err, &protocolErr))
+		assert.Equal(t, []party.ID{"a"}, protocolErr.Culprits)
+		require.Len(t, protocolErr.Evidence, 1)
+		assert.True(t, protocolErr.Evidence[0].Verify(identity("b")), "a third party should be able to verify the evidence")
+	})
+}
+
+func TestMultiHandlerEquivocation(t *testing.T) {
+	group := curve.Secp256k1{}
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	publicKeys := map[party.ID]ed25519.PublicKey{}
+	secrets := map[party.ID]ed25519.PrivateKey{}
+	for _, id := range partyIDs {
+		var err error
+		publicKeys[id], secrets[id], err = ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+	}
+	newHandler := func(id party.ID) *protocol.MultiHandler {
+		identity := protocol.NewEd25519Identity(secrets[id], publicKeys)
+		h, err := protocol.NewMultiHandler(frost.Keygen(group, id, partyIDs, 1), []byte("session"), protocol.WithIdentity(identity))
+		require.NoError(t, err)
+		return h
+	}
+
+	// a sends different broadcast messages to b and c, by running two executions.
+	a1, a2, b, c := newHandler("a"), newHandler("a"), newHandler("b"), newHandler("c")
+	recipients := map[*protocol.MultiHandler][]*protocol.MultiHandler{
+		a1: {b},
+		a2: {c},
+		b:  {a1, a2, c},
+		c:  {a1, a2, b},
+	}
+	for progress := true; progress; {
+		progress = false
+		for h, others := range recipients {
+			select {
+			case msg, ok := <-h.Listen():
+				if !ok || msg.RoundNumber == 0 {
+					continue
+				}
+				progress = true
+				for _, other := range others {
+					other.Accept(msg)
+				}
+			default:
+			}
+		}
+	}
+
+	verifier := protocol.NewEd25519Identity(nil, publicKeys)
+	for _, h := range []*protocol.MultiHandler{b, c} {
+		_, err := h.Result()
+		var protocolErr protocol.Error
+		require.True(t, errors.As(err, &protocolErr))
+		assert.ErrorIs(t, err, protocol.ErrBroadcastVerification)
+		assert.Equal(t, []party.ID{"a"}, protocolErr.Culprits)
+		require.Len(t, protocolErr.Evidence, 2)
+		assert.NotEqual(t, protocolErr.Evidence[0].Hash(), protocolErr.Evidence[1].Hash())
+		for _, msg := range protocolErr.Evidence {
+			assert.Equal(t, party.ID("a"), msg.From)
+			assert.True(t, msg.Verify(verifier))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
+)
+
+// Identity binds each party.ID to a long-term public key.
+//
+// It is used by a handler to sign the messages it sends, and to authenticate the messages it receives.
+type Identity interface {
+	// Sign returns a signature of the hash of a message sent by this party,
+	// using rand as a source of randomness if needed.
+	Sign(rand io.Reader, hash []byte) ([]byte, error)
+	// Verify returns true if signature is a valid signature of hash by the party from.
+	Verify(from party.ID, hash, signature []byte) bool
+}
+
+type ed25519Identity struct {
+	secret     ed25519.PrivateKey
+	publicKeys map[party.ID]ed25519.PublicKey
+}
+
+// NewEd25519Identity returns an Identity which signs messages with secret,
+// and verifies them using the Ed25519 public keys of all parties.
+func NewEd25519Identity(secret ed25519.PrivateKey, publicKeys map[party.ID]ed25519.PublicKey) Identity {
+	return &ed25519Identity{
+		secret:     secret,
+		publicKeys: publicKeys,
+	}
+}
+
+func (i *ed25519Identity) Sign(_ io.Reader, hash []byte) ([]byte, error) {
+	if len(i.secret) != ed25519.PrivateKeySize {
+		return nil, errors.New("protocol: invalid ed25519 secret key")
+	}
+	return ed25519.Sign(i.secret, hash), nil
+}
+
+func (i *ed25519Identity) Verify(from party.ID, hash, signature []byte) bool {
+	public, ok := i.publicKeys[from]
+	if !ok || len(public) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(public, hash, signature)
+}
+
+type taprootIdentity struct {
+	secret     taproot.SecretKey
+	publicKeys map[party.ID]taproot.PublicKey
+}
+
+// NewTaprootIdentity returns an Identity which signs messages with secret,
+// and verifies them using the BIP-340 secp256k1 public keys of all parties.
+func NewTaprootIdentity(secret taproot.SecretKey, publicKeys map[party.ID]taproot.PublicKey) Identity {
+	return &taprootIdentity{
+		secret:     secret,
+		publicKeys: publicKeys,
+	}
+}
+
+func (i *taprootIdentity) Sign(rand io.Reader, hash []byte) ([]byte, error) {
+	return i.secret.Sign(rand, hash)
+}
+
+func (i *taprootIdentity) Verify(from party.ID, hash, signature []byte) bool {
+	public, ok := i.publicKeys[from]
+	if !ok {
+		return false
+	}
+	return public.Verify(signature, hash)
+}
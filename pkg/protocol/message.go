@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
@@ -27,6 +28,8 @@ type Message struct {
 	// BroadcastVerification is the hash of all messages broadcast by the parties,
 	// and is included in all messages in the round following a broadcast round.
 	BroadcastVerification []byte
+	// Signature is the signature of Hash() by the sender, and is only set when the handler was given an Identity.
+	Signature []byte
 }
 
 // String implements fmt.Stringer.
@@ -43,7 +46,7 @@ func (m Message) IsFor(id party.ID) bool {
 }
 
 // Hash returns a 64 byte hash of the message content, including the headers.
-// Can be used to produce a signature for the message.
+// It is used to produce the signature of the message, and therefore does not include the Signature field.
 func (m *Message) Hash() []byte {
 	var broadcast byte
 	if m.Broadcast {
@@ -62,6 +65,28 @@ func (m *Message) Hash() []byte {
 	return h.Sum()
 }
 
+// Sign sets the Signature of the message using the long-term key of the sender,
+// and rand as a source of randomness.
+func (m *Message) Sign(rand io.Reader, identity Identity) error {
+	signature, err := identity.Sign(rand, m.Hash())
+	if err != nil {
+		return fmt.Errorf("protocol: failed to sign message: %w", err)
+	}
+	m.Signature = signature
+	return nil
+}
+
+// Verify returns true if the message carries a valid signature by its sender.
+//
+// Since the signature covers the headers of the message, a message signed by a party
+// can be used as evidence that this party sent it in a given session.
+func (m *Message) Verify(identity Identity) bool {
+	if len(m.Signature) == 0 {
+		return false
+	}
+	return identity.Verify(m.From, m.Hash(), m.Signature)
+}
+
 // marshallableMessage is a copy of message for the purpose of cbor marshalling.
 //
 // This is a workaround to use cbor's default marshalling for Message, all while providing
@@ -75,6 +100,7 @@ type marshallableMessage struct {
 	Data                  []byte
 	Broadcast             bool
 	BroadcastVerification []byte
+	Signature             []byte
 }
 
 func (m *Message) toMarshallable() *marshallableMessage {
@@ -87,6 +113,7 @@ func (m *Message) toMarshallable() *marshallableMessage {
 		Data:                  m.Data,
 		Broadcast:             m.Broadcast,
 		BroadcastVerification: m.BroadcastVerification,
+		Signature:             m.Signature,
 	}
 }
 
@@ -107,5 +134,6 @@ func (m *Message) UnmarshalBinary(data []byte) error {
 	m.Data = deserialized.Data
 	m.Broadcast = deserialized.Broadcast
 	m.BroadcastVerification = deserialized.BroadcastVerification
+	m.Signature = deserialized.Signature
 	return nil
 }
@@ -0,0 +1,24 @@
+package protocol
+
+import (
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+)
+
+// Option configures a protocol execution, and can be passed to any function returning a StartFunc.
+type Option = round.Option
+
+// WithRand sets the source of randomness used by a party during the protocol execution.
+// By default, crypto/rand.Reader is used.
+//
+// This is mostly useful for generating reproducible test vectors.
+// The execution is only deterministic when no pool is given, since the order in which
+// concurrent operations read from rand is otherwise unspecified.
+// A deterministic reader must never be reused across executions, or for different parties,
+// since this leaks secret material.
+func WithRand(rand io.Reader) Option {
+	return func(info *round.Info) {
+		info.Rand = rand
+	}
+}
@@ -0,0 +1,101 @@
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+)
+
+// ErrSnapshotUnsupported is returned by MarshalBinary when the rounds of the protocol cannot be saved.
+var ErrSnapshotUnsupported = round.ErrSnapshotUnsupported
+
+// state contains everything required to resume a handler after a restart.
+type state struct {
+	// SessionID is the sessionID given to the StartFunc.
+	SessionID []byte
+	// SSID of the session, used to check that the same protocol is resumed.
+	SSID []byte
+	// Round is the snapshot of the current round, taken before any message for it was processed.
+	// It is nil if the handler is still in the first round.
+	Round []byte
+	// Queued contains the messages received for the current and later rounds,
+	// as well as our own broadcast message for the current round.
+	Queued []*Message
+	// BroadcastHashes contains the echo broadcast hashes of the previous rounds.
+	BroadcastHashes map[round.Number][]byte
+	// Views contains the views of a broadcast round received from other parties.
+	Views []*Message
+	// Sent contains the messages output for the current round, which are output again since they may not have been delivered.
+	Sent []*Message
+	// Leader is only used by the TwoPartyHandler.
+	Leader bool
+}
+
+// snapshotter saves the current round of a handler, and the messages it sent for it.
+type snapshotter struct {
+	// snapshot is the state of the current round before any message for it was processed.
+	snapshot []byte
+	// err is set if the current round could not be saved.
+	err error
+	// sent contains the messages output for the current round.
+	sent []*Message
+}
+
+// next is called when the handler moves to a new round r, after having output the messages in sent.
+func (s *snapshotter) next(r round.Session, sent []*Message) {
+	s.sent = sent
+	switch r.(type) {
+	case *round.Abort, *round.Output:
+		s.snapshot, s.err = nil, errors.New("protocol: execution has finished")
+	default:
+		s.snapshot, s.err = round.MarshalSnapshot(r)
+	}
+}
+
+// marshal encodes the state, after checking that the current round was saved.
+func (s *snapshotter) marshal(st *state) ([]byte, error) {
+	if s.err != nil {
+		return nil, fmt.Errorf("protocol: failed to marshal session: %w", s.err)
+	}
+	st.Round = s.snapshot
+	st.Sent = s.sent
+	data, err := cbor.Marshal(st)
+	if err != nil {
+		return nil, fmt.Errorf("protocol: failed to marshal session: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalState decodes the state returned by MarshalBinary.
+func unmarshalState(data []byte) (*state, error) {
+	var st state
+	if err := cbor.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("protocol: failed to unmarshal session: %w", err)
+	}
+	for _, msgs := range [][]*Message{st.Queued, st.Views, st.Sent} {
+		for _, msg := range msgs {
+			if msg == nil {
+				return nil, errors.New("protocol: failed to unmarshal session: nil message")
+			}
+		}
+	}
+	return &st, nil
+}
+
+// restore returns the current round of the saved session, given the first round of the new one.
+func (st *state) restore(first round.Session) (round.Session, error) {
+	if !bytes.Equal(st.SSID, first.SSID()) {
+		return nil, errors.New("protocol: resumed session has a different SSID")
+	}
+	if st.Round == nil {
+		return first, nil
+	}
+	r, err := round.UnmarshalSnapshot(first, st.Round)
+	if err != nil {
+		return nil, fmt.Errorf("protocol: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,184 @@
+package protocol
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// RoutedHandler is a Handler which can be identified by the messages it accepts.
+//
+// It is implemented by MultiHandler and TwoPartyHandler.
+type RoutedHandler interface {
+	Handler
+	// ProtocolID returns the Protocol of all messages of this execution.
+	ProtocolID() string
+	// SSID returns the SSID of all messages of this execution.
+	SSID() []byte
+}
+
+// ErrAlreadyRegistered is returned by Router.Run when a handler with the same protocol and SSID is already running.
+var ErrAlreadyRegistered = errors.New("protocol: a handler for this session is already running")
+
+// RouterOption configures a Router.
+type RouterOption func(*Router)
+
+// WithBufferLimits bounds the messages stored for sessions whose handler is not running yet.
+// At most messages are stored for each of at most sessions sessions, and other messages are dropped.
+//
+// The default is 1024 sessions of 256 messages.
+func WithBufferLimits(sessions, messages int) RouterOption {
+	return func(r *Router) {
+		r.maxSessions = sessions
+		r.maxMessages = messages
+	}
+}
+
+// WithBufferTimeout sets the time during which messages are stored for a session whose handler is not running yet,
+// as well as the time during which messages for a finished session are dropped instead of stored.
+//
+// The default is one minute.
+func WithBufferTimeout(timeout time.Duration) RouterOption {
+	return func(r *Router) {
+		r.timeout = timeout
+	}
+}
+
+type sessionKey struct {
+	protocol string
+	ssid     string
+}
+
+type pendingSession struct {
+	created  time.Time
+	messages []*Message
+}
+
+// Router dispatches the messages received from other parties to many concurrent handlers,
+// according to their Protocol and SSID.
+//
+// Messages for a session whose handler is not running yet are stored until it is started with Run.
+type Router struct {
+	send        func(*Message)
+	maxSessions int
+	maxMessages int
+	timeout     time.Duration
+
+	handlers map[sessionKey]RoutedHandler
+	pending  map[sessionKey]*pendingSession
+	finished map[sessionKey]time.Time
+	mtx      sync.Mutex
+}
+
+// NewRouter returns a Router which calls send with the outgoing messages of all handlers.
+// send must deliver the message to all parties for which Message.IsFor returns true.
+func NewRouter(send func(*Message), opts ...RouterOption) *Router {
+	r := &Router{
+		send:        send,
+		maxSessions: 1024,
+		maxMessages: 256,
+		timeout:     time.Minute,
+		handlers:    map[sessionKey]RoutedHandler{},
+		pending:     map[sessionKey]*pendingSession{},
+		finished:    map[sessionKey]time.Time{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run delivers the messages of h until the protocol has finished, and returns its result.
+//
+// Messages for h which were received before Run was called are delivered first.
+// Once the protocol has finished, the handler is removed from the Router.
+func (r *Router) Run(h RoutedHandler) (interface{}, error) {
+	key := sessionKey{protocol: h.ProtocolID(), ssid: string(h.SSID())}
+	r.mtx.Lock()
+	if _, ok := r.handlers[key]; ok {
+		r.mtx.Unlock()
+		return nil, ErrAlreadyRegistered
+	}
+	r.handlers[key] = h
+	delete(r.finished, key)
+	var pending []*Message
+	if p := r.pending[key]; p != nil {
+		pending = p.messages
+		delete(r.pending, key)
+	}
+	r.mtx.Unlock()
+
+	for _, msg := range pending {
+		h.Accept(msg)
+	}
+	for msg := range h.Listen() {
+		r.send(msg)
+	}
+
+	r.mtx.Lock()
+	delete(r.handlers, key)
+	r.finished[key] = time.Now()
+	r.prune()
+	r.mtx.Unlock()
+	return h.Result()
+}
+
+// Accept gives msg to the handler of its session, or stores it until the handler is started.
+//
+// The Router does not authenticate messages, so msg must come from an authenticated channel with its sender.
+func (r *Router) Accept(msg *Message) {
+	if msg == nil {
+		return
+	}
+	key := sessionKey{protocol: msg.Protocol, ssid: string(msg.SSID)}
+	r.mtx.Lock()
+	if h, ok := r.handlers[key]; ok {
+		r.mtx.Unlock()
+		h.Accept(msg)
+		return
+	}
+	defer r.mtx.Unlock()
+	if _, ok := r.finished[key]; ok {
+		return
+	}
+	p := r.pending[key]
+	if p == nil {
+		r.prune()
+		if len(r.pending) >= r.maxSessions {
+			return
+		}
+		p = &pendingSession{created: time.Now()}
+		r.pending[key] = p
+	}
+	if len(p.messages) < r.maxMessages {
+		p.messages = append(p.messages, msg)
+	}
+}
+
+// Serve accepts the messages received from a single party over in, until it is closed.
+//
+// Messages which were not sent by from are dropped.
+func (r *Router) Serve(from party.ID, in <-chan *Message) {
+	for msg := range in {
+		if msg != nil && msg.From == from {
+			r.Accept(msg)
+		}
+	}
+}
+
+// prune removes the expired pending and finished sessions.
+func (r *Router) prune() {
+	now := time.Now()
+	for key, p := range r.pending {
+		if now.Sub(p.created) > r.timeout {
+			delete(r.pending, key)
+		}
+	}
+	for key, t := range r.finished {
+		if now.Sub(t) > r.timeout {
+			delete(r.finished, key)
+		}
+	}
+}
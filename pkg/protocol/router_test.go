@@ -0,0 +1,68 @@
+package protocol_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/example"
+)
+
+func TestRouter(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	routers := map[party.ID]*protocol.Router{}
+	for _, id := range partyIDs {
+		routers[id] = protocol.NewRouter(func(msg *protocol.Message) {
+			for otherID, other := range routers {
+				if msg.IsFor(otherID) {
+					go other.Accept(msg)
+				}
+			}
+		})
+	}
+
+	const sessions = 20
+	var wg sync.WaitGroup
+	run := func(id party.ID) {
+		for i := 0; i < sessions; i++ {
+			h, err := protocol.NewMultiHandler(example.StartXOR(id, partyIDs), []byte(fmt.Sprintf("session %d", i)))
+			require.NoError(t, err)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := routers[id].Run(h)
+				assert.NoError(t, err)
+			}()
+		}
+	}
+	// the messages of a and b for all sessions are stored until c starts its handlers
+	run("a")
+	run("b")
+	run("c")
+	wg.Wait()
+}
+
+func TestRouterAlreadyRegistered(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b"})
+	router := protocol.NewRouter(func(*protocol.Message) {})
+	h1, err := protocol.NewMultiHandler(example.StartXOR("a", partyIDs), nil)
+	require.NoError(t, err)
+	h2, err := protocol.NewMultiHandler(example.StartXOR("a", partyIDs), nil)
+	require.NoError(t, err)
+
+	errs := make(chan error, 2)
+	for _, h := range []*protocol.MultiHandler{h1, h2} {
+		go func(h *protocol.MultiHandler) {
+			_, err := router.Run(h)
+			errs <- err
+		}(h)
+	}
+	assert.ErrorIs(t, <-errs, protocol.ErrAlreadyRegistered)
+	h1.Stop()
+	h2.Stop()
+	assert.NotErrorIs(t, <-errs, protocol.ErrAlreadyRegistered)
+}
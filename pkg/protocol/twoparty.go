@@ -8,6 +8,7 @@ import (
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
 )
 
 // TwoPartyHandler represents a restriction of the Handler for 2 party protocols.
@@ -19,21 +20,23 @@ type TwoPartyHandler struct {
 	messages map[round.Number]*Message
 	out      chan *Message
 	mtx      sync.Mutex
+
+	handlerOptions
+
+	// sessionID is the sessionID given to the StartFunc, and ssid the SSID of the session.
+	sessionID, ssid []byte
+	// snapshotter saves the current round so that the execution can be resumed.
+	snapshotter snapshotter
 }
 
-func NewTwoPartyHandler(create StartFunc, sessionID []byte, leader bool) (*TwoPartyHandler, error) {
-	r, err := create(sessionID)
+// NewTwoPartyHandler expects a StartFunc for the desired protocol. It returns a handler that the user can interact with.
+//
+// The leader starts the execution, while the other party waits for its first message.
+// Only the WithIdentity option is used, and behaves as for the MultiHandler.
+func NewTwoPartyHandler(create StartFunc, sessionID []byte, leader bool, opts ...HandlerOption) (*TwoPartyHandler, error) {
+	handler, err := newTwoPartyHandler(create, sessionID, leader, opts)
 	if err != nil {
-		return nil, fmt.Errorf("protocol: failed to create round: %w", err)
-	}
-	handler := &TwoPartyHandler{
-		round:    r,
-		leader:   leader,
-		err:      nil,
-		result:   nil,
-		messages: map[round.Number]*Message{},
-		out:      make(chan *Message, 2),
-		mtx:      sync.Mutex{},
+		return nil, err
 	}
 	if leader {
 		handler.advance()
@@ -41,6 +44,86 @@ func NewTwoPartyHandler(create StartFunc, sessionID []byte, leader bool) (*TwoPa
 	return handler, nil
 }
 
+// ResumeTwoPartyHandler recreates a handler from the state returned by TwoPartyHandler.MarshalBinary.
+//
+// See ResumeMultiHandler for the requirements on create.
+func ResumeTwoPartyHandler(create StartFunc, data []byte, opts ...HandlerOption) (*TwoPartyHandler, error) {
+	st, err := unmarshalState(data)
+	if err != nil {
+		return nil, err
+	}
+	h, err := newTwoPartyHandler(create, st.SessionID, st.Leader, opts)
+	if err != nil {
+		return nil, err
+	}
+	r, err := st.restore(h.round)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.round = r
+	h.snapshotter = snapshotter{snapshot: st.Round, sent: st.Sent}
+	for _, msg := range st.Queued {
+		h.messages[msg.RoundNumber] = msg
+	}
+	for _, msg := range st.Sent {
+		h.out <- msg
+	}
+	// the follower only starts once it has received a message
+	if st.Round != nil || h.leader || len(st.Queued) > 0 {
+		h.advance()
+	}
+	return h, nil
+}
+
+// MarshalBinary returns the state of the handler, which can be given to ResumeTwoPartyHandler.
+//
+// The rounds of the protocol must support snapshots, otherwise ErrSnapshotUnsupported is returned.
+//
+// The state contains secret data and must be encrypted by the caller.
+func (h *TwoPartyHandler) MarshalBinary() ([]byte, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.err != nil || h.result != nil {
+		return nil, errors.New("protocol: execution has finished")
+	}
+	st := &state{
+		SessionID: h.sessionID,
+		SSID:      h.ssid,
+		Leader:    h.leader,
+	}
+	for number, msg := range h.messages {
+		if number >= h.round.Number() {
+			st.Queued = append(st.Queued, msg)
+		}
+	}
+	return h.snapshotter.marshal(st)
+}
+
+func newTwoPartyHandler(create StartFunc, sessionID []byte, leader bool, opts []HandlerOption) (*TwoPartyHandler, error) {
+	r, err := create(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("protocol: failed to create round: %w", err)
+	}
+	h := &TwoPartyHandler{
+		round:     r,
+		leader:    leader,
+		err:       nil,
+		result:    nil,
+		messages:  map[round.Number]*Message{},
+		out:       make(chan *Message, 2),
+		mtx:       sync.Mutex{},
+		sessionID: sessionID,
+		ssid:      r.SSID(),
+	}
+	for _, opt := range opts {
+		opt(&h.handlerOptions)
+	}
+	return h, nil
+}
+
 func (h *TwoPartyHandler) Result() (interface{}, error) {
 	h.mtx.Lock()
 	defer h.mtx.Unlock()
@@ -53,6 +136,18 @@ func (h *TwoPartyHandler) Result() (interface{}, error) {
 	return nil, errors.New("protocol: not finished")
 }
 
+// SSID returns the session identifier included in all messages of this execution.
+func (h *TwoPartyHandler) SSID() []byte {
+	return h.ssid
+}
+
+// ProtocolID returns the identifier of the protocol being executed.
+func (h *TwoPartyHandler) ProtocolID() string {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.round.ProtocolID()
+}
+
 func (h *TwoPartyHandler) Listen() <-chan *Message {
 	h.mtx.Lock()
 	defer h.mtx.Unlock()
@@ -60,7 +155,9 @@ func (h *TwoPartyHandler) Listen() <-chan *Message {
 }
 
 func (h *TwoPartyHandler) Stop() {
-	if h.err != nil || h.result != nil {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.err == nil && h.result == nil {
 		h.abort(errors.New("aborted by user"))
 	}
 }
@@ -72,19 +169,33 @@ func (h *TwoPartyHandler) String() string {
 func (h *TwoPartyHandler) abort(err error) {
 	if err != nil {
 		h.err = err
-		select {
-		case h.out <- &Message{
+		msg := &Message{
 			SSID:     h.round.SSID(),
 			From:     h.round.SelfID(),
 			Protocol: h.round.ProtocolID(),
 			Data:     []byte(h.err.Error()),
-		}:
+		}
+		if h.identity != nil {
+			// the other party will ignore the message if it could not be signed
+			_ = msg.Sign(h.round.Rand(), h.identity)
+		}
+		select {
+		case h.out <- msg:
 		default:
 		}
 	}
 	close(h.out)
 }
 
+// blame aborts the execution with the sender of msg as culprit, and msg as evidence.
+func (h *TwoPartyHandler) blame(err error, msg *Message) {
+	h.abort(Error{
+		Culprits: []party.ID{msg.From},
+		Err:      err,
+		Evidence: []*Message{msg},
+	})
+}
+
 func (h *TwoPartyHandler) canAdvance() bool {
 	if h.round.MessageContent() == nil {
 		return true
@@ -134,7 +245,7 @@ func (h *TwoPartyHandler) advance() {
 	for h.canAdvance() {
 		msg := h.messages[h.round.Number()]
 		if err := h.verifyMessage(msg); err != nil {
-			h.abort(err)
+			h.blame(err, msg)
 			return
 		}
 		out := make(chan *round.Message, 1)
@@ -144,6 +255,7 @@ func (h *TwoPartyHandler) advance() {
 			return
 		}
 		close(out)
+		var sent []*Message
 		for roundMsg := range out {
 			data, err := cbor.Marshal(roundMsg.Content)
 			if err != nil {
@@ -159,13 +271,32 @@ func (h *TwoPartyHandler) advance() {
 				Broadcast:             roundMsg.Broadcast,
 				BroadcastVerification: nil,
 			}
+			if h.identity != nil {
+				if err = msg.Sign(newRound.Rand(), h.identity); err != nil {
+					h.abort(err)
+					return
+				}
+			}
+			sent = append(sent, msg)
 			h.out <- msg
 		}
 		h.round = newRound
+		h.snapshotter.next(newRound, sent)
 		switch R := newRound.(type) {
 		// An abort happened
 		case *round.Abort:
-			h.abort(R.Err)
+			var evidence []*Message
+			culprits := party.NewIDSlice(R.Culprits)
+			for number := round.Number(1); number <= h.round.FinalRoundNumber(); number++ {
+				if msg := h.messages[number]; msg != nil && culprits.Contains(msg.From) {
+					evidence = append(evidence, msg)
+				}
+			}
+			h.abort(Error{
+				Culprits: R.Culprits,
+				Err:      R.Err,
+				Evidence: evidence,
+			})
 			return
 		// We have the result
 		case *round.Output:
@@ -206,13 +337,15 @@ func (h *TwoPartyHandler) CanAccept(msg *Message) bool {
 func (h *TwoPartyHandler) Accept(msg *Message) {
 	h.mtx.Lock()
 	defer h.mtx.Unlock()
+	h.accept(msg)
+}
 
-	if !h.CanAccept(msg) || h.err != nil || h.result != nil {
+func (h *TwoPartyHandler) accept(msg *Message) {
+	if !h.CanAccept(msg) || h.err != nil || h.result != nil || !h.authentic(msg) {
 		return
 	}
-
 	if msg.RoundNumber == 0 {
-		h.abort(fmt.Errorf("aborted by other party with error: \"%s\"", msg.Data))
+		h.blame(fmt.Errorf("aborted by other party with error: \"%s\"", msg.Data), msg)
 		return
 	}
 
@@ -0,0 +1,73 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+// Results returns the outcome of each handler, and should be called after Run.
+func (n *Network) Results() (map[party.ID]interface{}, map[party.ID]error) {
+	results := make(map[party.ID]interface{}, len(n.handlers))
+	errs := make(map[party.ID]error, len(n.handlers))
+	for id, h := range n.handlers {
+		result, err := h.Result()
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		results[id] = result
+	}
+	return results, errs
+}
+
+// AssertSuccess reports a failure to t if a handler did not complete successfully,
+// and returns the results of all handlers.
+func AssertSuccess(t testing.TB, n *Network) map[party.ID]interface{} {
+	t.Helper()
+	results, errs := n.Results()
+	for id, err := range errs {
+		t.Errorf("party %s: unexpected error: %v", id, err)
+	}
+	return results
+}
+
+// AssertCulprits reports a failure to t unless every party other than the culprits aborted
+// with a protocol.Error whose Culprits are exactly the given ones.
+func AssertCulprits(t testing.TB, n *Network, culprits ...party.ID) {
+	t.Helper()
+	expected := party.NewIDSlice(culprits)
+	_, errs := n.Results()
+	for id := range n.handlers {
+		if expected.Contains(id) {
+			continue
+		}
+		err := errs[id]
+		if err == nil {
+			t.Errorf("party %s: expected an abort, but the protocol succeeded", id)
+			continue
+		}
+		var protocolErr protocol.Error
+		if !errors.As(err, &protocolErr) {
+			t.Errorf("party %s: expected a protocol.Error, got: %v", id, err)
+			continue
+		}
+		if !equalIDs(expected, party.NewIDSlice(protocolErr.Culprits)) {
+			t.Errorf("party %s: expected culprits %v, got %v (%v)", id, expected, protocolErr.Culprits, protocolErr.Err)
+		}
+	}
+}
+
+func equalIDs(a, b party.IDSlice) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package simulation
+
+import (
+	"time"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+// Envelope is a message in transit to a single party.
+type Envelope struct {
+	// To is the recipient of the message.
+	To party.ID
+	// Message is delivered to To by calling Accept.
+	Message *protocol.Message
+	// Delay is the time after which the message is delivered.
+	Delay time.Duration
+}
+
+// Fault decides how a message is delivered to a single party.
+//
+// It returns the envelopes which are delivered instead of e, which may be empty in order to drop the message.
+// The Message of e is shared between all recipients, and must be copied before being modified.
+type Fault func(e Envelope) []Envelope
+
+// Drop drops the message.
+func Drop() Fault {
+	return func(Envelope) []Envelope {
+		return nil
+	}
+}
+
+// Duplicate delivers the message twice.
+func Duplicate() Fault {
+	return func(e Envelope) []Envelope {
+		return []Envelope{e, e}
+	}
+}
+
+// Delay delivers the message after an additional delay.
+func Delay(delay time.Duration) Fault {
+	return func(e Envelope) []Envelope {
+		e.Delay += delay
+		return []Envelope{e}
+	}
+}
+
+// Tamper replaces the content of the message by the output of f, which is given a copy of Message.Data.
+//
+// When the handlers authenticate messages with a protocol.Identity, the tampered message is not correctly signed,
+// and is therefore ignored by the recipient.
+func Tamper(f func(data []byte) []byte) Fault {
+	return func(e Envelope) []Envelope {
+		msg := *e.Message
+		msg.Data = f(append([]byte(nil), e.Message.Data...))
+		e.Message = &msg
+		return []Envelope{e}
+	}
+}
+
+// Only applies fault to the messages sent to the given parties, and delivers the others normally.
+//
+// For instance, Only(Tamper(f), id) causes a party to equivocate when it broadcasts a message.
+func Only(fault Fault, to ...party.ID) Fault {
+	recipients := party.NewIDSlice(to)
+	return func(e Envelope) []Envelope {
+		if !recipients.Contains(e.To) {
+			return []Envelope{e}
+		}
+		return fault(e)
+	}
+}
+
+// Round applies fault to the messages of the given round, and delivers the others normally.
+// The messages sent by a party when it aborts have round number 0.
+func Round(number round.Number, fault Fault) Fault {
+	return func(e Envelope) []Envelope {
+		if e.Message.RoundNumber != number {
+			return []Envelope{e}
+		}
+		return fault(e)
+	}
+}
@@ -0,0 +1,205 @@
+// Package simulation runs protocol executions over a simulated network,
+// where faults such as message loss, reordering, duplication, delays, or tampering can be injected.
+package simulation
+
+import (
+	mrand "math/rand"
+	"sync"
+	"time"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+// Option configures a Network.
+type Option func(*Network)
+
+// WithReordering delivers the messages in a random order, given by seed, instead of the order in which they were sent.
+func WithReordering(seed int64) Option {
+	return func(n *Network) {
+		n.rand = mrand.New(mrand.NewSource(seed))
+	}
+}
+
+// WithoutAborts does not deliver the messages sent by a party when it aborts,
+// so that each party must detect faults by itself, and does not blame the first party which aborted.
+func WithoutAborts() Option {
+	return func(n *Network) {
+		n.rules = append(n.rules, rule{fault: Round(0, Drop())})
+	}
+}
+
+// WithIdleTimeout sets the time after which the execution is stopped when no message is in transit.
+// This happens when an honest party waits for a message which was dropped.
+//
+// The default is one second.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(n *Network) {
+		n.idleTimeout = timeout
+	}
+}
+
+type rule struct {
+	// from is the sender of the messages the rule applies to, or all parties if empty.
+	from  party.ID
+	fault Fault
+}
+
+type envelope struct {
+	Envelope
+	deliverAt time.Time
+}
+
+// Network delivers the messages between handlers, after applying the faults injected for the sender.
+//
+// Each handler runs as its own party, and messages are delivered one at a time.
+// A byzantine party is simulated by injecting faults in its outgoing messages.
+type Network struct {
+	handlers    map[party.ID]protocol.Handler
+	rules       []rule
+	rand        *mrand.Rand
+	idleTimeout time.Duration
+
+	inTransit    []*envelope
+	lastActivity time.Time
+	wake         chan struct{}
+	mtx          sync.Mutex
+}
+
+// New returns a Network between the given handlers, which must all execute the same protocol.
+func New(handlers map[party.ID]protocol.Handler, opts ...Option) *Network {
+	n := &Network{
+		handlers:    handlers,
+		idleTimeout: time.Second,
+		wake:        make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// Inject applies fault to all messages sent by from. Round can be used to only apply it to a single round.
+//
+// Faults are applied in the order in which they were injected, and must be injected before calling Run.
+func (n *Network) Inject(from party.ID, fault Fault) {
+	n.rules = append(n.rules, rule{from: from, fault: fault})
+}
+
+// Run delivers messages until all handlers have finished, or until no message was in transit during the idle timeout.
+// In the latter case, the handlers which have not finished are stopped.
+func (n *Network) Run() {
+	n.mtx.Lock()
+	n.lastActivity = time.Now()
+	n.mtx.Unlock()
+
+	var wg sync.WaitGroup
+	for id, h := range n.handlers {
+		wg.Add(1)
+		go func(id party.ID, h protocol.Handler) {
+			defer wg.Done()
+			for msg := range h.Listen() {
+				n.send(id, msg)
+			}
+		}(id, h)
+	}
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		e, wait := n.next()
+		if e != nil {
+			if h, ok := n.handlers[e.To]; ok {
+				h.Accept(e.Message)
+			}
+			continue
+		}
+		if wait <= 0 {
+			break
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-finished:
+			return
+		case <-n.wake:
+		case <-timer.C:
+		}
+	}
+
+	for _, h := range n.handlers {
+		h.Stop()
+	}
+	<-finished
+}
+
+// send applies the faults to msg, and puts the resulting envelopes in transit.
+func (n *Network) send(from party.ID, msg *protocol.Message) {
+	var envelopes []Envelope
+	for id := range n.handlers {
+		if msg.IsFor(id) {
+			envelopes = append(envelopes, Envelope{To: id, Message: msg})
+		}
+	}
+	for _, r := range n.rules {
+		if r.from != "" && r.from != from {
+			continue
+		}
+		var faulty []Envelope
+		for _, e := range envelopes {
+			faulty = append(faulty, r.fault(e)...)
+		}
+		envelopes = faulty
+	}
+
+	n.mtx.Lock()
+	now := time.Now()
+	for _, e := range envelopes {
+		n.inTransit = append(n.inTransit, &envelope{Envelope: e, deliverAt: now.Add(e.Delay)})
+	}
+	n.lastActivity = now
+	n.mtx.Unlock()
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next removes an envelope which can be delivered from the messages in transit.
+// If none can be delivered yet, it returns the time to wait before the next one,
+// or a non-positive duration if the idle timeout has expired.
+func (n *Network) next() (*envelope, time.Duration) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	now := time.Now()
+	var ready []int
+	wait := n.idleTimeout - now.Sub(n.lastActivity)
+	for i, e := range n.inTransit {
+		if !e.deliverAt.After(now) {
+			ready = append(ready, i)
+		} else if d := e.deliverAt.Sub(now); d < wait || wait <= 0 {
+			wait = d
+		}
+	}
+	if len(ready) == 0 {
+		return nil, wait
+	}
+	i := ready[0]
+	if n.rand != nil {
+		i = ready[n.rand.Intn(len(ready))]
+	}
+	e := n.inTransit[i]
+	n.inTransit = append(n.inTransit[:i], n.inTransit[i+1:]...)
+	n.lastActivity = now
+	return e, 0
+}
@@ -0,0 +1,163 @@
+package simulation
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp"
+	"github.com/taurusgroup/multi-party-sig/protocols/doerner"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost"
+)
+
+var partyIDs = party.NewIDSlice([]party.ID{"a", "b", "c"})
+
+func frostKeygen(t *testing.T, opts ...protocol.HandlerOption) map[party.ID]protocol.Handler {
+	handlers := map[party.ID]protocol.Handler{}
+	for _, id := range partyIDs {
+		h, err := protocol.NewMultiHandler(frost.Keygen(curve.Secp256k1{}, id, partyIDs, 1), nil, opts...)
+		require.NoError(t, err)
+		handlers[id] = h
+	}
+	return handlers
+}
+
+// identities returns an Ed25519 protocol.Identity for each party.
+func identities(t *testing.T, ids party.IDSlice) map[party.ID]protocol.Identity {
+	publicKeys := map[party.ID]ed25519.PublicKey{}
+	secrets := map[party.ID]ed25519.PrivateKey{}
+	for _, id := range ids {
+		var err error
+		publicKeys[id], secrets[id], err = ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+	}
+	identities := map[party.ID]protocol.Identity{}
+	for _, id := range ids {
+		identities[id] = protocol.NewEd25519Identity(secrets[id], publicKeys)
+	}
+	return identities
+}
+
+func flip(data []byte) []byte {
+	data[len(data)-1] ^= 1
+	return data
+}
+
+func TestUnreliableNetwork(t *testing.T) {
+	n := New(frostKeygen(t), WithReordering(1))
+	n.Inject("a", Duplicate())
+	n.Inject("b", Delay(10*time.Millisecond))
+	n.Run()
+	results := AssertSuccess(t, n)
+	require.Len(t, results, len(partyIDs))
+	publicKey := results["a"].(*frost.Config).PublicKey
+	for _, result := range results {
+		assert.True(t, publicKey.Equal(result.(*frost.Config).PublicKey))
+	}
+}
+
+func TestTamper(t *testing.T) {
+	n := New(frostKeygen(t), WithReordering(2), WithoutAborts())
+	n.Inject("c", Round(2, Tamper(flip)))
+	n.Run()
+	AssertCulprits(t, n, "c")
+}
+
+func TestEquivocate(t *testing.T) {
+	n := New(frostKeygen(t), WithoutAborts())
+	n.Inject("c", Round(2, Only(Tamper(flip), "a")))
+	n.Run()
+	_, errs := n.Results()
+	assert.Error(t, errs["a"])
+	assert.Error(t, errs["b"], "b should detect that c sent a different broadcast message to a")
+}
+
+// equivocate returns a Fault delivering the first messages of h instead of the messages sent to the given parties.
+// h must be started for the same session and with the same Identity as the sending party, so that its messages are valid,
+// but its randomness differs.
+func equivocate(t *testing.T, h protocol.Handler, to ...party.ID) Fault {
+	var msgs []*protocol.Message
+	for len(msgs) == 0 || len(h.Listen()) > 0 {
+		select {
+		case msg := <-h.Listen():
+			msgs = append(msgs, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no message from the equivocating handler")
+		}
+	}
+	return Only(func(e Envelope) []Envelope {
+		for _, msg := range msgs {
+			if msg.RoundNumber == e.Message.RoundNumber && msg.Broadcast == e.Message.Broadcast && msg.IsFor(e.To) {
+				e.Message = msg
+				break
+			}
+		}
+		return []Envelope{e}
+	}, to...)
+}
+
+func TestEquivocateIdentity(t *testing.T) {
+	identities := identities(t, partyIDs)
+	start := func(id party.ID) protocol.Handler {
+		h, err := protocol.NewMultiHandler(frost.Keygen(curve.Secp256k1{}, id, partyIDs, 1), nil, protocol.WithIdentity(identities[id]))
+		require.NoError(t, err)
+		return h
+	}
+	handlers := map[party.ID]protocol.Handler{}
+	for _, id := range partyIDs {
+		handlers[id] = start(id)
+	}
+	n := New(handlers, WithoutAborts())
+	n.Inject("c", Round(2, equivocate(t, start("c"), "a")))
+	n.Run()
+	AssertCulprits(t, n, "c")
+}
+
+func TestEquivocateCMP(t *testing.T) {
+	configs, signers := test.GenerateConfig(curve.Secp256k1{}, 3, 1, rand.Reader, nil)
+	identities := identities(t, signers)
+	messageHash := make([]byte, 32)
+	start := func(id party.ID) protocol.Handler {
+		h, err := protocol.NewMultiHandler(cmp.Sign(configs[id], signers, messageHash, nil), nil, protocol.WithIdentity(identities[id]))
+		require.NoError(t, err)
+		return h
+	}
+	handlers := map[party.ID]protocol.Handler{}
+	for _, id := range signers {
+		handlers[id] = start(id)
+	}
+	n := New(handlers, WithoutAborts())
+	n.Inject("c", Round(2, equivocate(t, start("c"), "a")))
+	n.Run()
+	AssertCulprits(t, n, "c")
+}
+
+func TestDrop(t *testing.T) {
+	n := New(frostKeygen(t, protocol.WithRoundTimeout(500*time.Millisecond)), WithoutAborts(), WithIdleTimeout(5*time.Second))
+	n.Inject("c", Round(3, Drop()))
+	n.Run()
+	AssertCulprits(t, n, "c")
+	_, errs := n.Results()
+	assert.ErrorIs(t, errs["a"], protocol.ErrRoundTimeout)
+}
+
+func TestTwoParty(t *testing.T) {
+	ids := party.NewIDSlice([]party.ID{"a", "b"})
+	handlers := map[party.ID]protocol.Handler{}
+	for i, id := range ids {
+		h, err := protocol.NewTwoPartyHandler(doerner.Keygen(curve.Secp256k1{}, i == 0, id, ids[1-i], nil), nil, i == 0)
+		require.NoError(t, err)
+		handlers[id] = h
+	}
+	n := New(handlers, WithReordering(3))
+	n.Inject("a", Duplicate())
+	n.Run()
+	AssertSuccess(t, n)
+}
@@ -0,0 +1,46 @@
+package taproot
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+)
+
+// TapTweak calculates the scalar t = hash_TapTweak(P || merkleRoot), by which an internal key P is tweaked.
+//
+// merkleRoot is the 32 byte root of the script tree, and is empty if the output has no script path.
+//
+// See: https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki#constructing-and-spending-taproot-outputs
+func TapTweak(internalKey PublicKey, merkleRoot []byte) (*curve.Secp256k1Scalar, error) {
+	if len(internalKey) != 32 {
+		return nil, fmt.Errorf("tweak: invalid internal key length %d", len(internalKey))
+	}
+	if len(merkleRoot) != 0 && len(merkleRoot) != 32 {
+		return nil, fmt.Errorf("tweak: invalid merkle root length %d", len(merkleRoot))
+	}
+	tweak := new(curve.Secp256k1Scalar)
+	if err := tweak.UnmarshalBinary(TaggedHash("TapTweak", internalKey, merkleRoot)); err != nil {
+		return nil, fmt.Errorf("tweak: %w", err)
+	}
+	return tweak, nil
+}
+
+// TweakPublicKey calculates the output key Q = P + t⋅G of an internal key P, where t is given by TapTweak.
+//
+// The second return value indicates whether Q has an odd y coordinate. This is the parity bit
+// which must be set in the control block when spending the output through its script path.
+func TweakPublicKey(internalKey PublicKey, merkleRoot []byte) (PublicKey, bool, error) {
+	tweak, err := TapTweak(internalKey, merkleRoot)
+	if err != nil {
+		return nil, false, err
+	}
+	P, err := curve.Secp256k1{}.LiftX(internalKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("tweak: %w", err)
+	}
+	Q := P.Add(tweak.ActOnBase()).(*curve.Secp256k1Point)
+	if Q.IsIdentity() {
+		return nil, false, fmt.Errorf("tweak: output key is the identity")
+	}
+	return Q.XBytes(), !Q.HasEvenY(), nil
+}
@@ -0,0 +1,58 @@
+package taproot
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from https://github.com/bitcoin/bips/blob/master/bip-0341/wallet-test-vectors.json
+//
+// The vectors only give the parity of the output key for outputs with a script path,
+// as the first byte of their control blocks: 0xc0 if it is even, and 0xc1 if it is odd.
+func TestTweakPublicKey(t *testing.T) {
+	tests := []struct {
+		internalKey, merkleRoot, tweak, outputKey string
+		odd                                       bool
+	}{
+		{
+			"d6889cb081036e0faefa3a35157ad71086b123b2b144b649798b494c300a961d",
+			"",
+			"b86e7be8f39bab32a6f2c0443abbc210f0edac0e2c53d501b36b64437d9c6c70",
+			"53a1f6e454df1aa2776a2814a721372d6258050de330b3c6d10ee8f4e0dda343",
+			false,
+		},
+		{
+			"187791b6f712a8ea41c8ecdd0ee77fab3e85263b37e1ec18a3651926b3a6cf27",
+			"5b75adecf53548f3ec6ad7d78383bf84cc57b55a3127c72b9a2481752dd88b21",
+			"cbd8679ba636c1110ea247542cfbd964131a6be84f873f7f3b62a777528ed001",
+			"147c9c57132f6e7ecddba9800bb0c4449251c92a1e60371ee77557b6620f3ea3",
+			true,
+		},
+		{
+			"93478e9488f956df2396be2ce6c5cced75f900dfa18e7dabd2428aae78451820",
+			"c525714a7f49c28aedbbba78c005931a81c234b2f6c99a73e4d06082adc8bf2b",
+			"6af9e28dbf9d6aaf027696e2598a5b3d056f5fd2355a7fd5a37a0e5008132d30",
+			"e4d810fd50586274face62b8a807eb9719cef49c04177cc6b76a9a4251d5450e",
+			false,
+		},
+	}
+	for _, test := range tests {
+		internalKey, _ := hex.DecodeString(test.internalKey)
+		merkleRoot, _ := hex.DecodeString(test.merkleRoot)
+
+		tweak, err := TapTweak(internalKey, merkleRoot)
+		require.NoError(t, err)
+		tweakBytes, _ := tweak.MarshalBinary()
+		assert.Equal(t, test.tweak, hex.EncodeToString(tweakBytes))
+
+		outputKey, odd, err := TweakPublicKey(internalKey, merkleRoot)
+		require.NoError(t, err)
+		assert.Equal(t, test.outputKey, hex.EncodeToString(outputKey))
+		if len(merkleRoot) != 0 {
+			assert.Equal(t, test.odd, odd)
+		}
+	}
+}
@@ -0,0 +1,368 @@
+// Package tcp connects a fixed set of parties over TCP, authenticated with mutual TLS,
+// so that a protocol.Handler can be run across separate processes.
+package tcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+// Config describes the parties of the network, and the credentials of this party.
+type Config struct {
+	// SelfID is the party.ID of this party.
+	SelfID party.ID
+	// Addresses contains the address on which each party listens, including this one.
+	Addresses map[party.ID]string
+	// Certificate is presented to other parties, and must contain SelfID as a DNS name.
+	// It must not be valid for the party.ID of another party.
+	Certificate tls.Certificate
+	// CAs is used to verify the certificates of other parties.
+	CAs *x509.CertPool
+	// Listener is used to accept connections if set, instead of listening on Addresses[SelfID].
+	Listener net.Listener
+	// ReconnectDelay is the time waited before reconnecting to a party after a failure. Defaults to one second.
+	ReconnectDelay time.Duration
+	// HandshakeTimeout bounds the time taken by the TLS handshake of a connection. Defaults to ten seconds.
+	HandshakeTimeout time.Duration
+	// WriteTimeout bounds the time taken to write a message or an acknowledgement to a connection,
+	// which is closed when it expires. Defaults to ten seconds.
+	WriteTimeout time.Duration
+	// MaxPendingSize bounds the total size of the messages kept for a party until it acknowledges them.
+	// A message which would exceed it is dropped for that party. Defaults to four times the maximum message size.
+	MaxPendingSize int
+	// RouterOptions configure the protocol.Router dispatching the received messages.
+	RouterOptions []protocol.RouterOption
+}
+
+var (
+	// ErrMessageTooLarge is returned by Network.Send for a message larger than the maximum size accepted by other parties.
+	ErrMessageTooLarge = errors.New("tcp: message too large")
+	// ErrPendingLimit is returned by Network.Send when a message is dropped for a party,
+	// because the messages it did not acknowledge would exceed Config.MaxPendingSize.
+	ErrPendingLimit = errors.New("tcp: too many messages pending")
+)
+
+// Network sends the messages of this party to the others, and routes the messages it receives
+// to the running handlers with a protocol.Router.
+//
+// Each party dials all other parties, and the connections are only used to send messages,
+// which the receiver acknowledges.
+// A party is identified by its certificate, and only messages it sent are accepted on its connections.
+type Network struct {
+	config    Config
+	tlsConfig *tls.Config
+	listener  net.Listener
+	peers     map[party.ID]*peer
+	router    *protocol.Router
+	// instance identifies this Network, so that other parties know when its sequence numbers start again.
+	instance uint64
+
+	// conns contains all open incoming connections.
+	conns map[net.Conn]struct{}
+	// received contains the sequence numbers of the messages received from each party.
+	received map[party.ID]inbound
+	// running contains the handlers run with Run.
+	running map[session]*running
+	mtx     sync.Mutex
+
+	// ctx is cancelled when the Network is closed.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// inbound is the state of the messages received from a party.
+type inbound struct {
+	// instance is the instance of the Network of the sender.
+	instance uint64
+	// next is the sequence number of the next message, smaller numbers are duplicates.
+	next uint64
+}
+
+// session identifies a handler by the Protocol and SSID of its messages.
+type session struct {
+	protocol string
+	ssid     string
+}
+
+// running is a handler run with Run, and the error of the first message it could not send.
+type running struct {
+	handler protocol.RoutedHandler
+	err     error
+}
+
+// New returns a Network connecting this party to all others in config.Addresses.
+//
+// Connections to other parties are established when the first message is sent to them,
+// and are reopened if they are dropped.
+// The messages which were not acknowledged by the recipient are then sent again,
+// so that no message is lost when a connection is reopened while both Networks are running.
+// Messages may still be lost when a party restarts its process.
+func New(config Config) (*Network, error) {
+	if _, ok := config.Addresses[config.SelfID]; !ok && config.Listener == nil {
+		return nil, errors.New("tcp: no address for self")
+	}
+	if config.ReconnectDelay <= 0 {
+		config.ReconnectDelay = time.Second
+	}
+	if config.HandshakeTimeout <= 0 {
+		config.HandshakeTimeout = 10 * time.Second
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 10 * time.Second
+	}
+	if config.MaxPendingSize <= 0 {
+		config.MaxPendingSize = 4 * maxMessageSize
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{config.Certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    config.CAs,
+		RootCAs:      config.CAs,
+		MinVersion:   tls.VersionTLS13,
+	}
+
+	listener := config.Listener
+	if listener == nil {
+		var err error
+		if listener, err = net.Listen("tcp", config.Addresses[config.SelfID]); err != nil {
+			return nil, fmt.Errorf("tcp: %w", err)
+		}
+	}
+
+	var instance [8]byte
+	if _, err := rand.Read(instance[:]); err != nil {
+		return nil, fmt.Errorf("tcp: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &Network{
+		config:    config,
+		tlsConfig: tlsConfig,
+		listener:  tls.NewListener(listener, tlsConfig),
+		peers:     map[party.ID]*peer{},
+		instance:  binary.BigEndian.Uint64(instance[:]),
+		conns:     map[net.Conn]struct{}{},
+		received:  map[party.ID]inbound{},
+		running:   map[session]*running{},
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	n.router = protocol.NewRouter(n.route, config.RouterOptions...)
+	for id, address := range config.Addresses {
+		if id == config.SelfID {
+			continue
+		}
+		p := newPeer(n, id, address)
+		n.peers[id] = p
+		n.wg.Add(1)
+		go p.run()
+	}
+	n.wg.Add(1)
+	go n.accept()
+	return n, nil
+}
+
+// Send sends msg to all parties for which msg.IsFor returns true.
+//
+// Messages are queued without blocking, and are sent once the connection to the recipient is established,
+// so that a party which is unreachable does not delay the messages to the others.
+// ErrMessageTooLarge is returned if msg is larger than the maximum size accepted by other parties,
+// and ErrPendingLimit if msg was dropped for some of them, in which case it is still sent to the others.
+func (n *Network) Send(msg *protocol.Message) error {
+	frame, err := encodeMessage(msg)
+	if err != nil {
+		return err
+	}
+	var dropped []party.ID
+	for id, p := range n.peers {
+		if msg.IsFor(id) && p.send(frame) != nil {
+			dropped = append(dropped, id)
+		}
+	}
+	if len(dropped) > 0 {
+		return fmt.Errorf("%w for %v", ErrPendingLimit, party.NewIDSlice(dropped))
+	}
+	return nil
+}
+
+// Run delivers the messages of h until the protocol has finished, and returns its result.
+//
+// Messages for h which were received before Run was called are delivered first.
+// Several handlers can be run concurrently on the same Network, as long as they were created with different session IDs.
+// If a message of h cannot be sent, h is stopped and Run returns the error of Send.
+func (n *Network) Run(h protocol.RoutedHandler) (interface{}, error) {
+	key := session{protocol: h.ProtocolID(), ssid: string(h.SSID())}
+	r := &running{handler: h}
+	n.mtx.Lock()
+	if _, ok := n.running[key]; ok {
+		n.mtx.Unlock()
+		return nil, protocol.ErrAlreadyRegistered
+	}
+	n.running[key] = r
+	n.mtx.Unlock()
+
+	result, err := n.router.Run(h)
+
+	n.mtx.Lock()
+	delete(n.running, key)
+	if r.err != nil {
+		result, err = nil, r.err
+	}
+	n.mtx.Unlock()
+	return result, err
+}
+
+// route sends the messages of the handlers run with Run, and stops a handler when one of its messages is dropped.
+func (n *Network) route(msg *protocol.Message) {
+	err := n.Send(msg)
+	if err == nil {
+		return
+	}
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	r, ok := n.running[session{protocol: msg.Protocol, ssid: string(msg.SSID)}]
+	if !ok || r.err != nil {
+		return
+	}
+	r.err = err
+	// Stop may wait for the handler to output a message, which the router only reads once route returns
+	go r.handler.Stop()
+}
+
+// Close closes all connections, and stops the Network.
+func (n *Network) Close() error {
+	n.cancel()
+	err := n.listener.Close()
+	n.mtx.Lock()
+	for conn := range n.conns {
+		_ = conn.Close()
+	}
+	n.mtx.Unlock()
+	for _, p := range n.peers {
+		p.close()
+	}
+	n.wg.Wait()
+	return err
+}
+
+// accept handles the connections of other parties.
+func (n *Network) accept() {
+	defer n.wg.Done()
+	for {
+		conn, err := n.listener.Accept()
+		if err != nil {
+			if n.ctx.Err() != nil {
+				return
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return
+		}
+		n.mtx.Lock()
+		if n.ctx.Err() != nil {
+			n.mtx.Unlock()
+			_ = conn.Close()
+			return
+		}
+		n.conns[conn] = struct{}{}
+		n.mtx.Unlock()
+		n.wg.Add(1)
+		go n.receive(conn.(*tls.Conn))
+	}
+}
+
+// receive reads the messages sent by a party over conn, and acknowledges them, until the connection is closed.
+func (n *Network) receive(conn *tls.Conn) {
+	defer n.wg.Done()
+	defer func() {
+		n.mtx.Lock()
+		delete(n.conns, conn)
+		n.mtx.Unlock()
+		_ = conn.Close()
+	}()
+
+	// a party which does not complete the handshake must not hold the connection
+	if err := conn.SetDeadline(time.Now().Add(n.config.HandshakeTimeout)); err != nil {
+		return
+	}
+	if err := conn.Handshake(); err != nil {
+		return
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return
+	}
+	from, ok := n.identify(conn.ConnectionState())
+	if !ok {
+		return
+	}
+	var hello [8]byte
+	if _, err := io.ReadFull(conn, hello[:]); err != nil {
+		return
+	}
+	instance := binary.BigEndian.Uint64(hello[:])
+	var ack [8]byte
+	for {
+		seq, data, err := readFrame(conn)
+		if err != nil {
+			return
+		}
+		msg := new(protocol.Message)
+		// a party may only send its own messages, and messages sent again after a reconnection are dropped
+		if msg.UnmarshalBinary(data) == nil && msg.From == from && n.sequence(from, instance, seq) {
+			n.router.Accept(msg)
+		}
+		binary.BigEndian.PutUint64(ack[:], seq)
+		if err = n.write(conn, ack[:]); err != nil {
+			return
+		}
+	}
+}
+
+// write writes data to conn, and fails if the other party does not read it before the write timeout.
+func (n *Network) write(conn net.Conn, data []byte) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(n.config.WriteTimeout)); err != nil {
+		return err
+	}
+	_, err := conn.Write(data)
+	return err
+}
+
+// sequence returns true if the message numbered seq from a party was not received before.
+func (n *Network) sequence(from party.ID, instance, seq uint64) bool {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if in, ok := n.received[from]; ok && in.instance == instance && seq < in.next {
+		return false
+	}
+	n.received[from] = inbound{instance: instance, next: seq + 1}
+	return true
+}
+
+// identify returns the party.ID of the other party of a connection, given by the DNS names of its certificate.
+func (n *Network) identify(state tls.ConnectionState) (party.ID, bool) {
+	if len(state.PeerCertificates) == 0 {
+		return "", false
+	}
+	cert := state.PeerCertificates[0]
+	for id := range n.peers {
+		if cert.VerifyHostname(string(id)) == nil {
+			return id, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,372 @@
+package tcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost"
+)
+
+// certificates returns a CA, and a certificate issued by it for each party.
+func certificates(t *testing.T, partyIDs []party.ID) (*x509.CertPool, map[party.ID]tls.Certificate) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	certs := map[party.ID]tls.Certificate{}
+	for i, id := range partyIDs {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 2)),
+			Subject:      pkix.Name{CommonName: string(id)},
+			DNSNames:     []string{string(id)},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		certs[id] = tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	}
+	return pool, certs
+}
+
+func networks(t *testing.T, partyIDs []party.ID) map[party.ID]*Network {
+	pool, certs := certificates(t, partyIDs)
+	listeners := map[party.ID]net.Listener{}
+	addresses := map[party.ID]string{}
+	for _, id := range partyIDs {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		listeners[id] = l
+		addresses[id] = l.Addr().String()
+	}
+	networks := map[party.ID]*Network{}
+	for _, id := range partyIDs {
+		n, err := New(Config{
+			SelfID:           id,
+			Addresses:        addresses,
+			Certificate:      certs[id],
+			CAs:              pool,
+			Listener:         listeners[id],
+			ReconnectDelay:   10 * time.Millisecond,
+			HandshakeTimeout: 100 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		networks[id] = n
+		t.Cleanup(func() { _ = n.Close() })
+	}
+	return networks
+}
+
+func runKeygen(t *testing.T, networks map[party.ID]*Network, partyIDs []party.ID, sessionID []byte) {
+	var wg sync.WaitGroup
+	results := make([]interface{}, len(partyIDs))
+	errs := make([]error, len(partyIDs))
+	for i, id := range partyIDs {
+		h, err := protocol.NewMultiHandler(frost.Keygen(curve.Secp256k1{}, id, partyIDs, 1), sessionID)
+		require.NoError(t, err)
+		wg.Add(1)
+		go func(i int, n *Network) {
+			defer wg.Done()
+			results[i], errs[i] = n.Run(h)
+		}(i, networks[id])
+	}
+	wg.Wait()
+
+	for i := range partyIDs {
+		require.NoError(t, errs[i])
+		assert.True(t, results[0].(*frost.Config).PublicKey.Equal(results[i].(*frost.Config).PublicKey))
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	networks := networks(t, partyIDs)
+	runKeygen(t, networks, partyIDs, []byte("session 1"))
+
+	// drop all connections, which must be reopened for the next session
+	for _, n := range networks {
+		for _, p := range n.peers {
+			p.close()
+		}
+	}
+	runKeygen(t, networks, partyIDs, []byte("session 2"))
+}
+
+func TestNetworkUnknownParty(t *testing.T) {
+	partyIDs := []party.ID{"a", "b"}
+	networks := networks(t, partyIDs)
+	_, certs := certificates(t, []party.ID{"a"})
+
+	// a certificate from another CA is rejected
+	n := networks["b"]
+	config := n.tlsConfig.Clone()
+	config.Certificates = []tls.Certificate{certs["a"]}
+	config.ServerName = "b"
+	conn, err := tls.Dial("tcp", n.config.Addresses["b"], config)
+	if err == nil {
+		err = conn.Handshake()
+		if err == nil {
+			_, err = conn.Read(make([]byte, 1))
+		}
+		_ = conn.Close()
+	}
+	assert.Error(t, err)
+}
+
+func TestNetworkMessageTooLarge(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	networks := networks(t, partyIDs)
+
+	// the message is dropped instead of blocking the following ones
+	err := networks["a"].Send(&protocol.Message{
+		SSID:     []byte("session 0"),
+		From:     "a",
+		Protocol: "test",
+		Data:     make([]byte, maxMessageSize),
+	})
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+	for _, p := range networks["a"].peers {
+		p.mtx.Lock()
+		assert.Empty(t, p.pending)
+		p.mtx.Unlock()
+	}
+	runKeygen(t, networks, partyIDs, []byte("session 1"))
+}
+
+func TestNetworkUnreachable(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	networks := networks(t, partyIDs)
+	require.NoError(t, networks["c"].Close())
+
+	// messages for a party which is down do not block the messages to the others
+	for i := 0; i < 10000; i++ {
+		require.NoError(t, networks["a"].Send(&protocol.Message{SSID: []byte("session 0"), From: "a", To: "c", Protocol: "test"}))
+	}
+	runKeygen(t, networks, partyIDs[:2], []byte("session 1"))
+}
+
+func TestNetworkPendingLimit(t *testing.T) {
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "c"})
+	networks := networks(t, partyIDs)
+	require.NoError(t, networks["c"].Close())
+	n := networks["a"]
+	n.config.MaxPendingSize = 1 << 12
+
+	// the messages for a party which is down are dropped once the limit is reached,
+	// and the messages of the handler below are larger than the space left
+	message := &protocol.Message{SSID: []byte("session 0"), From: "a", To: "c", Protocol: "test"}
+	var err error
+	for i := 0; i < 1000 && err == nil; i++ {
+		err = n.Send(message)
+	}
+	assert.ErrorIs(t, err, ErrPendingLimit)
+	p := n.peers["c"]
+	p.mtx.Lock()
+	assert.LessOrEqual(t, p.pendingSize, n.config.MaxPendingSize)
+	assert.Less(t, len(p.pending), 1000)
+	p.mtx.Unlock()
+
+	// the messages to the other parties are still sent
+	message.To = "b"
+	require.NoError(t, n.Send(message))
+	assert.Eventually(t, func() bool {
+		p := n.peers["b"]
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+		return len(p.pending) == 0 && p.pendingSize == 0
+	}, 5*time.Second, 10*time.Millisecond, "acknowledged messages should not be pending")
+
+	// a handler whose message is dropped is stopped
+	h, err := protocol.NewMultiHandler(frost.Keygen(curve.Secp256k1{}, "a", partyIDs, 1), []byte("session 1"))
+	require.NoError(t, err)
+	_, err = n.Run(h)
+	assert.ErrorIs(t, err, ErrPendingLimit)
+}
+
+func TestNetworkHandshakeTimeout(t *testing.T) {
+	partyIDs := []party.ID{"a", "b"}
+	networks := networks(t, partyIDs)
+
+	// a client which never starts the handshake is disconnected
+	conn, err := net.Dial("tcp", networks["b"].config.Addresses["b"])
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		assert.False(t, netErr.Timeout(), "the connection should have been closed by the server")
+	}
+	assert.Error(t, err)
+}
+
+func TestNetworkResend(t *testing.T) {
+	partyIDs := []party.ID{"a", "b"}
+	pool, certs := certificates(t, partyIDs)
+	listenerA, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	listenerB, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	// b is played by the test, which receives the messages of a directly
+	listenerB = tls.NewListener(listenerB, &tls.Config{
+		Certificates: []tls.Certificate{certs["b"]},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS13,
+	})
+	t.Cleanup(func() { _ = listenerB.Close() })
+	n, err := New(Config{
+		SelfID:         "a",
+		Addresses:      map[party.ID]string{"a": listenerA.Addr().String(), "b": listenerB.Addr().String()},
+		Certificate:    certs["a"],
+		CAs:            pool,
+		Listener:       listenerA,
+		ReconnectDelay: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = n.Close() })
+
+	message := func(data string) *protocol.Message {
+		return &protocol.Message{SSID: []byte("session"), From: "a", Protocol: "test", Data: []byte(data)}
+	}
+	accept := func() (net.Conn, uint64) {
+		conn, err := listenerB.Accept()
+		require.NoError(t, err)
+		var hello [8]byte
+		_, err = io.ReadFull(conn, hello[:])
+		require.NoError(t, err)
+		return conn, binary.BigEndian.Uint64(hello[:])
+	}
+	receive := func(conn net.Conn, expectedSeq uint64, expectedData string) {
+		seq, data, err := readFrame(conn)
+		require.NoError(t, err)
+		msg := new(protocol.Message)
+		require.NoError(t, msg.UnmarshalBinary(data))
+		assert.Equal(t, expectedSeq, seq)
+		assert.Equal(t, expectedData, string(msg.Data))
+	}
+	acknowledge := func(conn net.Conn, seq uint64) {
+		var ack [8]byte
+		binary.BigEndian.PutUint64(ack[:], seq)
+		_, err := conn.Write(ack[:])
+		require.NoError(t, err)
+	}
+
+	// the first message is lost with the connection, after being written to it
+	n.Send(message("1"))
+	conn1, instance1 := accept()
+	receive(conn1, 1, "1")
+	require.NoError(t, conn1.Close())
+
+	// it is sent again over the next connection, without sending another message
+	conn2, instance2 := accept()
+	defer conn2.Close()
+	assert.Equal(t, instance1, instance2)
+	receive(conn2, 1, "1")
+	acknowledge(conn2, 1)
+
+	n.Send(message("2"))
+	receive(conn2, 2, "2")
+	acknowledge(conn2, 2)
+	assert.Eventually(t, func() bool {
+		p := n.peers["b"]
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+		return len(p.pending) == 0
+	}, time.Second, 10*time.Millisecond, "acknowledged messages should not be pending")
+}
+
+func TestNetworkWriteTimeout(t *testing.T) {
+	partyIDs := []party.ID{"a", "b"}
+	pool, certs := certificates(t, partyIDs)
+	listenerA, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	listenerB, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	// b is played by the test, and stops reading from its first connection
+	listenerB = tls.NewListener(listenerB, &tls.Config{
+		Certificates: []tls.Certificate{certs["b"]},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS13,
+	})
+	t.Cleanup(func() { _ = listenerB.Close() })
+	n, err := New(Config{
+		SelfID:         "a",
+		Addresses:      map[party.ID]string{"a": listenerA.Addr().String(), "b": listenerB.Addr().String()},
+		Certificate:    certs["a"],
+		CAs:            pool,
+		Listener:       listenerA,
+		ReconnectDelay: 10 * time.Millisecond,
+		WriteTimeout:   time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = n.Close() })
+
+	// the message is larger than what the connection can buffer
+	n.Send(&protocol.Message{SSID: []byte("session"), From: "a", Protocol: "test", Data: make([]byte, maxMessageSize/4)})
+	conn1, err := listenerB.Accept()
+	require.NoError(t, err)
+	defer conn1.Close()
+	_, err = io.ReadFull(conn1, make([]byte, 8))
+	require.NoError(t, err)
+
+	// the write times out, and the message is sent again over a new connection
+	conn2, err := listenerB.Accept()
+	require.NoError(t, err)
+	defer conn2.Close()
+	_, err = io.ReadFull(conn2, make([]byte, 8))
+	require.NoError(t, err)
+	seq, data, err := readFrame(conn2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), seq)
+	msg := new(protocol.Message)
+	require.NoError(t, msg.UnmarshalBinary(data))
+	assert.Len(t, msg.Data, maxMessageSize/4)
+}
+
+func TestNetworkDuplicate(t *testing.T) {
+	n := &Network{received: map[party.ID]inbound{}}
+	assert.True(t, n.sequence("a", 1, 1))
+	assert.True(t, n.sequence("a", 1, 2))
+	assert.False(t, n.sequence("a", 1, 1), "a message sent again should be dropped")
+	assert.False(t, n.sequence("a", 1, 2), "a message sent again should be dropped")
+	assert.True(t, n.sequence("b", 1, 1))
+	// the sequence numbers of a restarted party start again
+	assert.True(t, n.sequence("a", 2, 1))
+}
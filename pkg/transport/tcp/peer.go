@@ -0,0 +1,271 @@
+package tcp
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+// maxMessageSize bounds the size of a single message.
+// The largest messages are sent during the CMP keygen, and contain several Paillier proofs.
+const maxMessageSize = 1 << 26
+
+// peer sends the messages of this party to another party.
+//
+// Each message is numbered, and is kept until the other party acknowledges it,
+// so that the messages which were lost with a connection are sent again over the next one.
+// Sending a message never blocks, and messages are only dropped when the messages kept for the other party
+// would exceed Config.MaxPendingSize, while it is unreachable or does not acknowledge them.
+type peer struct {
+	network *Network
+	id      party.ID
+	address string
+	// wake is signalled when a message is added, or when the connection is lost while some messages are not acknowledged.
+	wake chan struct{}
+
+	conn net.Conn
+	// seq is the sequence number of the last added message.
+	seq uint64
+	// written is the sequence number of the last message written over conn.
+	written uint64
+	// pending contains the messages which were not acknowledged yet, in order.
+	pending []*frame
+	// pendingSize is the total size of the pending messages.
+	pendingSize int
+	mtx         sync.Mutex
+}
+
+// frame is an encoded message, and its sequence number for a given party.
+type frame struct {
+	seq  uint64
+	data []byte
+}
+
+func newPeer(n *Network, id party.ID, address string) *peer {
+	return &peer{
+		network: n,
+		id:      id,
+		address: address,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// send numbers a message, and adds it to the pending messages, which run writes to the connection.
+// ErrPendingLimit is returned, and the message is dropped, if the pending messages would exceed Config.MaxPendingSize.
+func (p *peer) send(data []byte) error {
+	p.mtx.Lock()
+	if p.pendingSize+len(data) > p.network.config.MaxPendingSize {
+		p.mtx.Unlock()
+		return ErrPendingLimit
+	}
+	p.seq++
+	p.pending = append(p.pending, &frame{seq: p.seq, data: data})
+	p.pendingSize += len(data)
+	p.mtx.Unlock()
+	p.signal()
+	return nil
+}
+
+// signal wakes up run, without blocking if it was already signalled.
+func (p *peer) signal() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run writes the pending messages to the connection, reconnecting whenever it fails.
+func (p *peer) run() {
+	defer p.network.wg.Done()
+	for {
+		select {
+		case <-p.wake:
+		case <-p.network.ctx.Done():
+			return
+		}
+		for !p.flush() {
+			select {
+			case <-time.After(p.network.config.ReconnectDelay):
+			case <-p.network.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// flush writes the pending messages which were not written over the current connection yet.
+// If there is no connection, a new one is opened, and all pending messages are sent again.
+// If false is returned, the connection was closed and flush must be called again.
+func (p *peer) flush() bool {
+	p.mtx.Lock()
+	conn := p.conn
+	idle := len(p.pending) == 0
+	p.mtx.Unlock()
+
+	if conn == nil {
+		if idle {
+			return true
+		}
+		var err error
+		if conn, err = p.dial(); err != nil {
+			return false
+		}
+	}
+	for {
+		frames := p.unwritten(conn)
+		if len(frames) == 0 {
+			return true
+		}
+		for _, f := range frames {
+			if err := p.network.write(conn, f.encode()); err != nil {
+				p.reset(conn)
+				return false
+			}
+			p.mtx.Lock()
+			if p.conn == conn {
+				p.written = f.seq
+			}
+			p.mtx.Unlock()
+		}
+	}
+}
+
+// unwritten returns the pending messages which were not written over conn yet.
+func (p *peer) unwritten(conn net.Conn) []*frame {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.conn != conn {
+		return nil
+	}
+	i := 0
+	for i < len(p.pending) && p.pending[i].seq <= p.written {
+		i++
+	}
+	return append([]*frame(nil), p.pending[i:]...)
+}
+
+// dial opens a new connection, over which all pending messages must be sent again.
+func (p *peer) dial() (net.Conn, error) {
+	config := p.network.tlsConfig.Clone()
+	config.ServerName = string(p.id)
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: p.network.config.HandshakeTimeout},
+		Config:    config,
+	}
+	conn, err := dialer.DialContext(p.network.ctx, "tcp", p.address)
+	if err != nil {
+		return nil, err
+	}
+	// the instance lets the other party know whether the sequence numbers were restarted
+	var hello [8]byte
+	binary.BigEndian.PutUint64(hello[:], p.network.instance)
+	if err = p.network.write(conn, hello[:]); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.network.ctx.Err() != nil {
+		_ = conn.Close()
+		return nil, net.ErrClosed
+	}
+	p.conn = conn
+	p.written = 0
+	p.network.wg.Add(1)
+	go p.acknowledge(conn)
+	return conn, nil
+}
+
+// acknowledge removes the messages acknowledged by the other party over conn from the pending messages,
+// until the connection is closed.
+func (p *peer) acknowledge(conn net.Conn) {
+	defer p.network.wg.Done()
+	var ack [8]byte
+	for {
+		if _, err := io.ReadFull(conn, ack[:]); err != nil {
+			break
+		}
+		seq := binary.BigEndian.Uint64(ack[:])
+		p.mtx.Lock()
+		for len(p.pending) > 0 && p.pending[0].seq <= seq {
+			p.pendingSize -= len(p.pending[0].data)
+			p.pending[0] = nil
+			p.pending = p.pending[1:]
+		}
+		p.mtx.Unlock()
+	}
+	p.reset(conn)
+
+	// the messages which were not acknowledged may have been lost with the connection
+	p.mtx.Lock()
+	lost := len(p.pending) > 0
+	p.mtx.Unlock()
+	if lost {
+		p.signal()
+	}
+}
+
+// reset closes conn, so that a new connection is opened for the next message.
+func (p *peer) reset(conn net.Conn) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	_ = conn.Close()
+	if p.conn == conn {
+		p.conn = nil
+	}
+}
+
+func (p *peer) close() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+}
+
+// encode returns the message of f, prefixed by its sequence number.
+func (f *frame) encode() []byte {
+	buf := make([]byte, 8, 8+len(f.data))
+	binary.BigEndian.PutUint64(buf, f.seq)
+	return append(buf, f.data...)
+}
+
+// encodeMessage returns the CBOR encoding of msg, prefixed by its length.
+func encodeMessage(msg *protocol.Message) ([]byte, error) {
+	data, err := msg.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxMessageSize {
+		return nil, ErrMessageTooLarge
+	}
+	frame := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[4:], data)
+	return frame, nil
+}
+
+// readFrame reads a message encoded by encodeMessage, and the sequence number which precedes it.
+func readFrame(r io.Reader) (uint64, []byte, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	seq := binary.BigEndian.Uint64(header[:8])
+	size := binary.BigEndian.Uint32(header[8:])
+	if size > maxMessageSize {
+		return 0, nil, fmt.Errorf("tcp: message of %d bytes is too large", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, err
+	}
+	return seq, data, nil
+}
@@ -1,6 +1,7 @@
 package zk
 
 import (
+	"crypto/rand"
 	"fmt"
 
 	"github.com/cronokirby/saferith"
@@ -21,8 +22,8 @@ func generate() {
 	pl := pool.NewPool(0)
 	defer pl.TearDown()
 
-	sk1 := paillier.NewSecretKey(pl)
-	sk2 := paillier.NewSecretKey(pl)
+	sk1 := paillier.NewSecretKey(rand.Reader, pl)
+	sk2 := paillier.NewSecretKey(rand.Reader, pl)
 	fmt.Printf("p1, _ := new(saferith.Nat).SetHex(\"%s\")\n", sk1.P().Hex())
 	fmt.Printf("q1, _ := new(saferith.Nat).SetHex(\"%s\")\n", sk1.Q().Hex())
 	fmt.Printf("p2, _ := new(saferith.Nat).SetHex(\"%s\")\n", sk2.P().Hex())
@@ -32,7 +33,7 @@ func generate() {
 	fmt.Println("VerifierPaillierSecret = paillier.NewSecretKeyFromPrimes(p2, q2)")
 	fmt.Println("ProverPaillierPublic = ProverPaillierSecret.PublicKey")
 	fmt.Println("VerifierPaillierPublic = VerifierPaillierSecret.PublicKey")
-	ped, _ := sk2.GeneratePedersen()
+	ped, _ := sk2.GeneratePedersen(rand.Reader)
 	fmt.Printf("s, _ := new(saferith.Nat).SetHex(\"%s\")\n", ped.S().Hex())
 	fmt.Printf("t, _ := new(saferith.Nat).SetHex(\"%s\")\n", ped.T().Hex())
 	fmt.Println("Pedersen, _ = pedersen.New(VerifierPaillierPublic.N(), s, t)")
@@ -0,0 +1,123 @@
+// Package zkdleq implements a proof of equality of discrete logarithms, following Chaum and Pedersen.
+//
+// It is the proof of pkg/zk/log without the knowledge of the discrete logarithm of H,
+// so that H can be any point, such as the public key of a counterparty.
+package zkdleq
+
+import (
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+)
+
+type Public struct {
+	// H is an arbitrary point.
+	H curve.Point
+
+	// X = a⋅G
+	X curve.Point
+
+	// Y = a⋅H
+	Y curve.Point
+}
+
+type Private struct {
+	// A = a
+	A curve.Scalar
+}
+
+type Commitment struct {
+	// A = α⋅G
+	A curve.Point
+	// B = α⋅H
+	B curve.Point
+}
+
+type Proof struct {
+	group curve.Curve
+	*Commitment
+
+	// Z = α+ea (mod q)
+	Z curve.Scalar
+}
+
+func (p *Proof) IsValid() bool {
+	if p == nil || p.Commitment == nil || p.A == nil || p.B == nil || p.Z == nil {
+		return false
+	}
+	if p.A.IsIdentity() || p.B.IsIdentity() {
+		return false
+	}
+	if p.Z.IsZero() {
+		return false
+	}
+	return true
+}
+
+func NewProof(rand io.Reader, group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
+	alpha := sample.Scalar(rand, group)
+
+	commitment := &Commitment{
+		A: alpha.ActOnBase(),   // A = α⋅G
+		B: alpha.Act(public.H), // B = α⋅H
+	}
+	e, _ := challenge(hash, group, public, commitment)
+
+	return &Proof{
+		group:      group,
+		Commitment: commitment,
+		Z:          group.NewScalar().Set(e).Mul(private.A).Add(alpha), // Z = α+ea (mod q)
+	}
+}
+
+func (p *Proof) Verify(hash *hash.Hash, public Public) bool {
+	if !p.IsValid() {
+		return false
+	}
+	if public.H.IsIdentity() {
+		return false
+	}
+
+	e, err := challenge(hash, p.group, public, p.Commitment)
+	if err != nil {
+		return false
+	}
+
+	{
+		lhs := p.Z.ActOnBase()          // lhs = z⋅G
+		rhs := e.Act(public.X).Add(p.A) // rhs = A+e⋅X
+		if !lhs.Equal(rhs) {
+			return false
+		}
+	}
+
+	{
+		lhs := p.Z.Act(public.H)        // lhs = z⋅H
+		rhs := e.Act(public.Y).Add(p.B) // rhs = B+e⋅Y
+		if !lhs.Equal(rhs) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func challenge(hash *hash.Hash, group curve.Curve, public Public, commitment *Commitment) (e curve.Scalar, err error) {
+	err = hash.WriteAny(public.H, public.X, public.Y,
+		commitment.A, commitment.B)
+	e = sample.Scalar(hash.Digest(), group)
+	return
+}
+
+func Empty(group curve.Curve) *Proof {
+	return &Proof{
+		group: group,
+		Commitment: &Commitment{
+			A: group.NewPoint(),
+			B: group.NewPoint(),
+		},
+		Z: group.NewScalar(),
+	}
+}
@@ -0,0 +1,47 @@
+package zkdleq
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+)
+
+func TestDLEQ(t *testing.T) {
+	group := curve.Secp256k1{}
+
+	a := sample.Scalar(rand.Reader, group)
+	_, H := sample.ScalarPointPair(rand.Reader, group)
+	X := a.ActOnBase()
+	Y := a.Act(H)
+	public := Public{
+		H: H,
+		X: X,
+		Y: Y,
+	}
+
+	proof := NewProof(rand.Reader, group, hash.New(), public, Private{
+		A: a,
+	})
+	assert.True(t, proof.Verify(hash.New(), public))
+
+	out, err := cbor.Marshal(proof)
+	require.NoError(t, err, "failed to marshal proof")
+	proof2 := Empty(group)
+	require.NoError(t, cbor.Unmarshal(out, proof2), "failed to unmarshal proof")
+	out2, err := cbor.Marshal(proof2)
+	require.NoError(t, err, "failed to marshal 2nd proof")
+	proof3 := Empty(group)
+	require.NoError(t, cbor.Unmarshal(out2, proof3), "failed to unmarshal 2nd proof")
+
+	assert.True(t, proof3.Verify(hash.New(), public))
+
+	wrong := public
+	wrong.Y = Y.Add(H)
+	assert.False(t, proof.Verify(hash.New(), wrong), "proof should not verify for a different Y")
+}
@@ -1,9 +1,9 @@
 package zkelog
 
 import (
-	"crypto/rand"
+	"io"
 
-	"github.com/taurusgroup/multi-party-sig/internal/elgamal"
+	"github.com/taurusgroup/multi-party-sig/pkg/elgamal"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
@@ -66,9 +66,9 @@ func (p *Proof) IsValid(public Public) bool {
 	return true
 }
 
-func NewProof(group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
-	alpha := sample.Scalar(rand.Reader, group)
-	m := sample.Scalar(rand.Reader, group)
+func NewProof(rand io.Reader, group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
+	alpha := sample.Scalar(rand, group)
+	m := sample.Scalar(rand, group)
 
 	commitment := &Commitment{
 		A: alpha.ActOnBase(),                                  // A = α⋅G
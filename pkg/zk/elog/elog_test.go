@@ -7,7 +7,7 @@ import (
 	"github.com/fxamacker/cbor/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/taurusgroup/multi-party-sig/internal/elgamal"
+	"github.com/taurusgroup/multi-party-sig/pkg/elgamal"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
@@ -21,7 +21,7 @@ func TestElog(t *testing.T) {
 	y := sample.Scalar(rand.Reader, group)
 	Y := y.Act(H)
 
-	E, lambda := elgamal.Encrypt(X, y)
+	E, lambda := elgamal.Encrypt(rand.Reader, X, y)
 
 	public := Public{
 		E:             E,
@@ -30,7 +30,7 @@ func TestElog(t *testing.T) {
 		Y:             Y,
 	}
 
-	proof := NewProof(group, hash.New(), public, Private{
+	proof := NewProof(rand.Reader, group, hash.New(), public, Private{
 		Y:      y,
 		Lambda: lambda,
 	})
@@ -20,14 +20,14 @@ func TestEnc(t *testing.T) {
 	prover := zk.ProverPaillierPublic
 
 	k := sample.IntervalL(rand.Reader)
-	K, rho := prover.Enc(k)
+	K, rho := prover.Enc(rand.Reader, k)
 	public := Public{
 		K:      K,
 		Prover: prover,
 		Aux:    verifier,
 	}
 
-	proof := NewProof(group, hash.New(), public, Private{
+	proof := NewProof(rand.Reader, group, hash.New(), public, Private{
 		K:   k,
 		Rho: rho,
 	})
@@ -1,6 +1,7 @@
 package zkfac
 
 import (
+	"crypto/rand"
 	"testing"
 
 	"github.com/fxamacker/cbor/v2"
@@ -15,15 +16,15 @@ func TestFac(t *testing.T) {
 	pl := pool.NewPool(0)
 	defer pl.TearDown()
 
-	aux, _ := paillier.NewSecretKey(pl).GeneratePedersen()
-	sk := paillier.NewSecretKey(pl)
+	aux, _ := paillier.NewSecretKey(rand.Reader, pl).GeneratePedersen(rand.Reader)
+	sk := paillier.NewSecretKey(rand.Reader, pl)
 
 	public := Public{
 		N:   sk.Modulus().Modulus,
 		Aux: aux,
 	}
 
-	proof := NewProof(Private{
+	proof := NewProof(rand.Reader, Private{
 		P: sk.P(),
 		Q: sk.Q(),
 	}, hash.New(), public)
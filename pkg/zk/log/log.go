@@ -1,7 +1,7 @@
 package zklog
 
 import (
-	"crypto/rand"
+	"io"
 
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
@@ -58,9 +58,9 @@ func (p *Proof) IsValid() bool {
 	return true
 }
 
-func NewProof(group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
-	alpha := sample.Scalar(rand.Reader, group)
-	beta := sample.Scalar(rand.Reader, group)
+func NewProof(rand io.Reader, group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
+	alpha := sample.Scalar(rand, group)
+	beta := sample.Scalar(rand, group)
 
 	commitment := &Commitment{
 		A: alpha.ActOnBase(),   // A = α⋅G
@@ -1,7 +1,7 @@
 package zklogstar
 
 import (
-	"crypto/rand"
+	"io"
 
 	"github.com/cronokirby/saferith"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
@@ -74,7 +74,7 @@ func (p *Proof) IsValid(public Public) bool {
 	return true
 }
 
-func NewProof(group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
+func NewProof(rand io.Reader, group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
 	N := public.Prover.N()
 	NModulus := public.Prover.Modulus()
 
@@ -82,10 +82,10 @@ func NewProof(group curve.Curve, hash *hash.Hash, public Public, private Private
 		public.G = group.NewBasePoint()
 	}
 
-	alpha := sample.IntervalLEps(rand.Reader)
-	r := sample.UnitModN(rand.Reader, N)
-	mu := sample.IntervalLN(rand.Reader)
-	gamma := sample.IntervalLEpsN(rand.Reader)
+	alpha := sample.IntervalLEps(rand)
+	r := sample.UnitModN(rand, N)
+	mu := sample.IntervalLN(rand)
+	gamma := sample.IntervalLEpsN(rand)
 
 	commitment := &Commitment{
 		A: public.Prover.EncWithNonce(alpha, r),
@@ -22,7 +22,7 @@ func TestLogStar(t *testing.T) {
 	G := sample.Scalar(rand.Reader, group).ActOnBase()
 
 	x := sample.IntervalL(rand.Reader)
-	C, rho := prover.Enc(x)
+	C, rho := prover.Enc(rand.Reader, x)
 	X := group.NewScalar().SetNat(x.Mod(group.Order())).Act(G)
 	public := Public{
 		C:      C,
@@ -32,7 +32,7 @@ func TestLogStar(t *testing.T) {
 		Aux:    verifier,
 	}
 
-	proof := NewProof(group, hash.New(), public, Private{
+	proof := NewProof(rand.Reader, group, hash.New(), public, Private{
 		X:   x,
 		Rho: rho,
 	})
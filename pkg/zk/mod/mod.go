@@ -1,7 +1,7 @@
 package zkmod
 
 import (
-	"crypto/rand"
+	"io"
 	"math/big"
 
 	"github.com/cronokirby/saferith"
@@ -153,7 +153,7 @@ func (p *Proof) IsValid(public Public) bool {
 //   - z = y^{N⁻¹ mod ϕ(N)}
 //   - a, b s.t. y' = (-1)ᵃ wᵇ y
 //   - R = [(xᵢ aᵢ, bᵢ), zᵢ] for i = 1, …, m
-func NewProof(hash *hash.Hash, private Private, public Public, pl *pool.Pool) *Proof {
+func NewProof(rand io.Reader, hash *hash.Hash, private Private, public Public, pl *pool.Pool) *Proof {
 	n, p, q, phi := public.N, private.P, private.Q, private.Phi
 	nModulus := arith.ModulusFromFactors(p, q)
 	pHalf := new(saferith.Nat).Rsh(p, 1, -1)
@@ -162,7 +162,7 @@ func NewProof(hash *hash.Hash, private Private, public Public, pl *pool.Pool) *P
 	qMod := saferith.ModulusFromNat(q)
 	phiMod := saferith.ModulusFromNat(phi)
 	// W can be leaked so no need to make this sampling return a nat.
-	w := sample.QNR(rand.Reader, n)
+	w := sample.QNR(rand, n)
 
 	nInverse := new(saferith.Nat).ModInverse(n.Nat(), phiMod)
 
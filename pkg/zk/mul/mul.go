@@ -1,7 +1,7 @@
 package zkmul
 
 import (
-	"crypto/rand"
+	"io"
 
 	"github.com/cronokirby/saferith"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
@@ -66,18 +66,18 @@ func (p *Proof) IsValid(public Public) bool {
 	return true
 }
 
-func NewProof(group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
+func NewProof(rand io.Reader, group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
 	N := public.Prover.N()
 	NModulus := public.Prover.Modulus()
 
 	prover := public.Prover
 
-	alpha := sample.IntervalLEps(rand.Reader)
-	r := sample.UnitModN(rand.Reader, N)
-	s := sample.UnitModN(rand.Reader, N)
+	alpha := sample.IntervalLEps(rand)
+	r := sample.UnitModN(rand, N)
+	s := sample.UnitModN(rand, N)
 
 	A := public.Y.Clone().Mul(prover, alpha)
-	A.Randomize(prover, r)
+	A.Randomize(nil, prover, r)
 
 	commitment := &Commitment{
 		A: A,
@@ -119,7 +119,7 @@ func (p *Proof) Verify(group curve.Curve, hash *hash.Hash, public Public) bool {
 	{
 		// lhs = (z ⊙ Y)•uᴺ
 		lhs := public.Y.Clone().Mul(prover, p.Z)
-		lhs.Randomize(prover, p.U)
+		lhs.Randomize(nil, prover, p.U)
 
 		// (e ⊙ C) ⊕ A
 		rhs := public.C.Clone().Mul(prover, e).Add(prover, p.A)
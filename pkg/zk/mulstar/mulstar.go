@@ -1,7 +1,7 @@
 package zkmulstar
 
 import (
-	"crypto/rand"
+	"io"
 
 	"github.com/cronokirby/saferith"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
@@ -73,21 +73,21 @@ func (p *Proof) IsValid(public Public) bool {
 	return true
 }
 
-func NewProof(group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
+func NewProof(rand io.Reader, group curve.Curve, hash *hash.Hash, public Public, private Private) *Proof {
 	N0 := public.Verifier.N()
 	N0Modulus := public.Verifier.Modulus()
 
 	verifier := public.Verifier
 
-	alpha := sample.IntervalLEps(rand.Reader)
+	alpha := sample.IntervalLEps(rand)
 
-	r := sample.UnitModN(rand.Reader, N0)
+	r := sample.UnitModN(rand, N0)
 
-	gamma := sample.IntervalLEpsN(rand.Reader)
-	m := sample.IntervalLEpsN(rand.Reader)
+	gamma := sample.IntervalLEpsN(rand)
+	m := sample.IntervalLEpsN(rand)
 
 	A := public.C.Clone().Mul(verifier, alpha)
-	A.Randomize(verifier, r)
+	A.Randomize(nil, verifier, r)
 
 	commitment := &Commitment{
 		A:  A,
@@ -141,7 +141,7 @@ func (p *Proof) Verify(group curve.Curve, hash *hash.Hash, public Public) bool {
 	{
 		// lhs = z₁ ⊙ C + rand
 		lhs := public.C.Clone().Mul(verifier, p.Z1)
-		lhs.Randomize(verifier, p.W)
+		lhs.Randomize(nil, verifier, p.W)
 
 		// rhsCt = A ⊕ (e ⊙ D)
 		rhs := public.D.Clone().Mul(verifier, e).Add(verifier, p.A)
@@ -1,6 +1,7 @@
 package zkprm
 
 import (
+	"crypto/rand"
 	"testing"
 
 	"github.com/fxamacker/cbor/v2"
@@ -15,14 +16,14 @@ func TestPrm(t *testing.T) {
 	pl := pool.NewPool(0)
 	defer pl.TearDown()
 
-	sk := paillier.NewSecretKey(pl)
-	ped, lambda := sk.GeneratePedersen()
+	sk := paillier.NewSecretKey(rand.Reader, pl)
+	ped, lambda := sk.GeneratePedersen(rand.Reader)
 
 	public := Public{
 		Aux: ped,
 	}
 
-	proof := NewProof(Private{
+	proof := NewProof(rand.Reader, Private{
 		Lambda: lambda,
 		Phi:    sk.Phi(),
 		P:      sk.P(),
@@ -49,8 +50,8 @@ func BenchmarkCRT(b *testing.B) {
 	pl := pool.NewPool(0)
 	defer pl.TearDown()
 
-	sk := paillier.NewSecretKey(pl)
-	ped, lambda := sk.GeneratePedersen()
+	sk := paillier.NewSecretKey(rand.Reader, pl)
+	ped, lambda := sk.GeneratePedersen(rand.Reader)
 
 	public := Public{
 		Aux: ped,
@@ -64,6 +65,6 @@ func BenchmarkCRT(b *testing.B) {
 	}
 	b.StartTimer()
 	for i := 0; i < b.N; i++ {
-		p = NewProof(private, hash.New(), public, nil)
+		p = NewProof(rand.Reader, private, hash.New(), public, nil)
 	}
 }
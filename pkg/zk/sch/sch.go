@@ -1,9 +1,10 @@
 package zksch
 
 import (
-	"crypto/rand"
+	"errors"
 	"io"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
@@ -32,10 +33,10 @@ type Proof struct {
 }
 
 // NewProof generates a Schnorr proof of knowledge of exponent for public, using the Fiat-Shamir transform.
-func NewProof(hash *hash.Hash, public curve.Point, private curve.Scalar, gen curve.Point) *Proof {
+func NewProof(rand io.Reader, hash *hash.Hash, public curve.Point, private curve.Scalar, gen curve.Point) *Proof {
 	group := private.Curve()
 
-	a := NewRandomness(rand.Reader, group, gen)
+	a := NewRandomness(rand, group, gen)
 	z := a.Prove(hash, public, private, gen)
 	return &Proof{
 		C: *a.Commitment(),
@@ -85,6 +86,38 @@ func (r *Randomness) Commitment() *Commitment {
 	return &r.commitment
 }
 
+type randomnessMarshal struct {
+	A curve.Scalar
+	C curve.Point
+}
+
+// EmptyRandomness returns an empty Randomness over the given group, ready for unmarshalling.
+func EmptyRandomness(group curve.Curve) *Randomness {
+	return &Randomness{
+		a:          group.NewScalar(),
+		commitment: Commitment{C: group.NewPoint()},
+	}
+}
+
+// MarshalBinary encodes the randomness a along with its commitment, so that the proof can be completed later.
+func (r *Randomness) MarshalBinary() ([]byte, error) {
+	return cbor.Marshal(&randomnessMarshal{A: r.a, C: r.commitment.C})
+}
+
+// UnmarshalBinary decodes a Randomness, and must be called on the output of EmptyRandomness.
+func (r *Randomness) UnmarshalBinary(data []byte) error {
+	if r == nil || r.a == nil || r.commitment.C == nil {
+		return errors.New("zksch: Randomness must be initialized using EmptyRandomness")
+	}
+	m := &randomnessMarshal{A: r.a, C: r.commitment.C}
+	if err := cbor.Unmarshal(data, m); err != nil {
+		return err
+	}
+	r.a = m.A
+	r.commitment.C = m.C
+	return nil
+}
+
 // Verify checks that Response•G = Commitment + H(..., Commitment, public)•Public.
 func (z *Response) Verify(hash *hash.Hash, public curve.Point, commitment *Commitment, gen curve.Point) bool {
 	if gen == nil {
@@ -43,3 +43,19 @@ func TestSchFail(t *testing.T) {
 	proof := a.Prove(hash.New(), X, x, nil)
 	assert.False(t, proof.Verify(hash.New(), X, a.Commitment(), nil), "proof should not accept identity point")
 }
+
+func TestRandomnessMarshal(t *testing.T) {
+	group := curve.Secp256k1{}
+
+	a := NewRandomness(rand.Reader, group, nil)
+	x, X := sample.ScalarPointPair(rand.Reader, group)
+
+	data, err := a.MarshalBinary()
+	require.NoError(t, err, "failed to marshal randomness")
+	a2 := EmptyRandomness(group)
+	require.NoError(t, a2.UnmarshalBinary(data), "failed to unmarshal randomness")
+	assert.True(t, a.Commitment().C.Equal(a2.Commitment().C))
+
+	proof := a2.Prove(hash.New(), X, x, nil)
+	assert.True(t, proof.Verify(hash.New(), X, a.Commitment(), nil))
+}
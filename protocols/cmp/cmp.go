@@ -3,6 +3,7 @@ package cmp
 import (
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa/presignstore"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
 	"github.com/taurusgroup/multi-party-sig/pkg/pool"
@@ -10,6 +11,7 @@ import (
 	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
 	"github.com/taurusgroup/multi-party-sig/protocols/cmp/keygen"
 	"github.com/taurusgroup/multi-party-sig/protocols/cmp/presign"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/reshare"
 	"github.com/taurusgroup/multi-party-sig/protocols/cmp/sign"
 )
 
@@ -32,7 +34,7 @@ func EmptyConfig(group curve.Curve) *Config {
 //
 // For better performance, a `pool.Pool` can be provided in order to parallelize certain steps of the protocol.
 // Returns *cmp.Config if successful.
-func Keygen(group curve.Curve, selfID party.ID, participants []party.ID, threshold int, pl *pool.Pool) protocol.StartFunc {
+func Keygen(group curve.Curve, selfID party.ID, participants []party.ID, threshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
 	info := round.Info{
 		ProtocolID:       "cmp/keygen-threshold",
 		FinalRoundNumber: keygen.Rounds,
@@ -41,13 +43,14 @@ func Keygen(group curve.Curve, selfID party.ID, participants []party.ID, thresho
 		Threshold:        threshold,
 		Group:            group,
 	}
+	info.Apply(opts...)
 	return keygen.Start(info, pl, nil)
 }
 
 // Refresh allows the parties to refresh all existing cryptographic keys from a previously generated Config.
 // The group's ECDSA public key remains the same, but any previous shares are rendered useless.
 // Returns *cmp.Config if successful.
-func Refresh(config *Config, pl *pool.Pool) protocol.StartFunc {
+func Refresh(config *Config, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
 	info := round.Info{
 		ProtocolID:       "cmp/refresh-threshold",
 		FinalRoundNumber: keygen.Rounds,
@@ -56,13 +59,57 @@ func Refresh(config *Config, pl *pool.Pool) protocol.StartFunc {
 		Threshold:        config.Threshold,
 		Group:            config.Group,
 	}
+	info.Apply(opts...)
 	return keygen.Start(info, pl, config)
 }
 
+// Reshare transfers the ECDSA key of a previously generated Config to `newParties`, with threshold `newThreshold`.
+// The `oldSigners` must be able to reconstruct the key, and deal a new sharing of their shares to `newParties`.
+// Only the parties in `oldSigners` ∪ `newParties` run the protocol, and those of `newParties` without a share of the key use ReshareJoin.
+// The group's ECDSA public key and chain key remain the same, so the previous shares remain valid:
+// all holders of the previous Config must delete it once the protocol has completed,
+// since any Threshold+1 of them could otherwise still sign.
+// Returns *cmp.Config if the party is part of `newParties`, and the public key as a curve.Point otherwise.
+func Reshare(config *Config, oldSigners, newParties []party.ID, newThreshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return reshare.StartReshare(config, oldSigners, newParties, newThreshold, pl, opts...)
+}
+
+// ReshareJoin is used by a party from `newParties` which does not hold a share of the key, to take part in a Reshare.
+// `publicKey` is the ECDSA public key being reshared, and must be obtained from a trusted source.
+// Returns *cmp.Config if successful.
+func ReshareJoin(group curve.Curve, selfID party.ID, publicKey curve.Point, oldSigners, newParties []party.ID, newThreshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return reshare.StartReshareJoin(group, selfID, publicKey, oldSigners, newParties, newThreshold, pl, opts...)
+}
+
+// Import shares an existing ECDSA private key `secret` among `participants`, with the given `threshold`.
+// It is called by the `selfID` party holding the key, which acts as a dealer and does not need to be a participant.
+// The participants generate their auxiliary parameters as in Keygen, and verify the sharing of the key.
+// `chainKey` is the chaining key of the new Config, and is sampled at random if nil.
+// The dealer must delete the private key after the protocol has completed.
+// Returns *cmp.Config if the dealer is a participant, and the public key as a curve.Point otherwise.
+func Import(group curve.Curve, selfID party.ID, secret curve.Scalar, chainKey []byte, participants []party.ID, threshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return reshare.StartImport(group, selfID, secret, chainKey, participants, threshold, pl, opts...)
+}
+
+// ImportJoin is used by the participants of an Import which do not hold the private key.
+// `publicKey` is the ECDSA public key of the imported key, and must be obtained from a trusted source.
+// Returns *cmp.Config if successful.
+func ImportJoin(group curve.Curve, selfID, dealer party.ID, publicKey curve.Point, participants []party.ID, threshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return reshare.StartImportJoin(group, selfID, dealer, publicKey, participants, threshold, pl, opts...)
+}
+
 // Sign generates an ECDSA signature for `messageHash` among the given `signers`.
 // Returns *ecdsa.Signature if successful.
-func Sign(config *Config, signers []party.ID, messageHash []byte, pl *pool.Pool) protocol.StartFunc {
-	return sign.StartSign(config, signers, messageHash, pl)
+func Sign(config *Config, signers []party.ID, messageHash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartSign(config, signers, messageHash, pl, opts...)
+}
+
+// SignBatch generates an ECDSA signature for each of the `messageHashes` among the given `signers`,
+// in a single execution which requires as many rounds and messages as Sign.
+// If a signature cannot be generated, the execution aborts with the culprits identified for that signature.
+// Returns []*ecdsa.Signature if successful, in the same order as `messageHashes`.
+func SignBatch(config *Config, signers []party.ID, messageHashes [][]byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartSignBatch(config, signers, messageHashes, pl, opts...)
 }
 
 // Presign generates a preprocessed signature that does not depend on the message being signed.
@@ -70,12 +117,31 @@ func Sign(config *Config, signers []party.ID, messageHash []byte, pl *pool.Pool)
 // to produce a full signature with the PresignOnline protocol.
 // Note: the PreSignatures should be treated as secret key material.
 // Returns *ecdsa.PreSignature if successful.
-func Presign(config *Config, signers []party.ID, pl *pool.Pool) protocol.StartFunc {
-	return presign.StartPresign(config, signers, nil, pl)
+func Presign(config *Config, signers []party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return presign.StartPresign(config, signers, nil, pl, opts...)
+}
+
+// PresignBatch generates `count` preprocessed signatures in a single protocol execution,
+// which requires as many rounds and messages as Presign.
+// If a presignature cannot be generated, the execution aborts with the culprits identified for that presignature.
+// Note: the PreSignatures should be treated as secret key material.
+// Returns []*ecdsa.PreSignature if successful.
+func PresignBatch(config *Config, signers []party.ID, count int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return presign.StartPresignBatch(config, signers, count, pl, opts...)
 }
 
 // PresignOnline efficiently generates an ECDSA signature for `messageHash` given a preprocessed `PreSignature`.
+//
+// This does not enforce that the PreSignature is used only once, and signing two messages with the same
+// PreSignature leaks the secret key. Callers must discard it before calling this, or use PresignOnlineFromStore.
+// Returns *ecdsa.Signature if successful.
+func PresignOnline(config *Config, preSignature *ecdsa.PreSignature, messageHash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return presign.StartPresignOnline(config, preSignature, messageHash, pl, opts...)
+}
+
+// PresignOnlineFromStore is like PresignOnline, but uses the PreSignature with the given ID from `store`.
+// The PreSignature is consumed before any share of the signature is sent, so that it can never be used twice.
 // Returns *ecdsa.Signature if successful.
-func PresignOnline(config *Config, preSignature *ecdsa.PreSignature, messageHash []byte, pl *pool.Pool) protocol.StartFunc {
-	return presign.StartPresignOnline(config, preSignature, messageHash, pl)
+func PresignOnlineFromStore(config *Config, store presignstore.Store, preSignatureID []byte, messageHash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return presign.StartPresignOnlineFromStore(config, store, preSignatureID, messageHash, pl, opts...)
 }
@@ -3,11 +3,14 @@ package cmp
 import (
 	"crypto/rand"
 	"math"
+	mrand "math/rand"
 	"sync"
 	"testing"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/internal/test"
 	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
@@ -161,3 +164,82 @@ func TestStart(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMarshalP256(t *testing.T) {
+	group := curve.P256{}
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+	configs, partyIDs := test.GenerateConfig(group, 2, 1, rand.Reader, pl)
+
+	c := configs[partyIDs[0]]
+	data, err := cbor.Marshal(c)
+	require.NoError(t, err)
+
+	c2 := EmptyConfig(group)
+	require.NoError(t, cbor.Unmarshal(data, c2))
+	assert.True(t, c.PublicPoint().Equal(c2.PublicPoint()))
+	assert.True(t, c.ECDSA.Equal(c2.ECDSA))
+}
+
+// runRounds runs the protocol started by each of starts until completion, without a network.
+func runRounds(t *testing.T, starts []protocol.StartFunc) []round.Session {
+	rounds := make([]round.Session, 0, len(starts))
+	for _, start := range starts {
+		r, err := start(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+	return rounds
+}
+
+func TestDeterministic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates Paillier keys without a pool")
+	}
+	group := curve.Secp256k1{}
+	partyIDs := test.PartyIDs(2)
+	message := []byte("hello, world")
+
+	run := func() (map[party.ID]*Config, *ecdsa.Signature) {
+		starts := make([]protocol.StartFunc, 0, len(partyIDs))
+		for i, id := range partyIDs {
+			rand := mrand.New(mrand.NewSource(int64(i)))
+			starts = append(starts, Keygen(group, id, partyIDs, 1, nil, protocol.WithRand(rand)))
+		}
+		configs := make(map[party.ID]*Config, len(partyIDs))
+		for _, r := range runRounds(t, starts) {
+			require.IsType(t, &round.Output{}, r)
+			configs[r.SelfID()] = r.(*round.Output).Result.(*Config)
+		}
+
+		starts = starts[:0]
+		for i, id := range partyIDs {
+			rand := mrand.New(mrand.NewSource(int64(len(partyIDs) + i)))
+			starts = append(starts, Sign(configs[id], partyIDs, message, nil, protocol.WithRand(rand)))
+		}
+		rounds := runRounds(t, starts)
+		require.IsType(t, &round.Output{}, rounds[0])
+		signature := rounds[0].(*round.Output).Result.(*ecdsa.Signature)
+		require.True(t, signature.Verify(configs[partyIDs[0]].PublicPoint(), message))
+		return configs, signature
+	}
+
+	firstConfigs, firstSignature := run()
+	secondConfigs, secondSignature := run()
+	for _, id := range partyIDs {
+		first, err := firstConfigs[id].MarshalBinary()
+		require.NoError(t, err)
+		second, err := secondConfigs[id].MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, first, second, "different config for %s", id)
+	}
+	assert.True(t, firstSignature.R.Equal(secondSignature.R), "different signature nonce")
+	assert.True(t, firstSignature.S.Equal(secondSignature.S), "different signature")
+}
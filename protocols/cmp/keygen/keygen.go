@@ -1,7 +1,6 @@
 package keygen
 
 import (
-	"crypto/rand"
 	"fmt"
 
 	"github.com/taurusgroup/multi-party-sig/internal/round"
@@ -18,6 +17,10 @@ const Rounds round.Number = 5
 
 func Start(info round.Info, pl *pool.Pool, c *config.Config) protocol.StartFunc {
 	return func(sessionID []byte) (_ round.Session, err error) {
+		if info.Group.NewBasePoint().XScalar() == nil {
+			return nil, fmt.Errorf("keygen: group %s does not support ECDSA", info.Group.Name())
+		}
+
 		var helper *round.Helper
 		if c == nil {
 			helper, err = round.NewSession(info, sessionID, pl)
@@ -40,13 +43,13 @@ func Start(info round.Info, pl *pool.Pool, c *config.Config) protocol.StartFunc
 				PreviousSecretECDSA:       c.ECDSA,
 				PreviousPublicSharesECDSA: PublicSharesECDSA,
 				PreviousChainKey:          c.ChainKey,
-				VSSSecret:                 polynomial.NewPolynomial(group, helper.Threshold(), group.NewScalar()), // fᵢ(X) deg(fᵢ) = t, fᵢ(0) = 0
+				VSSSecret:                 polynomial.NewPolynomial(helper.Rand(), group, helper.Threshold(), group.NewScalar()), // fᵢ(X) deg(fᵢ) = t, fᵢ(0) = 0
 			}, nil
 		}
 
 		// sample fᵢ(X) deg(fᵢ) = t, fᵢ(0) = secretᵢ
-		VSSConstant := sample.Scalar(rand.Reader, group)
-		VSSSecret := polynomial.NewPolynomial(group, helper.Threshold(), VSSConstant)
+		VSSConstant := sample.Scalar(helper.Rand(), group)
+		VSSSecret := polynomial.NewPolynomial(helper.Rand(), group, helper.Threshold(), VSSConstant)
 		return &round1{
 			Helper:    helper,
 			VSSSecret: VSSSecret,
@@ -119,3 +119,59 @@ func TestRefresh(t *testing.T) {
 	}
 	checkOutput(t, rounds)
 }
+
+func TestKeygenSnapshot(t *testing.T) {
+	N := 2
+	partyIDs := test.PartyIDs(N)
+
+	infos := make([]round.Info, 0, N)
+	rounds := make([]round.Session, 0, N)
+	for _, partyID := range partyIDs {
+		info := round.Info{
+			ProtocolID:       "cmp/keygen-test",
+			FinalRoundNumber: Rounds,
+			SelfID:           partyID,
+			PartyIDs:         partyIDs,
+			Threshold:        N - 1,
+			Group:            group,
+		}
+		r, err := Start(info, nil, nil)([]byte("session"))
+		require.NoError(t, err, "round creation should not result in an error")
+		infos = append(infos, info)
+		rounds = append(rounds, r)
+	}
+
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+		// restart all parties from a snapshot of their current round
+		for i, r := range rounds {
+			data, err := round.MarshalSnapshot(r)
+			require.NoError(t, err, "failed to snapshot round", r.Number())
+			first, err := Start(infos[i], nil, nil)([]byte("session"))
+			require.NoError(t, err)
+			restored, err := round.UnmarshalSnapshot(first, data)
+			require.NoError(t, err, "failed to restore round", r.Number())
+			assert.Equal(t, r.Hash().Sum(), restored.Hash().Sum(), "hash state is different")
+			rounds[i] = restored
+		}
+	}
+	checkOutput(t, rounds)
+}
+
+func TestKeygenUnsupportedGroup(t *testing.T) {
+	partyIDs := test.PartyIDs(2)
+	info := round.Info{
+		ProtocolID:       "cmp/keygen-test",
+		FinalRoundNumber: Rounds,
+		SelfID:           partyIDs[0],
+		PartyIDs:         partyIDs,
+		Threshold:        1,
+		Group:            curve.Edwards25519{},
+	}
+	_, err := Start(info, nil, nil)(nil)
+	require.ErrorContains(t, err, "does not support ECDSA")
+}
@@ -1,7 +1,6 @@
 package keygen
 
 import (
-	"crypto/rand"
 	"errors"
 
 	"github.com/taurusgroup/multi-party-sig/internal/round"
@@ -62,11 +61,11 @@ func (r *round1) StoreMessage(round.Message) error { return nil }
 // - commit to message.
 func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
 	// generate Paillier and Pedersen
-	PaillierSecret := paillier.NewSecretKey(nil)
+	PaillierSecret := paillier.NewSecretKey(r.Rand(), nil)
 	SelfPaillierPublic := PaillierSecret.PublicKey
-	SelfPedersenPublic, PedersenSecret := PaillierSecret.GeneratePedersen()
+	SelfPedersenPublic, PedersenSecret := PaillierSecret.GeneratePedersen(r.Rand())
 
-	ElGamalSecret, ElGamalPublic := sample.ScalarPointPair(rand.Reader, r.Group())
+	ElGamalSecret, ElGamalPublic := sample.ScalarPointPair(r.Rand(), r.Group())
 
 	// save our own share already so we are consistent with what we receive from others
 	SelfShare := r.VSSSecret.Evaluate(r.SelfID().Scalar(r.Group()))
@@ -75,20 +74,20 @@ func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
 	SelfVSSPolynomial := polynomial.NewPolynomialExponent(r.VSSSecret)
 
 	// generate Schnorr randomness
-	SchnorrRand := zksch.NewRandomness(rand.Reader, r.Group(), nil)
+	SchnorrRand := zksch.NewRandomness(r.Rand(), r.Group(), nil)
 
 	// Sample RIDᵢ
-	SelfRID, err := types.NewRID(rand.Reader)
+	SelfRID, err := types.NewRID(r.Rand())
 	if err != nil {
 		return r, errors.New("failed to sample Rho")
 	}
-	chainKey, err := types.NewRID(rand.Reader)
+	chainKey, err := types.NewRID(r.Rand())
 	if err != nil {
 		return r, errors.New("failed to sample c")
 	}
 
 	// commit to data in message 2
-	SelfCommitment, Decommitment, err := r.HashForID(r.SelfID()).Commit(
+	SelfCommitment, Decommitment, err := r.HashForID(r.SelfID()).Commit(r.Rand(),
 		SelfRID, chainKey, SelfVSSPolynomial, SchnorrRand.Commitment(), ElGamalPublic,
 		SelfPedersenPublic.N(), SelfPedersenPublic.S(), SelfPedersenPublic.T())
 	if err != nil {
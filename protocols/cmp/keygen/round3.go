@@ -155,14 +155,14 @@ func (r *round3) Finalize(out chan<- *round.Message) (round.Session, error) {
 	_ = h.WriteAny(rid, r.SelfID())
 
 	// Prove N is a blum prime with zkmod
-	mod := zkmod.NewProof(h.Clone(), zkmod.Private{
+	mod := zkmod.NewProof(r.Rand(), h.Clone(), zkmod.Private{
 		P:   r.PaillierSecret.P(),
 		Q:   r.PaillierSecret.Q(),
 		Phi: r.PaillierSecret.Phi(),
 	}, zkmod.Public{N: r.PaillierPublic[r.SelfID()].N()}, r.Pool)
 
 	// prove s, t are correct as aux parameters with zkprm
-	prm := zkprm.NewProof(zkprm.Private{
+	prm := zkprm.NewProof(r.Rand(), zkprm.Private{
 		Lambda: r.PedersenSecret,
 		Phi:    r.PaillierSecret.Phi(),
 		P:      r.PaillierSecret.P(),
@@ -180,7 +180,7 @@ func (r *round3) Finalize(out chan<- *round.Message) (round.Session, error) {
 	for _, j := range r.OtherPartyIDs() {
 
 		// Prove that the factors of N are relatively large
-		fac := zkfac.NewProof(zkfac.Private{P: r.PaillierSecret.P(), Q: r.PaillierSecret.Q()}, h.Clone(), zkfac.Public{
+		fac := zkfac.NewProof(r.Rand(), zkfac.Private{P: r.PaillierSecret.P(), Q: r.PaillierSecret.Q()}, h.Clone(), zkfac.Public{
 			N:   r.PaillierPublic[r.SelfID()].N(),
 			Aux: r.Pedersen[j],
 		})
@@ -188,7 +188,7 @@ func (r *round3) Finalize(out chan<- *round.Message) (round.Session, error) {
 		// compute fᵢ(j)
 		share := r.VSSSecret.Evaluate(j.Scalar(r.Group()))
 		// Encrypt share
-		C, _ := r.PaillierPublic[j].Enc(curve.MakeInt(share))
+		C, _ := r.PaillierPublic[j].Enc(r.Rand(), curve.MakeInt(share))
 
 		err := r.SendMessage(out, &message4{
 			Share: C,
@@ -0,0 +1,264 @@
+package keygen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/saferith"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/arith"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pedersen"
+	zksch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+)
+
+var (
+	_ round.Restorer    = (*round1)(nil)
+	_ round.Snapshotter = (*round2)(nil)
+	_ round.Snapshotter = (*round3)(nil)
+	_ round.Snapshotter = (*round4)(nil)
+	_ round.Snapshotter = (*round5)(nil)
+)
+
+// snapshot contains the state of the protocol from round2 onwards.
+// Fields which are set in later rounds are empty in the snapshots of earlier rounds.
+type snapshot struct {
+	// round1
+	VSSSecret []byte
+
+	// round2
+	VSSPolynomials map[party.ID][]byte
+	Commitments    map[party.ID]hash.Commitment
+	RIDs           map[party.ID]types.RID
+	ChainKeys      map[party.ID]types.RID
+	ShareReceived  map[party.ID][]byte
+	ElGamalPublic  map[party.ID][]byte
+	Pedersen       map[party.ID]*pedersenSnapshot
+	ElGamalSecret  []byte
+	P, Q           *saferith.Nat
+	PedersenSecret *saferith.Nat
+	SchnorrRand    []byte
+	Decommitment   hash.Decommitment
+
+	// round3
+	SchnorrCommitments map[party.ID][]byte
+
+	// round4
+	RID, ChainKey types.RID
+
+	// round5
+	UpdatedConfig []byte
+}
+
+// pedersenSnapshot contains the Paillier and Pedersen parameters (Nⱼ,Sⱼ,Tⱼ) of a party.
+type pedersenSnapshot struct {
+	N    *saferith.Modulus
+	S, T *saferith.Nat
+}
+
+// Snapshot implements round.Snapshotter.
+func (r *round2) Snapshot() ([]byte, error) { return marshalSnapshot(r, nil, nil, nil) }
+
+// Snapshot implements round.Snapshotter.
+func (r *round3) Snapshot() ([]byte, error) { return marshalSnapshot(r.round2, r, nil, nil) }
+
+// Snapshot implements round.Snapshotter.
+func (r *round4) Snapshot() ([]byte, error) { return marshalSnapshot(r.round2, r.round3, r, nil) }
+
+// Snapshot implements round.Snapshotter.
+func (r *round5) Snapshot() ([]byte, error) {
+	return marshalSnapshot(r.round2, r.round3, r.round4, r)
+}
+
+// marshalSnapshot encodes the state of the rounds which are not nil.
+func marshalSnapshot(r2 *round2, r3 *round3, r4 *round4, r5 *round5) ([]byte, error) {
+	var err error
+	s := &snapshot{
+		Commitments:  r2.Commitments,
+		RIDs:         r2.RIDs,
+		ChainKeys:    r2.ChainKeys,
+		P:            r2.PaillierSecret.P(),
+		Q:            r2.PaillierSecret.Q(),
+		Decommitment: r2.Decommitment,
+
+		PedersenSecret: r2.PedersenSecret,
+	}
+	if s.VSSSecret, err = r2.VSSSecret.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if s.ElGamalSecret, err = r2.ElGamalSecret.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if s.SchnorrRand, err = r2.SchnorrRand.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	s.VSSPolynomials = make(map[party.ID][]byte, len(r2.VSSPolynomials))
+	for j, f := range r2.VSSPolynomials {
+		if s.VSSPolynomials[j], err = f.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if s.ShareReceived, err = round.MarshalScalars(r2.ShareReceived); err != nil {
+		return nil, err
+	}
+	if s.ElGamalPublic, err = round.MarshalPoints(r2.ElGamalPublic); err != nil {
+		return nil, err
+	}
+	s.Pedersen = make(map[party.ID]*pedersenSnapshot, len(r2.Pedersen))
+	for j, ped := range r2.Pedersen {
+		s.Pedersen[j] = &pedersenSnapshot{N: ped.N(), S: ped.S(), T: ped.T()}
+	}
+
+	if r3 != nil {
+		s.SchnorrCommitments = make(map[party.ID][]byte, len(r3.SchnorrCommitments))
+		for j, commitment := range r3.SchnorrCommitments {
+			if s.SchnorrCommitments[j], err = commitment.C.MarshalBinary(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r4 != nil {
+		s.RID, s.ChainKey = r4.RID, r4.ChainKey
+	}
+	if r5 != nil {
+		if s.UpdatedConfig, err = r5.UpdatedConfig.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	return cbor.Marshal(s)
+}
+
+// Restore implements round.Restorer.
+func (r *round1) Restore(number round.Number, data []byte) (round.Session, error) {
+	if number < 2 || number > Rounds {
+		return nil, fmt.Errorf("keygen: invalid round number %d", number)
+	}
+	s := &snapshot{}
+	if err := cbor.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("keygen: %w", err)
+	}
+	group := r.Group()
+
+	VSSSecret := polynomial.EmptyPolynomial(group)
+	if err := VSSSecret.UnmarshalBinary(s.VSSSecret); err != nil {
+		return nil, fmt.Errorf("keygen: vss secret: %w", err)
+	}
+	r.VSSSecret = VSSSecret
+
+	if err := paillier.ValidatePrime(s.P); err != nil {
+		return nil, fmt.Errorf("keygen: prime P: %w", err)
+	}
+	if err := paillier.ValidatePrime(s.Q); err != nil {
+		return nil, fmt.Errorf("keygen: prime Q: %w", err)
+	}
+	if s.PedersenSecret == nil {
+		return nil, errors.New("keygen: missing pedersen secret")
+	}
+	PaillierSecret := paillier.NewSecretKeyFromPrimes(s.P, s.Q)
+	ElGamalSecret := group.NewScalar()
+	if err := ElGamalSecret.UnmarshalBinary(s.ElGamalSecret); err != nil {
+		return nil, fmt.Errorf("keygen: elgamal secret: %w", err)
+	}
+	SchnorrRand := zksch.EmptyRandomness(group)
+	if err := SchnorrRand.UnmarshalBinary(s.SchnorrRand); err != nil {
+		return nil, fmt.Errorf("keygen: schnorr randomness: %w", err)
+	}
+	ShareReceived, err := round.UnmarshalScalars(group, s.ShareReceived)
+	if err != nil {
+		return nil, fmt.Errorf("keygen: shares: %w", err)
+	}
+	ElGamalPublic, err := round.UnmarshalPoints(group, s.ElGamalPublic)
+	if err != nil {
+		return nil, fmt.Errorf("keygen: elgamal public: %w", err)
+	}
+
+	r2 := &round2{
+		round1:         r,
+		VSSPolynomials: make(map[party.ID]*polynomial.Exponent, len(s.VSSPolynomials)),
+		Commitments:    s.Commitments,
+		RIDs:           s.RIDs,
+		ChainKeys:      s.ChainKeys,
+		ShareReceived:  ShareReceived,
+		ElGamalPublic:  ElGamalPublic,
+		PaillierPublic: make(map[party.ID]*paillier.PublicKey, len(s.Pedersen)),
+		Pedersen:       make(map[party.ID]*pedersen.Parameters, len(s.Pedersen)),
+		ElGamalSecret:  ElGamalSecret,
+		PaillierSecret: PaillierSecret,
+		PedersenSecret: s.PedersenSecret,
+		SchnorrRand:    SchnorrRand,
+		Decommitment:   s.Decommitment,
+	}
+	if r2.Commitments == nil {
+		r2.Commitments = map[party.ID]hash.Commitment{}
+	}
+	if r2.RIDs == nil || r2.ChainKeys == nil {
+		return nil, errors.New("keygen: missing rid")
+	}
+	for j, data := range s.VSSPolynomials {
+		f := polynomial.EmptyExponent(group)
+		if err := f.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("keygen: vss polynomial %s: %w", j, err)
+		}
+		r2.VSSPolynomials[j] = f
+	}
+	for j, ped := range s.Pedersen {
+		if ped == nil || ped.N == nil || ped.S == nil || ped.T == nil {
+			return nil, fmt.Errorf("keygen: pedersen %s: %w", j, round.ErrNilFields)
+		}
+		if j == r.SelfID() {
+			r2.PaillierPublic[j] = PaillierSecret.PublicKey
+			r2.Pedersen[j] = pedersen.New(PaillierSecret.Modulus(), ped.S, ped.T)
+			continue
+		}
+		r2.PaillierPublic[j] = paillier.NewPublicKey(ped.N)
+		r2.Pedersen[j] = pedersen.New(arith.ModulusFromN(ped.N), ped.S, ped.T)
+	}
+	if number == 2 {
+		return r2, nil
+	}
+
+	r3 := &round3{
+		round2:             r2,
+		SchnorrCommitments: make(map[party.ID]*zksch.Commitment, len(s.SchnorrCommitments)),
+	}
+	for j, data := range s.SchnorrCommitments {
+		commitment := zksch.EmptyCommitment(group)
+		if err := commitment.C.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("keygen: schnorr commitment %s: %w", j, err)
+		}
+		r3.SchnorrCommitments[j] = commitment
+	}
+	if number == 3 {
+		return r3, nil
+	}
+
+	if err := s.RID.Validate(); err != nil {
+		return nil, fmt.Errorf("keygen: rid: %w", err)
+	}
+	if err := s.ChainKey.Validate(); err != nil {
+		return nil, fmt.Errorf("keygen: chain key: %w", err)
+	}
+	r4 := &round4{
+		round3:   r3,
+		RID:      s.RID,
+		ChainKey: s.ChainKey,
+	}
+	if number == 4 {
+		return r4, nil
+	}
+
+	UpdatedConfig := config.EmptyConfig(group)
+	if err := UpdatedConfig.UnmarshalBinary(s.UpdatedConfig); err != nil {
+		return nil, fmt.Errorf("keygen: %w", err)
+	}
+	return &round5{
+		round4:        r4,
+		UpdatedConfig: UpdatedConfig,
+	}, nil
+}
@@ -4,12 +4,9 @@ import (
 	"errors"
 
 	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/internal/abort"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
-	"github.com/taurusgroup/multi-party-sig/pkg/hash"
-	"github.com/taurusgroup/multi-party-sig/pkg/math/arith"
-	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
-	zknth "github.com/taurusgroup/multi-party-sig/pkg/zk/nth"
 )
 
 var _ round.Round = (*abort1)(nil)
@@ -26,8 +23,8 @@ type broadcastAbort1 struct {
 	round.NormalBroadcastContent
 	// GammaShare = γᵢ
 	GammaShare  *saferith.Int
-	KProof      *abortNth
-	DeltaProofs map[party.ID]*abortNth
+	KProof      *abort.Nth
+	DeltaProofs map[party.ID]*abort.Nth
 }
 
 // StoreBroadcastMessage implements round.BroadcastRound.
@@ -57,7 +54,7 @@ func (r *abort1) StoreBroadcastMessage(msg round.Message) error {
 	}
 
 	for id, deltaProof := range body.DeltaProofs {
-		if !deltaProof.Verify(r.HashForID(from), public, r.DeltaCiphertext[from][id]) {
+		if !deltaProof.Verify(r.HashForID(from), public, r.DeltaCiphertext[id][from]) {
 			return errors.New("failed to validate Delta MtA Nth proof")
 		}
 	}
@@ -93,6 +90,9 @@ func (r *abort1) Finalize(chan<- *round.Message) (round.Session, error) {
 			culprits = append(culprits, j)
 		}
 	}
+	if len(culprits) == 0 {
+		return r.AbortRound(errors.New("abort1: failed to identify a culprit")), nil
+	}
 	return r.AbortRound(errors.New("abort1: detected culprit"), culprits...), nil
 }
 
@@ -107,51 +107,3 @@ func (r *abort1) BroadcastContent() round.BroadcastContent { return &broadcastAb
 
 // Number implements round.Round.
 func (abort1) Number() round.Number { return 7 }
-
-// abortNth for a given ciphertext c = end(m,r) contains
-// - the message m,
-// - the "hidden" nonce r^N % N^2, equal to enc(0,r)
-// - a proof of knowledge of r
-type abortNth struct {
-	Plaintext *saferith.Int
-	Nonce     *saferith.Nat
-	Proof     *zknth.Proof
-}
-
-// proveNth decypts the message and the nonce contained in the ciphertext c, using the private key.
-// Returns an abortNth proving knowledge of the nonce
-func proveNth(hash *hash.Hash, paillierSecret *paillier.SecretKey, c *paillier.Ciphertext) *abortNth {
-	NSquared := paillierSecret.ModulusSquared()
-	N := paillierSecret.Modulus()
-	deltaShareAlpha, deltaNonce, _ := paillierSecret.DecWithRandomness(c)
-	deltaNonceHidden := NSquared.Exp(deltaNonce, N.Nat())
-	proof := zknth.NewProof(hash, zknth.Public{
-		N: paillierSecret.PublicKey,
-		R: deltaNonceHidden,
-	}, zknth.Private{Rho: deltaNonce})
-	return &abortNth{
-		Plaintext: deltaShareAlpha,
-		Nonce:     deltaNonceHidden,
-		Proof:     proof,
-	}
-}
-
-func (msg *abortNth) Verify(hash *hash.Hash, paillierPublic *paillier.PublicKey, c *paillier.Ciphertext) bool {
-	if msg == nil || !arith.IsValidNatModN(paillierPublic.ModulusSquared().Modulus, msg.Nonce) || msg.Plaintext == nil {
-		return false
-	}
-	one := new(saferith.Nat).SetUint64(1)
-	cExpected := c.Nat()
-	cActual := paillierPublic.EncWithNonce(msg.Plaintext, one).Nat()
-	cActual.ModMul(cActual, msg.Nonce, paillierPublic.ModulusSquared().Modulus)
-	if cExpected.Eq(cActual) != 1 {
-		return false
-	}
-	if !msg.Proof.Verify(hash, zknth.Public{
-		N: paillierPublic,
-		R: msg.Nonce,
-	}) {
-		return false
-	}
-	return true
-}
@@ -3,6 +3,7 @@ package presign
 import (
 	"errors"
 
+	"github.com/taurusgroup/multi-party-sig/internal/abort"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
@@ -25,8 +26,8 @@ type broadcastAbort2 struct {
 	// YHat = Ŷⱼ = bⱼ⋅Yⱼ
 	YHat      curve.Point
 	YHatProof *zklog.Proof
-	KProof    *abortNth
-	ChiProofs map[party.ID]*abortNth
+	KProof    *abort.Nth
+	ChiProofs map[party.ID]*abort.Nth
 }
 
 // StoreBroadcastMessage implements round.BroadcastRound.
@@ -59,8 +60,8 @@ func (r *abort2) StoreBroadcastMessage(msg round.Message) error {
 	}
 
 	for id, chiProof := range body.ChiProofs {
-		if !chiProof.Verify(r.HashForID(from), public, r.ChiCiphertext[from][id]) {
-			return errors.New("failed to validate Delta MtA Nth proof")
+		if !chiProof.Verify(r.HashForID(from), public, r.ChiCiphertext[id][from]) {
+			return errors.New("failed to validate Chi MtA Nth proof")
 		}
 	}
 	return nil
@@ -93,6 +94,9 @@ func (r *abort2) Finalize(chan<- *round.Message) (round.Session, error) {
 		}
 	}
 
+	if len(culprits) == 0 {
+		return r.AbortRound(errors.New("abort2: failed to identify a culprit")), nil
+	}
 	return r.AbortRound(errors.New("abort2: detected culprit"), culprits...), nil
 }
 
@@ -129,14 +129,18 @@ func TestRoundFail(t *testing.T) {
 			}
 			for {
 				err, done := test.Rounds(rounds, &testCase.r)
-				if err != nil || done {
-					if err != nil {
-						t.Log(err)
-					}
-					assert.Error(t, err, "round should terminate with error")
+				require.NoError(t, err, "failed to process round")
+				if done {
 					break
 				}
 			}
+			for _, r := range rounds {
+				require.IsType(t, &round.Abort{}, r, "round should terminate with an abort")
+				if r.SelfID() == "a" {
+					continue
+				}
+				assert.Equal(t, []party.ID{"a"}, r.(*round.Abort).Culprits, "a should be identified as the culprit")
+			}
 		})
 	}
 }
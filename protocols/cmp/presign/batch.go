@@ -0,0 +1,82 @@
+package presign
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+)
+
+const (
+	protocolBatchID = "cmp/presign-batch"
+	// protocolBatchRounds includes the round of abort2, which may follow the last offline round.
+	protocolBatchRounds round.Number = 8
+)
+
+// StartPresignBatch generates count presignatures in a single execution.
+//
+// Each presignature is generated by its own offline presigning execution, whose session is derived from the batch's
+// SSID and the index of the presignature. All executions advance together in a round.Batch, so that every round
+// sends a single message to each party, and the executions are finalized in parallel on pl.
+func StartPresignBatch(c *config.Config, signers []party.ID, count int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if c == nil {
+			return nil, errors.New("presign: config is nil")
+		}
+		if c.Group.NewBasePoint().XScalar() == nil {
+			return nil, fmt.Errorf("presign: group %s does not support ECDSA", c.Group.Name())
+		}
+		if count <= 0 {
+			return nil, fmt.Errorf("presign: invalid number of presignatures %d", count)
+		}
+
+		info := round.Info{
+			ProtocolID:       protocolBatchID,
+			FinalRoundNumber: protocolBatchRounds,
+			SelfID:           c.ID,
+			PartyIDs:         signers,
+			Threshold:        c.Threshold,
+			Group:            c.Group,
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, pl, c, hash.BytesWithDomain{
+			TheDomain: "Presignature Count",
+			Bytes:     binary.BigEndian.AppendUint64(nil, uint64(count)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("presign: %w", err)
+		}
+
+		if !c.CanSign(helper.PartyIDs()) {
+			return nil, errors.New("presign: signers is not a valid signing subset")
+		}
+
+		single := info
+		single.ProtocolID = protocolOfflineID
+		single.FinalRoundNumber = protocolOfflineRounds
+		helpers, err := round.NewBatchHelpers(helper, single, count)
+		if err != nil {
+			return nil, fmt.Errorf("presign: %w", err)
+		}
+		sessions := make([]round.Session, count)
+		for i, h := range helpers {
+			sessions[i] = newPresign1(c, h, nil, nil)
+		}
+
+		return round.NewBatch(helper, sessions, func(results []interface{}) interface{} {
+			preSignatures := make([]*ecdsa.PreSignature, len(results))
+			for i, result := range results {
+				preSignatures[i] = result.(*ecdsa.PreSignature)
+			}
+			return preSignatures
+		}), nil
+	}
+}
@@ -0,0 +1,127 @@
+package presign
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cronokirby/saferith"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+)
+
+func startBatch(t *testing.T, count int) []round.Session {
+	rounds := make([]round.Session, 0, N)
+	for _, c := range configs {
+		pl := pool.NewPool(1)
+		t.Cleanup(pl.TearDown)
+		r, err := StartPresignBatch(c, partyIDs, count, pl)(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+	return rounds
+}
+
+func TestBatch(t *testing.T) {
+	const count = 2
+	rounds := startBatch(t, count)
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+
+	preSignatures := make(map[party.ID][]*ecdsa.PreSignature, N)
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		result, ok := r.(*round.Output).Result.([]*ecdsa.PreSignature)
+		require.True(t, ok, "result should be []*ecdsa.PreSignature")
+		require.Len(t, result, count)
+		preSignatures[r.SelfID()] = result
+	}
+
+	for i := 0; i < count; i++ {
+		R := preSignatures[partyIDs[0]][i].R
+		for j := 0; j < i; j++ {
+			assert.False(t, R.Equal(preSignatures[partyIDs[0]][j].R), "presignatures should be distinct")
+		}
+		shares := make(map[party.ID]ecdsa.SignatureShare, N)
+		for _, id := range partyIDs {
+			preSignature := preSignatures[id][i]
+			require.NoError(t, preSignature.Validate())
+			assert.True(t, R.Equal(preSignature.R))
+			shares[id] = preSignature.SignatureShare(messageHash)
+		}
+		signature := preSignatures[partyIDs[0]][i].Signature(shares)
+		assert.True(t, signature.Verify(configs[partyIDs[0]].PublicPoint(), messageHash))
+	}
+}
+
+func TestBatchFail(t *testing.T) {
+	tests := []struct {
+		name  string
+		index int
+		// fault is applied to the third round of party a in the session with the given index.
+		fault func(r *presign3)
+		// abort is the abort round in which the culprit is identified.
+		abort string
+	}{
+		{
+			// a adds one to its share of δ, so that the check of δ fails in round 6.
+			"delta share in batch[1]",
+			1,
+			func(r *presign3) {
+				r.DeltaShareBeta["b"] = new(saferith.Int).Add(r.DeltaShareBeta["b"], oneInt, -1)
+			},
+			"abort1",
+		},
+		{
+			// a uses an incorrect share of the secret key for χ, so that the check of S fails in round 7.
+			"secret share in batch[0]",
+			0,
+			func(r *presign3) {
+				r.SecretECDSA = r.Group().NewScalar().SetNat(oneNat).Add(r.SecretECDSA)
+			},
+			"abort2",
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			rounds := startBatch(t, 2)
+			// a sends honest proofs in the abort rounds, which identify it as the culprit.
+			rule := &TestRule{
+				AfterFinalize: func(rNext round.Session) {
+					b, ok := rNext.(*round.BroadcastBatch)
+					if !ok {
+						return
+					}
+					if r, ok := b.Sessions[testCase.index].(*presign3); ok {
+						testCase.fault(r)
+					}
+				},
+			}
+			for {
+				err, done := test.Rounds(rounds, rule)
+				require.NoError(t, err, "failed to process round")
+				if done {
+					break
+				}
+			}
+			for _, r := range rounds {
+				require.IsType(t, &round.Abort{}, r)
+				if r.SelfID() == "a" {
+					continue
+				}
+				abort := r.(*round.Abort)
+				assert.ErrorContains(t, abort.Err, fmt.Sprintf("batch[%d]: %s", testCase.index, testCase.abort))
+				assert.Equal(t, []party.ID{"a"}, abort.Culprits, "a should be identified as the culprit")
+			}
+		})
+	}
+}
@@ -4,10 +4,10 @@ import (
 	"errors"
 
 	"github.com/cronokirby/saferith"
-	"github.com/taurusgroup/multi-party-sig/internal/elgamal"
 	"github.com/taurusgroup/multi-party-sig/internal/mta"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/elgamal"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
@@ -140,11 +140,11 @@ func (r *presign2) Finalize(out chan<- *round.Message) (round.Session, error) {
 	mtaOuts := r.Pool.Parallelize(len(otherIDs), func(i int) interface{} {
 		j := otherIDs[i]
 
-		DeltaBeta, DeltaD, DeltaF, DeltaProof := mta.ProveAffP(r.Group(), r.HashForID(r.SelfID()),
+		DeltaBeta, DeltaD, DeltaF, DeltaProof := mta.ProveAffP(r.Rand(), r.Group(), r.HashForID(r.SelfID()),
 			r.GammaShare, r.G[r.SelfID()], r.GNonce, r.K[j],
 			r.SecretPaillier, r.Paillier[j], r.Pedersen[j])
 
-		ChiBeta, ChiD, ChiF, ChiProof := mta.ProveAffG(r.Group(), r.HashForID(r.SelfID()),
+		ChiBeta, ChiD, ChiF, ChiProof := mta.ProveAffG(r.Rand(), r.Group(), r.HashForID(r.SelfID()),
 			curve.MakeInt(r.SecretECDSA), r.ECDSA[r.SelfID()], r.K[j],
 			r.SecretPaillier, r.Paillier[j], r.Pedersen[j])
 
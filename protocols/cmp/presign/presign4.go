@@ -2,8 +2,8 @@ package presign
 
 import (
 	"github.com/cronokirby/saferith"
-	"github.com/taurusgroup/multi-party-sig/internal/elgamal"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/elgamal"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
 	zklogstar "github.com/taurusgroup/multi-party-sig/pkg/zk/logstar"
@@ -83,7 +83,7 @@ func (r *presign4) Finalize(out chan<- *round.Message) (round.Session, error) {
 	errors := r.Pool.Parallelize(len(otherIDs), func(i int) interface{} {
 		j := otherIDs[i]
 
-		proofLog := zklogstar.NewProof(r.Group(), r.HashForID(r.SelfID()), zklogstar.Public{
+		proofLog := zklogstar.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()), zklogstar.Public{
 			C:      r.G[r.SelfID()],
 			X:      BigGammaShare,
 			Prover: r.Paillier[r.SelfID()],
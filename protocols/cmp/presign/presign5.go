@@ -81,7 +81,7 @@ func (r *presign5) Finalize(out chan<- *round.Message) (round.Session, error) {
 	// Δᵢ = kᵢ⋅Γ
 	BigDeltaShare := r.KShare.Act(Gamma)
 
-	proofLog := zkelog.NewProof(r.Group(), r.HashForID(r.SelfID()),
+	proofLog := zkelog.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()),
 		zkelog.Public{
 			E:             r.ElGamalK[r.SelfID()],
 			ElGamalPublic: r.ElGamal[r.SelfID()],
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/internal/abort"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
@@ -92,14 +93,14 @@ func (r *presign6) Finalize(out chan<- *round.Message) (round.Session, error) {
 
 	// δ⋅G ?= ∑ⱼΔⱼ
 	if !BigDeltaActual.Equal(BigDeltaExpected) {
-		DeltaProofs := make(map[party.ID]*abortNth, r.N()-1)
+		DeltaProofs := make(map[party.ID]*abort.Nth, r.N()-1)
 		for _, j := range r.OtherPartyIDs() {
 			deltaCiphertext := r.DeltaCiphertext[j][r.SelfID()] // Dᵢⱼ
-			DeltaProofs[j] = proveNth(r.HashForID(r.SelfID()), r.SecretPaillier, deltaCiphertext)
+			DeltaProofs[j] = abort.ProveNth(r.Rand(), r.HashForID(r.SelfID()), r.SecretPaillier, deltaCiphertext)
 		}
 		msg := &broadcastAbort1{
 			GammaShare:  r.GammaShare,
-			KProof:      proveNth(r.HashForID(r.SelfID()), r.SecretPaillier, r.K[r.SelfID()]),
+			KProof:      abort.ProveNth(r.Rand(), r.HashForID(r.SelfID()), r.SecretPaillier, r.K[r.SelfID()]),
 			DeltaProofs: DeltaProofs,
 		}
 		if err := r.BroadcastMessage(out, msg); err != nil {
@@ -122,7 +123,7 @@ func (r *presign6) Finalize(out chan<- *round.Message) (round.Session, error) {
 		RBar[j] = DeltaInv.Act(BigDeltaJ)
 	}
 
-	proof := zkelog.NewProof(r.Group(), r.HashForID(r.SelfID()), zkelog.Public{
+	proof := zkelog.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()), zkelog.Public{
 		E:             r.ElGamalChi[r.SelfID()],
 		ElGamalPublic: r.ElGamal[r.SelfID()],
 		Base:          R,
@@ -3,6 +3,7 @@ package presign
 import (
 	"errors"
 
+	"github.com/taurusgroup/multi-party-sig/internal/abort"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/internal/types"
 	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
@@ -101,7 +102,7 @@ func (r *presign7) Finalize(out chan<- *round.Message) (round.Session, error) {
 	// ∑ⱼ Sⱼ ?= X
 	if !r.PublicKey.Equal(PublicKeyComputed) {
 		YHat := r.ElGamalChiNonce.Act(r.ElGamal[r.SelfID()])
-		YHatProof := zklog.NewProof(r.Group(), r.HashForID(r.SelfID()), zklog.Public{
+		YHatProof := zklog.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()), zklog.Public{
 			H: r.ElGamalChiNonce.ActOnBase(),
 			X: r.ElGamal[r.SelfID()],
 			Y: YHat,
@@ -110,15 +111,15 @@ func (r *presign7) Finalize(out chan<- *round.Message) (round.Session, error) {
 			B: r.ElGamalChiNonce,
 		})
 
-		ChiProofs := make(map[party.ID]*abortNth, r.N()-1)
+		ChiProofs := make(map[party.ID]*abort.Nth, r.N()-1)
 		for _, j := range r.OtherPartyIDs() {
 			chiCiphertext := r.ChiCiphertext[j][r.SelfID()] // D̂ᵢⱼ
-			ChiProofs[j] = proveNth(r.HashForID(r.SelfID()), r.SecretPaillier, chiCiphertext)
+			ChiProofs[j] = abort.ProveNth(r.Rand(), r.HashForID(r.SelfID()), r.SecretPaillier, chiCiphertext)
 		}
 		msg := &broadcastAbort2{
 			YHat:      YHat,
 			YHatProof: YHatProof,
-			KProof:    proveNth(r.HashForID(r.SelfID()), r.SecretPaillier, r.K[r.SelfID()]),
+			KProof:    abort.ProveNth(r.Rand(), r.HashForID(r.SelfID()), r.SecretPaillier, r.K[r.SelfID()]),
 			ChiProofs: ChiProofs,
 		}
 		if err := r.BroadcastMessage(out, msg); err != nil {
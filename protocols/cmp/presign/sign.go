@@ -7,6 +7,7 @@ import (
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/internal/types"
 	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa/presignstore"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
@@ -26,11 +27,14 @@ const (
 	protocolFullRounds    round.Number = 8
 )
 
-func StartPresign(c *config.Config, signers []party.ID, message []byte, pl *pool.Pool) protocol.StartFunc {
+func StartPresign(c *config.Config, signers []party.ID, message []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
 		if c == nil {
 			return nil, errors.New("presign: config is nil")
 		}
+		if c.Group.NewBasePoint().XScalar() == nil {
+			return nil, fmt.Errorf("presign: group %s does not support ECDSA", c.Group.Name())
+		}
 
 		info := round.Info{
 			SelfID:    c.ID,
@@ -38,6 +42,7 @@ func StartPresign(c *config.Config, signers []party.ID, message []byte, pl *pool
 			Threshold: c.Threshold,
 			Group:     c.Group,
 		}
+		info.Apply(opts...)
 		if len(message) == 0 {
 			info.FinalRoundNumber = protocolOfflineRounds
 			info.ProtocolID = protocolOfflineID
@@ -54,44 +59,49 @@ func StartPresign(c *config.Config, signers []party.ID, message []byte, pl *pool
 		if !c.CanSign(helper.PartyIDs()) {
 			return nil, errors.New("sign.Create: signers is not a valid signing subset")
 		}
-		// Scale public data
-		T := helper.N()
-		group := c.Group
-		ECDSA := make(map[party.ID]curve.Point, T)
-		ElGamal := make(map[party.ID]curve.Point, T)
-		Paillier := make(map[party.ID]*paillier.PublicKey, T)
-		Pedersen := make(map[party.ID]*pedersen.Parameters, T)
-		PublicKey := group.NewPoint()
-		lagrange := polynomial.Lagrange(group, signers)
-		// Scale own secret
-		SecretECDSA := group.NewScalar().Set(lagrange[c.ID]).Mul(c.ECDSA)
-		for _, j := range helper.PartyIDs() {
-			public := c.Public[j]
-			// scale public key share
-			ECDSA[j] = lagrange[j].Act(public.ECDSA)
-			ElGamal[j] = public.ElGamal
-			Paillier[j] = public.Paillier
-			Pedersen[j] = public.Pedersen
-			PublicKey = PublicKey.Add(ECDSA[j])
-		}
+		return newPresign1(c, helper, pl, message), nil
+	}
+}
 
-		return &presign1{
-			Helper:         helper,
-			Pool:           pl,
-			SecretECDSA:    SecretECDSA,
-			SecretElGamal:  c.ElGamal,
-			SecretPaillier: c.Paillier,
-			PublicKey:      PublicKey,
-			ECDSA:          ECDSA,
-			ElGamal:        ElGamal,
-			Paillier:       Paillier,
-			Pedersen:       Pedersen,
-			Message:        message,
-		}, nil
+// newPresign1 returns the first round of a presigning execution among the parties of helper.
+func newPresign1(c *config.Config, helper *round.Helper, pl *pool.Pool, message []byte) *presign1 {
+	// Scale public data
+	T := helper.N()
+	group := c.Group
+	ECDSA := make(map[party.ID]curve.Point, T)
+	ElGamal := make(map[party.ID]curve.Point, T)
+	Paillier := make(map[party.ID]*paillier.PublicKey, T)
+	Pedersen := make(map[party.ID]*pedersen.Parameters, T)
+	PublicKey := group.NewPoint()
+	lagrange := polynomial.Lagrange(group, helper.PartyIDs())
+	// Scale own secret
+	SecretECDSA := group.NewScalar().Set(lagrange[c.ID]).Mul(c.ECDSA)
+	for _, j := range helper.PartyIDs() {
+		public := c.Public[j]
+		// scale public key share
+		ECDSA[j] = lagrange[j].Act(public.ECDSA)
+		ElGamal[j] = public.ElGamal
+		Paillier[j] = public.Paillier
+		Pedersen[j] = public.Pedersen
+		PublicKey = PublicKey.Add(ECDSA[j])
+	}
+
+	return &presign1{
+		Helper:         helper,
+		Pool:           pl,
+		SecretECDSA:    SecretECDSA,
+		SecretElGamal:  c.ElGamal,
+		SecretPaillier: c.Paillier,
+		PublicKey:      PublicKey,
+		ECDSA:          ECDSA,
+		ElGamal:        ElGamal,
+		Paillier:       Paillier,
+		Pedersen:       Pedersen,
+		Message:        message,
 	}
 }
 
-func StartPresignOnline(c *config.Config, preSignature *ecdsa.PreSignature, message []byte, pl *pool.Pool) protocol.StartFunc {
+func StartPresignOnline(c *config.Config, preSignature *ecdsa.PreSignature, message []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
 		if c == nil || preSignature == nil {
 			return nil, errors.New("presign: config or preSignature is nil")
@@ -119,6 +129,7 @@ func StartPresignOnline(c *config.Config, preSignature *ecdsa.PreSignature, mess
 			Threshold:        c.Threshold,
 			Group:            c.Group,
 		}
+		info.Apply(opts...)
 
 		helper, err := round.NewSession(
 			info,
@@ -143,3 +154,23 @@ func StartPresignOnline(c *config.Config, preSignature *ecdsa.PreSignature, mess
 		}, nil
 	}
 }
+
+// StartPresignOnlineFromStore consumes the presignature with the given ID from store, and signs message with it.
+//
+// The presignature is consumed before any signature share is sent, and is not available anymore
+// even if the protocol fails.
+func StartPresignOnlineFromStore(c *config.Config, store presignstore.Store, preSignatureID []byte, message []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if c == nil || store == nil {
+			return nil, errors.New("presign: config or store is nil")
+		}
+		if len(message) == 0 {
+			return nil, errors.New("sign.Create: message is nil")
+		}
+		preSignature, err := store.Consume(preSignatureID)
+		if err != nil {
+			return nil, fmt.Errorf("sign.Create: %w", err)
+		}
+		return StartPresignOnline(c, preSignature, message, pl, opts...)(sessionID)
+	}
+}
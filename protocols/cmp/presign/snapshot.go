@@ -0,0 +1,338 @@
+package presign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/saferith"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/elgamal"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var (
+	_ round.Restorer    = (*presign1)(nil)
+	_ round.Snapshotter = (*presign2)(nil)
+	_ round.Snapshotter = (*presign3)(nil)
+	_ round.Snapshotter = (*presign4)(nil)
+	_ round.Snapshotter = (*presign5)(nil)
+	_ round.Snapshotter = (*presign6)(nil)
+	_ round.Snapshotter = (*presign7)(nil)
+)
+
+// snapshot contains the state of the presigning protocol from presign2 onwards.
+// Fields which are set in later rounds are empty in the snapshots of earlier rounds.
+//
+// The rounds of the online signature, and the abort rounds, cannot be saved.
+type snapshot struct {
+	// presign2
+	K, G           map[party.ID]*paillier.Ciphertext
+	GammaShare     *saferith.Int
+	KShare         []byte
+	KNonce, GNonce *saferith.Nat
+	ElGamalKNonce  []byte
+	ElGamalK       map[party.ID]cbor.RawMessage
+	PresignatureID map[party.ID]types.RID
+	CommitmentID   map[party.ID]hash.Commitment
+	DecommitmentID hash.Decommitment
+
+	// presign3
+	DeltaShareBeta, ChiShareBeta   map[party.ID]*saferith.Int
+	DeltaCiphertext, ChiCiphertext map[party.ID]map[party.ID]*paillier.Ciphertext
+
+	// presign4
+	DeltaShareAlpha, ChiShareAlpha map[party.ID]*saferith.Int
+	ElGamalChiNonce                []byte
+	ElGamalChi                     map[party.ID]cbor.RawMessage
+	DeltaShares                    map[party.ID][]byte
+	ChiShare                       []byte
+
+	// presign5
+	BigGammaShare map[party.ID][]byte
+
+	// presign6
+	BigDeltaShares map[party.ID][]byte
+	Gamma          []byte
+
+	// presign7
+	Delta []byte
+	S     map[party.ID][]byte
+	R     []byte
+	RBar  map[party.ID][]byte
+}
+
+// Snapshot implements round.Snapshotter.
+func (r *presign2) Snapshot() ([]byte, error) { return marshalSnapshot(r, nil, nil, nil, nil, nil) }
+
+// Snapshot implements round.Snapshotter.
+func (r *presign3) Snapshot() ([]byte, error) {
+	return marshalSnapshot(r.presign2, r, nil, nil, nil, nil)
+}
+
+// Snapshot implements round.Snapshotter.
+func (r *presign4) Snapshot() ([]byte, error) {
+	return marshalSnapshot(r.presign2, r.presign3, r, nil, nil, nil)
+}
+
+// Snapshot implements round.Snapshotter.
+func (r *presign5) Snapshot() ([]byte, error) {
+	return marshalSnapshot(r.presign2, r.presign3, r.presign4, r, nil, nil)
+}
+
+// Snapshot implements round.Snapshotter.
+func (r *presign6) Snapshot() ([]byte, error) {
+	return marshalSnapshot(r.presign2, r.presign3, r.presign4, r.presign5, r, nil)
+}
+
+// Snapshot implements round.Snapshotter.
+func (r *presign7) Snapshot() ([]byte, error) {
+	return marshalSnapshot(r.presign2, r.presign3, r.presign4, r.presign5, r.presign6, r)
+}
+
+// marshalSnapshot encodes the state of the rounds which are not nil.
+func marshalSnapshot(r2 *presign2, r3 *presign3, r4 *presign4, r5 *presign5, r6 *presign6, r7 *presign7) ([]byte, error) {
+	var err error
+	s := &snapshot{
+		K:              r2.K,
+		G:              r2.G,
+		GammaShare:     r2.GammaShare,
+		KNonce:         r2.KNonce,
+		GNonce:         r2.GNonce,
+		PresignatureID: r2.PresignatureID,
+		CommitmentID:   r2.CommitmentID,
+		DecommitmentID: r2.DecommitmentID,
+	}
+	if s.KShare, err = r2.KShare.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if s.ElGamalKNonce, err = r2.ElGamalKNonce.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if s.ElGamalK, err = marshalElGamal(r2.ElGamalK); err != nil {
+		return nil, err
+	}
+
+	if r3 != nil {
+		s.DeltaShareBeta, s.ChiShareBeta = r3.DeltaShareBeta, r3.ChiShareBeta
+		s.DeltaCiphertext, s.ChiCiphertext = r3.DeltaCiphertext, r3.ChiCiphertext
+	}
+	if r4 != nil {
+		s.DeltaShareAlpha, s.ChiShareAlpha = r4.DeltaShareAlpha, r4.ChiShareAlpha
+		if s.ElGamalChiNonce, err = r4.ElGamalChiNonce.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if s.ElGamalChi, err = marshalElGamal(r4.ElGamalChi); err != nil {
+			return nil, err
+		}
+		if s.DeltaShares, err = round.MarshalScalars(r4.DeltaShares); err != nil {
+			return nil, err
+		}
+		if s.ChiShare, err = r4.ChiShare.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if r5 != nil {
+		if s.BigGammaShare, err = round.MarshalPoints(r5.BigGammaShare); err != nil {
+			return nil, err
+		}
+	}
+	if r6 != nil {
+		if s.BigDeltaShares, err = round.MarshalPoints(r6.BigDeltaShares); err != nil {
+			return nil, err
+		}
+		if s.Gamma, err = r6.Gamma.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if r7 != nil {
+		if s.Delta, err = r7.Delta.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if s.S, err = round.MarshalPoints(r7.S); err != nil {
+			return nil, err
+		}
+		if s.R, err = r7.R.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if s.RBar, err = round.MarshalPoints(r7.RBar); err != nil {
+			return nil, err
+		}
+	}
+	return cbor.Marshal(s)
+}
+
+// Restore implements round.Restorer.
+func (r *presign1) Restore(number round.Number, data []byte) (round.Session, error) {
+	if number < 2 || number > protocolOfflineRounds {
+		return nil, fmt.Errorf("presign: invalid round number %d", number)
+	}
+	s := &snapshot{}
+	if err := cbor.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("presign: %w", err)
+	}
+	group := r.Group()
+
+	if s.K[r.SelfID()] == nil || s.G[r.SelfID()] == nil || s.GammaShare == nil || s.KNonce == nil || s.GNonce == nil {
+		return nil, fmt.Errorf("presign: paillier: %w", round.ErrNilFields)
+	}
+	if s.PresignatureID[r.SelfID()] == nil || s.CommitmentID == nil {
+		return nil, errors.New("presign: missing presignature id")
+	}
+	KShare := group.NewScalar()
+	if err := KShare.UnmarshalBinary(s.KShare); err != nil {
+		return nil, fmt.Errorf("presign: k share: %w", err)
+	}
+	ElGamalKNonce := group.NewScalar()
+	if err := ElGamalKNonce.UnmarshalBinary(s.ElGamalKNonce); err != nil {
+		return nil, fmt.Errorf("presign: elgamal nonce: %w", err)
+	}
+	ElGamalK, err := unmarshalElGamal(group, s.ElGamalK)
+	if err != nil {
+		return nil, fmt.Errorf("presign: elgamal k: %w", err)
+	}
+	r2 := &presign2{
+		presign1:       r,
+		K:              s.K,
+		G:              s.G,
+		GammaShare:     s.GammaShare,
+		KShare:         KShare,
+		KNonce:         s.KNonce,
+		GNonce:         s.GNonce,
+		ElGamalKNonce:  ElGamalKNonce,
+		ElGamalK:       ElGamalK,
+		PresignatureID: s.PresignatureID,
+		CommitmentID:   s.CommitmentID,
+		DecommitmentID: s.DecommitmentID,
+	}
+	if number == 2 {
+		return r2, nil
+	}
+
+	if s.DeltaShareBeta == nil || s.ChiShareBeta == nil || s.DeltaCiphertext[r.SelfID()] == nil || s.ChiCiphertext[r.SelfID()] == nil {
+		return nil, fmt.Errorf("presign: mta: %w", round.ErrNilFields)
+	}
+	r3 := &presign3{
+		presign2:        r2,
+		DeltaShareBeta:  s.DeltaShareBeta,
+		ChiShareBeta:    s.ChiShareBeta,
+		DeltaCiphertext: s.DeltaCiphertext,
+		ChiCiphertext:   s.ChiCiphertext,
+	}
+	if number == 3 {
+		return r3, nil
+	}
+
+	if s.DeltaShareAlpha == nil || s.ChiShareAlpha == nil {
+		return nil, fmt.Errorf("presign: mta: %w", round.ErrNilFields)
+	}
+	ElGamalChiNonce := group.NewScalar()
+	if err = ElGamalChiNonce.UnmarshalBinary(s.ElGamalChiNonce); err != nil {
+		return nil, fmt.Errorf("presign: elgamal nonce: %w", err)
+	}
+	ElGamalChi, err := unmarshalElGamal(group, s.ElGamalChi)
+	if err != nil {
+		return nil, fmt.Errorf("presign: elgamal chi: %w", err)
+	}
+	DeltaShares, err := round.UnmarshalScalars(group, s.DeltaShares)
+	if err != nil {
+		return nil, fmt.Errorf("presign: delta shares: %w", err)
+	}
+	ChiShare := group.NewScalar()
+	if err = ChiShare.UnmarshalBinary(s.ChiShare); err != nil {
+		return nil, fmt.Errorf("presign: chi share: %w", err)
+	}
+	r4 := &presign4{
+		presign3:        r3,
+		DeltaShareAlpha: s.DeltaShareAlpha,
+		ChiShareAlpha:   s.ChiShareAlpha,
+		ElGamalChiNonce: ElGamalChiNonce,
+		ElGamalChi:      ElGamalChi,
+		DeltaShares:     DeltaShares,
+		ChiShare:        ChiShare,
+	}
+	if number == 4 {
+		return r4, nil
+	}
+
+	BigGammaShare, err := round.UnmarshalPoints(group, s.BigGammaShare)
+	if err != nil {
+		return nil, fmt.Errorf("presign: big gamma shares: %w", err)
+	}
+	r5 := &presign5{
+		presign4:      r4,
+		BigGammaShare: BigGammaShare,
+	}
+	if number == 5 {
+		return r5, nil
+	}
+
+	BigDeltaShares, err := round.UnmarshalPoints(group, s.BigDeltaShares)
+	if err != nil {
+		return nil, fmt.Errorf("presign: big delta shares: %w", err)
+	}
+	Gamma := group.NewPoint()
+	if err = Gamma.UnmarshalBinary(s.Gamma); err != nil {
+		return nil, fmt.Errorf("presign: gamma: %w", err)
+	}
+	r6 := &presign6{
+		presign5:       r5,
+		BigDeltaShares: BigDeltaShares,
+		Gamma:          Gamma,
+	}
+	if number == 6 {
+		return r6, nil
+	}
+
+	Delta := group.NewScalar()
+	if err = Delta.UnmarshalBinary(s.Delta); err != nil {
+		return nil, fmt.Errorf("presign: delta: %w", err)
+	}
+	S, err := round.UnmarshalPoints(group, s.S)
+	if err != nil {
+		return nil, fmt.Errorf("presign: S: %w", err)
+	}
+	R := group.NewPoint()
+	if err = R.UnmarshalBinary(s.R); err != nil {
+		return nil, fmt.Errorf("presign: R: %w", err)
+	}
+	RBar, err := round.UnmarshalPoints(group, s.RBar)
+	if err != nil {
+		return nil, fmt.Errorf("presign: RBar: %w", err)
+	}
+	return &presign7{
+		presign6: r6,
+		Delta:    Delta,
+		S:        S,
+		R:        R,
+		RBar:     RBar,
+	}, nil
+}
+
+func marshalElGamal(ciphertexts map[party.ID]*elgamal.Ciphertext) (map[party.ID]cbor.RawMessage, error) {
+	out := make(map[party.ID]cbor.RawMessage, len(ciphertexts))
+	for j, ct := range ciphertexts {
+		data, err := cbor.Marshal(ct)
+		if err != nil {
+			return nil, err
+		}
+		out[j] = data
+	}
+	return out, nil
+}
+
+func unmarshalElGamal(group curve.Curve, data map[party.ID]cbor.RawMessage) (map[party.ID]*elgamal.Ciphertext, error) {
+	out := make(map[party.ID]*elgamal.Ciphertext, len(data))
+	for j, d := range data {
+		ct := elgamal.Empty(group)
+		if err := cbor.Unmarshal(d, ct); err != nil {
+			return nil, fmt.Errorf("%s: %w", j, err)
+		}
+		out[j] = ct
+	}
+	return out, nil
+}
@@ -0,0 +1,61 @@
+package presign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+)
+
+func TestPresignSnapshot(t *testing.T) {
+	sessionID := []byte("session")
+	rounds := make([]round.Session, 0, N)
+	for _, id := range partyIDs {
+		r, err := StartPresign(configs[id], partyIDs, nil, nil)(sessionID)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+		// restart all parties from a snapshot of their current round
+		for i, r := range rounds {
+			data, err := round.MarshalSnapshot(r)
+			require.NoError(t, err, "failed to snapshot round", r.Number())
+			first, err := StartPresign(configs[r.SelfID()], partyIDs, nil, nil)(sessionID)
+			require.NoError(t, err)
+			restored, err := round.UnmarshalSnapshot(first, data)
+			require.NoError(t, err, "failed to restore round", r.Number())
+			assert.Equal(t, r.Hash().Sum(), restored.Hash().Sum(), "hash state is different")
+			rounds[i] = restored
+		}
+	}
+
+	online := make([]round.Session, 0, N)
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		preSignature := r.(*round.Output).Result.(*ecdsa.PreSignature)
+		next, err := StartPresignOnline(configs[r.SelfID()], preSignature, messageHash, nil)(nil)
+		require.NoError(t, err)
+		online = append(online, next)
+	}
+	for {
+		err, done := test.Rounds(online, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+	for _, r := range online {
+		require.IsType(t, &round.Output{}, r)
+		signature := r.(*round.Output).Result.(*ecdsa.Signature)
+		assert.True(t, signature.Verify(configs[r.SelfID()].PublicPoint(), messageHash))
+	}
+}
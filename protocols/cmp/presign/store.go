@@ -0,0 +1,33 @@
+package presign
+
+import (
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa/presignstore"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+)
+
+// Store holds presignatures until they are used, and ensures that each is used for at most one message.
+// It is implemented by the presignstore package, which does not depend on the CMP protocol.
+type Store = presignstore.Store
+
+// MemoryStore is a Store which keeps the presignatures in memory.
+type MemoryStore = presignstore.MemoryStore
+
+// FileStore is a Store which keeps each presignature in its own file, in a single directory.
+type FileStore = presignstore.FileStore
+
+var (
+	// ErrNotFound is returned when a Store does not contain a presignature.
+	ErrNotFound = presignstore.ErrNotFound
+	// ErrConsumed is returned when a presignature was already consumed or expired, and must not be used again.
+	ErrConsumed = presignstore.ErrConsumed
+)
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return presignstore.NewMemoryStore()
+}
+
+// NewFileStore returns a FileStore which keeps the presignatures of the given group in dir.
+func NewFileStore(dir string, group curve.Curve) (*FileStore, error) {
+	return presignstore.NewFileStore(dir, group)
+}
@@ -0,0 +1,54 @@
+package presign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa/presignstore"
+)
+
+func TestPresignOnlineFromStore(t *testing.T) {
+	rounds := startBatch(t, 1)
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+
+	// each party signs with the presignature it stored
+	stores := make(map[*ecdsa.PreSignature]*presignstore.MemoryStore, N)
+	online := make([]round.Session, 0, N)
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		preSignature := r.(*round.Output).Result.([]*ecdsa.PreSignature)[0]
+		s := presignstore.NewMemoryStore()
+		require.NoError(t, s.Put(preSignature))
+		stores[preSignature] = s
+		next, err := StartPresignOnlineFromStore(configs[r.SelfID()], s, preSignature.ID, messageHash, nil)(nil)
+		require.NoError(t, err)
+		online = append(online, next)
+	}
+	for {
+		err, done := test.Rounds(online, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+	for _, r := range online {
+		require.IsType(t, &round.Output{}, r)
+		signature := r.(*round.Output).Result.(*ecdsa.Signature)
+		assert.True(t, signature.Verify(configs[partyIDs[0]].PublicPoint(), messageHash))
+	}
+
+	for preSignature, s := range stores {
+		_, err := StartPresignOnlineFromStore(configs[partyIDs[0]], s, preSignature.ID, messageHash, nil)(nil)
+		assert.ErrorIs(t, err, presignstore.ErrConsumed, "a presignature should not be used twice")
+	}
+}
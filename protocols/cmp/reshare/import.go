@@ -0,0 +1,53 @@
+package reshare
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+const protocolIDImport = "cmp/import-threshold"
+
+// StartImport is used by the dealer holding an existing private key,
+// which is shared among parties with the given threshold.
+//
+// The import is a resharing where the dealer is the only old signer, and secret is its share.
+// chainKey is the chain key of the new config, and is sampled at random if nil.
+// The dealer must delete the private key after the protocol has completed.
+func StartImport(group curve.Curve, selfID party.ID, secret curve.Scalar, chainKey []byte, parties []party.ID, threshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	if secret == nil || secret.IsZero() {
+		return func([]byte) (round.Session, error) {
+			return nil, errors.New("import: private key is invalid")
+		}
+	}
+	publicKey := secret.ActOnBase()
+	return start(group, selfID, protocolIDImport, publicKey, []party.ID{selfID}, parties, threshold, pl, func(r *round1) error {
+		if chainKey == nil {
+			rid, err := types.NewRID(r.Rand())
+			if err != nil {
+				return fmt.Errorf("failed to sample chain key: %w", err)
+			}
+			chainKey = rid
+		}
+		if err := types.RID(chainKey).Validate(); err != nil {
+			return fmt.Errorf("chain key: %w", err)
+		}
+		r.PreviousChainKey = types.RID(chainKey).Copy()
+		// f(X) deg(f) = t, f(0) = x
+		r.VSSSecret = polynomial.NewPolynomial(r.Rand(), group, threshold, secret)
+		return nil
+	}, opts...)
+}
+
+// StartImportJoin is used by the parties receiving a share of the key imported by dealer.
+// publicKey is the public key of the imported private key, and must be obtained from a trusted source.
+func StartImportJoin(group curve.Curve, selfID, dealer party.ID, publicKey curve.Point, parties []party.ID, threshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return start(group, selfID, protocolIDImport, publicKey, []party.ID{dealer}, parties, threshold, pl, nil, opts...)
+}
@@ -0,0 +1,153 @@
+package reshare
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+)
+
+const (
+	protocolID = "cmp/reshare-threshold"
+	// Rounds is the number of rounds before the output round.
+	Rounds round.Number = 5
+)
+
+// StartReshare is used by a party holding a share of the key.
+// If the party is included in oldSigners, it deals a sharing of its share to newParties.
+func StartReshare(c *config.Config, oldSigners, newParties []party.ID, newThreshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return start(c.Group, c.ID, protocolID, c.PublicPoint(), oldSigners, newParties, newThreshold, pl, func(r *round1) error {
+		// check that the old signers can reconstruct the key
+		if !config.ValidThreshold(c.Threshold, len(r.OldSigners)) {
+			return errors.New("not enough old signers to reconstruct the key")
+		}
+		for _, j := range r.OldSigners {
+			if _, ok := c.Public[j]; !ok {
+				return fmt.Errorf("old signer %s is not part of the config", j)
+			}
+		}
+
+		// X'ⱼ = λⱼ⋅Xⱼ, so that ∑ⱼ X'ⱼ = X
+		lagrange := polynomial.Lagrange(c.Group, r.OldSigners)
+		r.PreviousPublicSharesECDSA = make(map[party.ID]curve.Point, len(r.OldSigners))
+		for _, j := range r.OldSigners {
+			r.PreviousPublicSharesECDSA[j] = lagrange[j].Act(c.Public[j].ECDSA)
+		}
+		r.PreviousChainKey = c.ChainKey
+
+		if r.isDealer(c.ID) {
+			// fᵢ(X) deg(fᵢ) = t', fᵢ(0) = λᵢ⋅xᵢ
+			secret := c.Group.NewScalar().Set(lagrange[c.ID]).Mul(c.ECDSA)
+			r.VSSSecret = polynomial.NewPolynomial(r.Rand(), c.Group, newThreshold, secret)
+		}
+		return nil
+	}, opts...)
+}
+
+// StartReshareJoin is used by a party from newParties which does not hold a share of the key.
+// publicKey is the public key which is being reshared, and must be obtained from a trusted source.
+func StartReshareJoin(group curve.Curve, selfID party.ID, publicKey curve.Point, oldSigners, newParties []party.ID, newThreshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return start(group, selfID, protocolID, publicKey, oldSigners, newParties, newThreshold, pl, nil, opts...)
+}
+
+// start creates the first round of the protocol, shared by all roles.
+// If setup is not nil, it is called to set the data derived from the previous sharing of the key.
+func start(group curve.Curve, selfID party.ID, protocolID string, publicKey curve.Point, oldSigners, newParties []party.ID, newThreshold int, pl *pool.Pool, setup func(r *round1) error, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		oldSignerIDs := party.NewIDSlice(oldSigners)
+		newPartyIDs := party.NewIDSlice(newParties)
+		if !oldSignerIDs.Valid() || !newPartyIDs.Valid() {
+			return nil, errors.New("reshare: party IDs contain duplicates")
+		}
+		if !config.ValidThreshold(newThreshold, len(newPartyIDs)) {
+			return nil, fmt.Errorf("reshare: threshold %d is invalid for number of new parties %d", newThreshold, len(newPartyIDs))
+		}
+		if publicKey == nil || publicKey.IsIdentity() {
+			return nil, errors.New("reshare: public key is invalid")
+		}
+
+		// all parties taking part in the protocol
+		partyIDs := oldSignerIDs.Copy()
+		for _, id := range newPartyIDs {
+			if !oldSignerIDs.Contains(id) {
+				partyIDs = append(partyIDs, id)
+			}
+		}
+
+		info := round.Info{
+			ProtocolID:       protocolID,
+			FinalRoundNumber: Rounds,
+			SelfID:           selfID,
+			PartyIDs:         partyIDs,
+			Threshold:        newThreshold,
+			Group:            group,
+		}
+		info.Apply(opts...)
+		helper, err := round.NewSession(info, sessionID, pl, &reshareInfo{
+			PublicKey:  publicKey,
+			OldSigners: oldSignerIDs,
+			NewParties: newPartyIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reshare: %w", err)
+		}
+
+		r := &round1{
+			Helper:     helper,
+			OldSigners: oldSignerIDs,
+			NewParties: newPartyIDs,
+			PublicKey:  publicKey,
+		}
+		if setup != nil {
+			if err = setup(r); err != nil {
+				return nil, fmt.Errorf("reshare: %w", err)
+			}
+		}
+		if r.isDealer(selfID) && r.VSSSecret == nil {
+			return nil, errors.New("reshare: old signer must provide its share of the key")
+		}
+		return r, nil
+	}
+}
+
+// reshareInfo contains the parameters of the resharing all parties must agree on.
+type reshareInfo struct {
+	PublicKey  curve.Point
+	OldSigners party.IDSlice
+	NewParties party.IDSlice
+}
+
+// WriteTo implements io.WriterTo interface.
+func (i *reshareInfo) WriteTo(w io.Writer) (total int64, err error) {
+	data, err := i.PublicKey.MarshalBinary()
+	if err != nil {
+		return
+	}
+	n, err := w.Write(data)
+	total = int64(n)
+	if err != nil {
+		return
+	}
+
+	n64, err := i.OldSigners.WriteTo(w)
+	total += n64
+	if err != nil {
+		return
+	}
+
+	n64, err = i.NewParties.WriteTo(w)
+	total += n64
+	return
+}
+
+// Domain implements hash.WriterToWithDomain.
+func (reshareInfo) Domain() string {
+	return "Reshare Info"
+}
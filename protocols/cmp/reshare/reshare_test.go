@@ -0,0 +1,154 @@
+package reshare
+
+import (
+	"crypto/rand"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+)
+
+var group = curve.Secp256k1{}
+
+func TestReshare(t *testing.T) {
+	N, T := 3, 1
+	configs, _ := test.GenerateConfig(group, N, T, mrand.New(mrand.NewSource(1)), nil)
+	publicKey := configs["a"].PublicPoint()
+
+	// "a" leaves, "b" stays, "c" holds a share but does not deal, and "d" joins.
+	oldSigners := []party.ID{"a", "b"}
+	newParties := []party.ID{"b", "c", "d"}
+	newThreshold := 2
+
+	rounds := make([]round.Session, 0, 4)
+	for _, id := range []party.ID{"a", "b", "c"} {
+		r, err := StartReshare(configs[id], oldSigners, newParties, newThreshold, nil)(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+	r, err := StartReshareJoin(group, "d", publicKey, oldSigners, newParties, newThreshold, nil)(nil)
+	require.NoError(t, err, "round creation should not result in an error")
+	rounds = append(rounds, r)
+
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+
+	newConfigs := make(map[party.ID]*config.Config, len(newParties))
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		result := r.(*round.Output).Result
+		if r.SelfID() == "a" {
+			require.Implements(t, (*curve.Point)(nil), result)
+			assert.True(t, publicKey.Equal(result.(curve.Point)))
+			continue
+		}
+		require.IsType(t, &config.Config{}, result)
+		c := result.(*config.Config)
+		data, err := cbor.Marshal(c)
+		require.NoError(t, err)
+		c2 := config.EmptyConfig(group)
+		require.NoError(t, cbor.Unmarshal(data, c2))
+		newConfigs[c.ID] = c2
+	}
+
+	secret := group.NewScalar()
+	lagrange := polynomial.Lagrange(group, newParties)
+	for _, c := range newConfigs {
+		assert.Equal(t, newThreshold, c.Threshold)
+		assert.Equal(t, party.NewIDSlice(newParties), c.PartyIDs())
+		assert.True(t, publicKey.Equal(c.PublicPoint()), "public key is different")
+		assert.EqualValues(t, configs["a"].ChainKey, c.ChainKey, "chain key is different")
+		assert.True(t, c.ECDSA.ActOnBase().Equal(c.Public[c.ID].ECDSA), "public share does not match secret")
+		for id, p := range newConfigs["b"].Public {
+			assert.True(t, p.ECDSA.Equal(c.Public[id].ECDSA), "ecdsa not the same", id)
+			assert.True(t, p.Paillier.Equal(c.Public[id].Paillier), "paillier not the same", id)
+		}
+		secret.Add(group.NewScalar().Set(lagrange[c.ID]).Mul(c.ECDSA))
+	}
+	assert.True(t, publicKey.Equal(secret.ActOnBase()), "shares do not reconstruct the secret")
+}
+
+func TestReshareInvalid(t *testing.T) {
+	configs, _ := test.GenerateConfig(group, 3, 1, mrand.New(mrand.NewSource(1)), nil)
+
+	_, err := StartReshare(configs["a"], []party.ID{"a"}, []party.ID{"b", "c"}, 1, nil)(nil)
+	assert.Error(t, err, "not enough old signers")
+
+	_, err = StartReshare(configs["a"], []party.ID{"a", "b"}, []party.ID{"b", "c"}, 2, nil)(nil)
+	assert.Error(t, err, "threshold too large")
+
+	_, err = StartReshareJoin(group, "a", configs["a"].PublicPoint(), []party.ID{"a", "b"}, []party.ID{"b", "c"}, 1, nil)(nil)
+	assert.Error(t, err, "old signer without config")
+}
+
+func TestImport(t *testing.T) {
+	secret := sample.Scalar(rand.Reader, group)
+	publicKey := secret.ActOnBase()
+	chainKey := make([]byte, 32)
+	chainKey[0] = 1
+
+	tests := []struct {
+		name    string
+		dealer  party.ID
+		parties []party.ID
+	}{
+		{"dealer is a party", "a", []party.ID{"a", "b"}},
+		{"dealer is not a party", "a", []party.ID{"b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threshold := 1
+			rounds := make([]round.Session, 0, len(tt.parties)+1)
+			r, err := StartImport(group, tt.dealer, secret, chainKey, tt.parties, threshold, nil)(nil)
+			require.NoError(t, err, "round creation should not result in an error")
+			rounds = append(rounds, r)
+			for _, id := range tt.parties {
+				if id == tt.dealer {
+					continue
+				}
+				r, err = StartImportJoin(group, id, tt.dealer, publicKey, tt.parties, threshold, nil)(nil)
+				require.NoError(t, err, "round creation should not result in an error")
+				rounds = append(rounds, r)
+			}
+
+			for {
+				err, done := test.Rounds(rounds, nil)
+				require.NoError(t, err, "failed to process round")
+				if done {
+					break
+				}
+			}
+
+			reconstructed := group.NewScalar()
+			lagrange := polynomial.Lagrange(group, tt.parties)
+			for _, r := range rounds {
+				require.IsType(t, &round.Output{}, r)
+				result := r.(*round.Output).Result
+				if !party.NewIDSlice(tt.parties).Contains(r.SelfID()) {
+					assert.True(t, publicKey.Equal(result.(curve.Point)))
+					continue
+				}
+				require.IsType(t, &config.Config{}, result)
+				c := result.(*config.Config)
+				assert.True(t, publicKey.Equal(c.PublicPoint()), "public key is different")
+				assert.EqualValues(t, chainKey, c.ChainKey, "chain key is different")
+				reconstructed.Add(group.NewScalar().Set(lagrange[c.ID]).Mul(c.ECDSA))
+			}
+			assert.True(t, secret.Equal(reconstructed), "shares do not reconstruct the secret")
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package reshare
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pedersen"
+	zksch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+)
+
+var _ round.Round = (*round1)(nil)
+
+type round1 struct {
+	*round.Helper
+
+	// OldSigners hold a share of the key, and deal a new sharing of it.
+	OldSigners party.IDSlice
+	// NewParties receive a share of the key, and generate new auxiliary parameters.
+	NewParties party.IDSlice
+
+	// PublicKey = X is the public key being reshared.
+	PublicKey curve.Point
+
+	// PreviousPublicSharesECDSA[j] = λⱼ⋅X'ⱼ for all j in OldSigners.
+	// Reshare: λⱼ⋅X'ⱼ
+	// Join:    nil
+	PreviousPublicSharesECDSA map[party.ID]curve.Point
+
+	// PreviousChainKey is the chain key of the previous config.
+	// Reshare: c'
+	// Join:    nil
+	PreviousChainKey types.RID
+
+	// VSSSecret = fᵢ(X)
+	// Polynomial from which the new secret shares are computed, with fᵢ(0) = λᵢ⋅x'ᵢ.
+	// nil if we are not one of the OldSigners.
+	VSSSecret *polynomial.Polynomial
+}
+
+// isDealer returns true if id deals a sharing of its previous share.
+func (r *round1) isDealer(id party.ID) bool { return r.OldSigners.Contains(id) }
+
+// isReceiver returns true if id receives a share of the key.
+func (r *round1) isReceiver(id party.ID) bool { return r.NewParties.Contains(id) }
+
+// commitData returns the data committed to by party id in round 1, which depends on its role.
+func (r *round1) commitData(id party.ID, body *broadcast3) []interface{} {
+	data := []interface{}{body.RID}
+	if r.isDealer(id) {
+		data = append(data, body.C, body.VSSPolynomial)
+	}
+	if r.isReceiver(id) {
+		rec := body.Receiver
+		data = append(data, rec.SchnorrCommitments, rec.ElGamalPublic, rec.N, rec.S, rec.T)
+	}
+	return data
+}
+
+// VerifyMessage implements round.Round.
+func (r *round1) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (r *round1) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - sample ridᵢ <- {0,1}ᵏ
+// - if dealer, compute Fᵢ(X) = fᵢ(X)⋅G
+// - if receiver, sample Paillier (pᵢ, qᵢ), Pedersen Nᵢ, sᵢ, tᵢ, and Schnorr randomness aᵢ
+// - commit to message.
+func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
+	// Sample RIDᵢ
+	SelfRID, err := types.NewRID(r.Rand())
+	if err != nil {
+		return r, errors.New("failed to sample Rho")
+	}
+
+	nextRound := &round2{
+		round1:             r,
+		VSSPolynomials:     map[party.ID]*polynomial.Exponent{},
+		Commitments:        map[party.ID]hash.Commitment{},
+		RIDs:               map[party.ID]types.RID{},
+		ChainKeys:          map[party.ID]types.RID{},
+		ShareReceived:      map[party.ID]curve.Scalar{},
+		ElGamalPublic:      map[party.ID]curve.Point{},
+		PaillierPublic:     map[party.ID]*paillier.PublicKey{},
+		Pedersen:           map[party.ID]*pedersen.Parameters{},
+		SchnorrCommitments: map[party.ID]*zksch.Commitment{},
+	}
+	msg := &broadcast3{RID: SelfRID}
+
+	if r.isDealer(r.SelfID()) {
+		// set Fᵢ(X) = fᵢ(X)•G
+		msg.VSSPolynomial = polynomial.NewPolynomialExponent(r.VSSSecret)
+		msg.C = r.PreviousChainKey
+		if r.isReceiver(r.SelfID()) {
+			// save our own share already so we are consistent with what we receive from others
+			nextRound.ShareReceived[r.SelfID()] = r.VSSSecret.Evaluate(r.SelfID().Scalar(r.Group()))
+		}
+	}
+
+	if r.isReceiver(r.SelfID()) {
+		// generate Paillier and Pedersen
+		nextRound.PaillierSecret = paillier.NewSecretKey(r.Rand(), nil)
+		SelfPedersenPublic, PedersenSecret := nextRound.PaillierSecret.GeneratePedersen(r.Rand())
+		nextRound.PedersenSecret = PedersenSecret
+
+		var ElGamalPublic curve.Point
+		nextRound.ElGamalSecret, ElGamalPublic = sample.ScalarPointPair(r.Rand(), r.Group())
+
+		// generate Schnorr randomness
+		nextRound.SchnorrRand = zksch.NewRandomness(r.Rand(), r.Group(), nil)
+
+		msg.Receiver = &receiver3{
+			SchnorrCommitments: nextRound.SchnorrRand.Commitment(),
+			ElGamalPublic:      ElGamalPublic,
+			N:                  SelfPedersenPublic.N(),
+			S:                  SelfPedersenPublic.S(),
+			T:                  SelfPedersenPublic.T(),
+		}
+	}
+
+	// commit to data in message 3
+	SelfCommitment, Decommitment, err := r.HashForID(r.SelfID()).Commit(r.Rand(), r.commitData(r.SelfID(), msg)...)
+	if err != nil {
+		return r, errors.New("failed to commit")
+	}
+	msg.Decommitment = Decommitment
+
+	if err = r.BroadcastMessage(out, &broadcast2{Commitment: SelfCommitment}); err != nil {
+		return r, err
+	}
+
+	nextRound.Commitments[r.SelfID()] = SelfCommitment
+	nextRound.Message3 = msg
+	nextRound.store(r.SelfID(), msg)
+	return nextRound, nil
+}
+
+// MessageContent implements round.Round.
+func (round1) MessageContent() round.Content { return nil }
+
+// Number implements round.Round.
+func (round1) Number() round.Number { return 1 }
@@ -0,0 +1,131 @@
+package reshare
+
+import (
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/arith"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pedersen"
+	zksch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+)
+
+var _ round.Round = (*round2)(nil)
+
+type round2 struct {
+	*round1
+
+	// VSSPolynomials[j] = Fⱼ(X) = fⱼ(X)•G, for all dealers j
+	VSSPolynomials map[party.ID]*polynomial.Exponent
+
+	// Commitments[j] = H(Reshare3ⱼ ∥ Decommitments[j])
+	Commitments map[party.ID]hash.Commitment
+
+	// RIDs[j] = ridⱼ
+	RIDs map[party.ID]types.RID
+	// ChainKeys[j] = cⱼ, for all dealers j
+	ChainKeys map[party.ID]types.RID
+
+	// ShareReceived[j] = xʲᵢ
+	// share received from dealer j
+	ShareReceived map[party.ID]curve.Scalar
+
+	// ElGamalPublic[j] = Yⱼ, for all receivers j
+	ElGamalPublic map[party.ID]curve.Point
+	// PaillierPublic[j] = Nⱼ, for all receivers j
+	PaillierPublic map[party.ID]*paillier.PublicKey
+	// Pedersen[j] = (Nⱼ,Sⱼ,Tⱼ), for all receivers j
+	Pedersen map[party.ID]*pedersen.Parameters
+
+	// SchnorrCommitments[j] = Aⱼ, for all receivers j
+	// Commitment for proof of knowledge in the last round
+	SchnorrCommitments map[party.ID]*zksch.Commitment
+
+	// The following are only set if we are a receiver.
+
+	ElGamalSecret curve.Scalar
+
+	// PaillierSecret = (pᵢ, qᵢ)
+	PaillierSecret *paillier.SecretKey
+
+	// PedersenSecret = λᵢ
+	// Used to generate the Pedersen parameters
+	PedersenSecret *saferith.Nat
+
+	// SchnorrRand = aᵢ
+	// Randomness used to compute Schnorr commitment of proof of knowledge of secret share
+	SchnorrRand *zksch.Randomness
+
+	// Message3 contains the data we committed to, which is revealed in the next round.
+	Message3 *broadcast3
+}
+
+type broadcast2 struct {
+	round.ReliableBroadcastContent
+	// Commitment = Vᵢ = H(ρᵢ, cᵢ, Fᵢ(X), Aᵢ, Yᵢ, Nᵢ, sᵢ, tᵢ, uᵢ)
+	Commitment hash.Commitment
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+// - save commitment Vⱼ.
+func (r *round2) StoreBroadcastMessage(msg round.Message) error {
+	body, ok := msg.Content.(*broadcast2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if err := body.Commitment.Validate(); err != nil {
+		return err
+	}
+	r.Commitments[msg.From] = body.Commitment
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round2) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round2) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - send all committed data.
+func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.BroadcastMessage(out, r.Message3); err != nil {
+		return r, err
+	}
+	return &round3{
+		round2: r,
+	}, nil
+}
+
+// store saves the data revealed by party j in round 3, depending on its role.
+func (r *round2) store(j party.ID, body *broadcast3) {
+	r.RIDs[j] = body.RID
+	if r.isDealer(j) {
+		r.ChainKeys[j] = body.C
+		r.VSSPolynomials[j] = body.VSSPolynomial
+	}
+	if r.isReceiver(j) {
+		rec := body.Receiver
+		r.PaillierPublic[j] = paillier.NewPublicKey(rec.N)
+		r.Pedersen[j] = pedersen.New(arith.ModulusFromN(rec.N), rec.S, rec.T)
+		r.ElGamalPublic[j] = rec.ElGamalPublic
+		r.SchnorrCommitments[j] = rec.SchnorrCommitments
+	}
+}
+
+// MessageContent implements round.Round.
+func (round2) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast2) RoundNumber() round.Number { return 2 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (round2) BroadcastContent() round.BroadcastContent { return &broadcast2{} }
+
+// Number implements round.Round.
+func (round2) Number() round.Number { return 2 }
@@ -0,0 +1,260 @@
+package reshare
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/pedersen"
+	zkfac "github.com/taurusgroup/multi-party-sig/pkg/zk/fac"
+	zkmod "github.com/taurusgroup/multi-party-sig/pkg/zk/mod"
+	zkprm "github.com/taurusgroup/multi-party-sig/pkg/zk/prm"
+	zksch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+)
+
+var _ round.Round = (*round3)(nil)
+
+type round3 struct {
+	*round2
+}
+
+// broadcast3 contains the data committed to in round 1.
+// Fields which do not correspond to the role of the sender are nil.
+type broadcast3 struct {
+	round.NormalBroadcastContent
+	// RID = RIDᵢ
+	RID types.RID
+	// C = cᵢ, the previous chain key, set by dealers.
+	C types.RID
+	// VSSPolynomial = Fᵢ(X), set by dealers.
+	VSSPolynomial *polynomial.Exponent
+	// Receiver is set by receivers.
+	Receiver *receiver3
+	// Decommitment = uᵢ decommitment bytes
+	Decommitment hash.Decommitment
+}
+
+// receiver3 contains the auxiliary data generated by a receiver.
+type receiver3 struct {
+	// SchnorrCommitments = Aᵢ Schnorr commitment for the final confirmation
+	SchnorrCommitments *zksch.Commitment
+	// ElGamalPublic = Yᵢ
+	ElGamalPublic curve.Point
+	// N Paillier and Pedersen N = p•q, p ≡ q ≡ 3 mod 4
+	N *saferith.Modulus
+	// S = r² mod N
+	S *saferith.Nat
+	// T = Sˡ mod N
+	T *saferith.Nat
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - if the sender is a dealer:
+//   - verify degree of VSS polynomial Fⱼ "in-the-exponent"
+//   - if we hold the previous config, verify Fⱼ(0) = λⱼ⋅X'ⱼ and cⱼ = c'
+//
+// - if the sender is a receiver:
+//   - validate Paillier
+//   - validate Pedersen
+//
+// - validate commitments.
+// - store ridⱼ, cⱼ, Fⱼ(X), Nⱼ, Sⱼ, Tⱼ, Aⱼ.
+func (r *round3) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcast3)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+
+	// check RID length
+	if err := body.RID.Validate(); err != nil {
+		return fmt.Errorf("rid: %w", err)
+	}
+	// check decommitment
+	if err := body.Decommitment.Validate(); err != nil {
+		return err
+	}
+
+	if r.isDealer(from) {
+		if body.VSSPolynomial == nil {
+			return round.ErrNilFields
+		}
+		if err := body.C.Validate(); err != nil {
+			return fmt.Errorf("chainkey: %w", err)
+		}
+		// the constant coefficient is a share of the secret, and cannot be 0
+		if body.VSSPolynomial.IsConstant {
+			return errors.New("vss polynomial has incorrect constant")
+		}
+		// check deg(Fⱼ) = t
+		if body.VSSPolynomial.Degree() != r.Threshold() {
+			return errors.New("vss polynomial has incorrect degree")
+		}
+		if r.PreviousPublicSharesECDSA != nil {
+			if !body.VSSPolynomial.Constant().Equal(r.PreviousPublicSharesECDSA[from]) {
+				return errors.New("vss polynomial does not share the previous secret")
+			}
+			if !bytes.Equal(body.C, r.PreviousChainKey) {
+				return errors.New("chain key is different from the previous one")
+			}
+		}
+	}
+
+	if r.isReceiver(from) {
+		rec := body.Receiver
+		if rec == nil || rec.N == nil || rec.S == nil || rec.T == nil || rec.SchnorrCommitments == nil || rec.ElGamalPublic == nil {
+			return round.ErrNilFields
+		}
+		// Set Paillier
+		if err := paillier.ValidateN(rec.N); err != nil {
+			return err
+		}
+		// Verify Pedersen
+		if err := pedersen.ValidateParameters(rec.N, rec.S, rec.T); err != nil {
+			return err
+		}
+	}
+
+	// Verify decommit
+	if !r.HashForID(from).Decommit(r.Commitments[from], body.Decommitment, r.commitData(from, body)...) {
+		return errors.New("failed to decommit")
+	}
+
+	r.store(from, body)
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round3) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round3) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - check that all dealers agree on the chain key
+// - set F(X) = ∑ⱼ Fⱼ(X) and check F(0) = X
+// - set rid = ⊕ⱼ ridⱼ and update hash state
+// - if receiver, prove Nᵢ is Blum, prove Pedersen parameters, and prove zkfac to other receivers
+// - if dealer, send encryption of share to all receivers.
+func (r *round3) Finalize(out chan<- *round.Message) (round.Session, error) {
+	// c = cⱼ
+	var chainKey types.RID
+	for _, j := range r.OldSigners {
+		if chainKey == nil {
+			chainKey = r.ChainKeys[j]
+		} else if !bytes.Equal(chainKey, r.ChainKeys[j]) {
+			return r.AbortRound(errors.New("dealers sent different chain keys")), nil
+		}
+	}
+
+	// ShamirPublicPolynomial = F(X) = ∑Fⱼ(X)
+	ShamirPublicPolynomials := make([]*polynomial.Exponent, 0, len(r.OldSigners))
+	for _, j := range r.OldSigners {
+		ShamirPublicPolynomials = append(ShamirPublicPolynomials, r.VSSPolynomials[j])
+	}
+	ShamirPublicPolynomial, err := polynomial.Sum(ShamirPublicPolynomials)
+	if err != nil {
+		return r, err
+	}
+	// F(0) == X
+	if !ShamirPublicPolynomial.Constant().Equal(r.PublicKey) {
+		return r.AbortRound(errors.New("reshared secret does not correspond to the public key")), nil
+	}
+
+	// RID = ⊕ⱼ RIDⱼ
+	rid := types.EmptyRID()
+	for _, j := range r.PartyIDs() {
+		rid.XOR(r.RIDs[j])
+	}
+
+	// temporary hash which does not modify the state
+	h := r.Hash()
+	_ = h.WriteAny(rid, r.SelfID())
+
+	isReceiver := r.isReceiver(r.SelfID())
+
+	msg4 := &broadcast4{}
+	if isReceiver {
+		// Prove N is a blum prime with zkmod
+		msg4.Mod = zkmod.NewProof(r.Rand(), h.Clone(), zkmod.Private{
+			P:   r.PaillierSecret.P(),
+			Q:   r.PaillierSecret.Q(),
+			Phi: r.PaillierSecret.Phi(),
+		}, zkmod.Public{N: r.PaillierPublic[r.SelfID()].N()}, r.Pool)
+
+		// prove s, t are correct as aux parameters with zkprm
+		msg4.Prm = zkprm.NewProof(r.Rand(), zkprm.Private{
+			Lambda: r.PedersenSecret,
+			Phi:    r.PaillierSecret.Phi(),
+			P:      r.PaillierSecret.P(),
+			Q:      r.PaillierSecret.Q(),
+		}, h.Clone(), zkprm.Public{Aux: r.Pedersen[r.SelfID()]}, r.Pool)
+	}
+	if err = r.BroadcastMessage(out, msg4); err != nil {
+		return r, err
+	}
+
+	// create P2P messages with encrypted shares and zkfac proof
+	for _, j := range r.OtherPartyIDs() {
+		msg := &message4{}
+		if r.isReceiver(j) {
+			if isReceiver {
+				// Prove that the factors of N are relatively large
+				msg.Fac = zkfac.NewProof(r.Rand(), zkfac.Private{P: r.PaillierSecret.P(), Q: r.PaillierSecret.Q()}, h.Clone(), zkfac.Public{
+					N:   r.PaillierPublic[r.SelfID()].N(),
+					Aux: r.Pedersen[j],
+				})
+			}
+			if r.isDealer(r.SelfID()) {
+				// compute fᵢ(j)
+				share := r.VSSSecret.Evaluate(j.Scalar(r.Group()))
+				// Encrypt share
+				msg.Share, _ = r.PaillierPublic[j].Enc(r.Rand(), curve.MakeInt(share))
+			}
+		}
+		if err = r.SendMessage(out, msg, j); err != nil {
+			return r, err
+		}
+	}
+
+	// the shares have been sent, we no longer need the dealt secret
+	r.VSSSecret = nil
+
+	// Write rid to the hash state
+	r.UpdateHashState(rid)
+	return &round4{
+		round3:                 r,
+		RID:                    rid,
+		ChainKey:               chainKey,
+		ShamirPublicPolynomial: ShamirPublicPolynomial,
+	}, nil
+}
+
+// MessageContent implements round.Round.
+func (round3) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast3) RoundNumber() round.Number { return 3 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *round3) BroadcastContent() round.BroadcastContent {
+	return &broadcast3{
+		VSSPolynomial: polynomial.EmptyExponent(r.Group()),
+		Receiver: &receiver3{
+			SchnorrCommitments: zksch.EmptyCommitment(r.Group()),
+			ElGamalPublic:      r.Group().NewPoint(),
+		},
+	}
+}
+
+// Number implements round.Round.
+func (round3) Number() round.Number { return 3 }
@@ -0,0 +1,221 @@
+package reshare
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	zkfac "github.com/taurusgroup/multi-party-sig/pkg/zk/fac"
+	zkmod "github.com/taurusgroup/multi-party-sig/pkg/zk/mod"
+	zkprm "github.com/taurusgroup/multi-party-sig/pkg/zk/prm"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+)
+
+var _ round.Round = (*round4)(nil)
+
+type round4 struct {
+	*round3
+
+	// RID = ⊕ⱼ RIDⱼ
+	// Random ID generated by taking the XOR of all ridᵢ
+	RID types.RID
+	// ChainKey is the chain key of the previous config, which is kept as is.
+	ChainKey types.RID
+	// ShamirPublicPolynomial = F(X) = ∑ⱼ Fⱼ(X), over all dealers j
+	ShamirPublicPolynomial *polynomial.Exponent
+}
+
+type message4 struct {
+	// Share = Encⱼ(fᵢ(j)) is the encryption of the receivers share, set if sent from a dealer to a receiver.
+	Share *paillier.Ciphertext
+	// Fac is set if sent from a receiver to a receiver.
+	Fac *zkfac.Proof
+}
+
+type broadcast4 struct {
+	round.NormalBroadcastContent
+	// Mod and Prm are set if sent by a receiver.
+	Mod *zkmod.Proof
+	Prm *zkprm.Proof
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - if the sender is a receiver, verify Mod, Prm proof for N.
+func (r *round4) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcast4)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+
+	if !r.isReceiver(from) {
+		return nil
+	}
+	if body.Mod == nil || body.Prm == nil {
+		return round.ErrNilFields
+	}
+
+	// verify zkmod
+	if !body.Mod.Verify(zkmod.Public{N: r.Pedersen[from].N()}, r.HashForID(from), r.Pool) {
+		return errors.New("failed to validate mod proof")
+	}
+
+	// verify zkprm
+	if !body.Prm.Verify(zkprm.Public{Aux: r.Pedersen[from]}, r.HashForID(from), r.Pool) {
+		return errors.New("failed to validate prm proof")
+	}
+
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+//
+// - if we are a receiver and the sender a dealer, verify validity of share ciphertext.
+// - if we are both receivers, verify zkfac.
+func (r *round4) VerifyMessage(msg round.Message) error {
+	from, to := msg.From, r.SelfID()
+	body, ok := msg.Content.(*message4)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+
+	if !r.isReceiver(to) {
+		return nil
+	}
+
+	if r.isDealer(from) {
+		if body.Share == nil {
+			return round.ErrNilFields
+		}
+		if !r.PaillierPublic[to].ValidateCiphertexts(body.Share) {
+			return errors.New("invalid ciphertext")
+		}
+	}
+
+	if r.isReceiver(from) {
+		if body.Fac == nil {
+			return round.ErrNilFields
+		}
+		// verify zkfac
+		if !body.Fac.Verify(zkfac.Public{N: r.PaillierPublic[from].N(), Aux: r.Pedersen[to]}, r.HashForID(from)) {
+			return errors.New("failed to validate fac proof")
+		}
+	}
+
+	return nil
+}
+
+// StoreMessage implements round.Round.
+//
+// Since this message is only intended for us, we need to do the VSS verification here.
+// - check that the decrypted share did not overflow.
+// - check VSS condition.
+// - save share.
+func (r *round4) StoreMessage(msg round.Message) error {
+	from, body := msg.From, msg.Content.(*message4)
+	if !r.isReceiver(r.SelfID()) || !r.isDealer(from) {
+		return nil
+	}
+
+	// decrypt share
+	DecryptedShare, err := r.PaillierSecret.Dec(body.Share)
+	if err != nil {
+		return err
+	}
+	Share := r.Group().NewScalar().SetNat(DecryptedShare.Mod(r.Group().Order()))
+	if DecryptedShare.Eq(curve.MakeInt(Share)) != 1 {
+		return errors.New("decrypted share is not in correct range")
+	}
+
+	// verify share with VSS
+	ExpectedPublicShare := r.VSSPolynomials[from].Evaluate(r.SelfID().Scalar(r.Group())) // Fⱼ(i)
+	PublicShare := Share.ActOnBase()
+	// X == Fⱼ(i)
+	if !PublicShare.Equal(ExpectedPublicShare) {
+		return errors.New("failed to validate VSS share")
+	}
+
+	r.ShareReceived[from] = Share
+	return nil
+}
+
+// Finalize implements round.Round
+//
+// - compute the public key shares of all receivers
+// - if receiver:
+//   - sum of all received shares
+//   - create the new config
+//   - create proof of knowledge of secret.
+//
+// - write new config to hash state.
+func (r *round4) Finalize(out chan<- *round.Message) (round.Session, error) {
+	// compute the new public key share Xⱼ = F(j)
+	PublicData := make(map[party.ID]*config.Public, len(r.NewParties))
+	for _, j := range r.NewParties {
+		PublicData[j] = &config.Public{
+			ECDSA:    r.ShamirPublicPolynomial.Evaluate(j.Scalar(r.Group())),
+			ElGamal:  r.ElGamalPublic[j],
+			Paillier: r.PaillierPublic[j],
+			Pedersen: r.Pedersen[j],
+		}
+	}
+
+	UpdatedConfig := &config.Config{
+		Group:     r.Group(),
+		ID:        r.SelfID(),
+		Threshold: r.Threshold(),
+		RID:       r.RID.Copy(),
+		ChainKey:  r.ChainKey.Copy(),
+		Public:    PublicData,
+	}
+
+	msg := &broadcast5{}
+	if r.isReceiver(r.SelfID()) {
+		// add all shares to our secret
+		UpdatedSecretECDSA := r.Group().NewScalar()
+		for _, j := range r.OldSigners {
+			UpdatedSecretECDSA.Add(r.ShareReceived[j])
+		}
+		UpdatedConfig.ECDSA = UpdatedSecretECDSA
+		UpdatedConfig.ElGamal = r.ElGamalSecret
+		UpdatedConfig.Paillier = r.PaillierSecret
+
+		// write new ssid to hash, to bind the Schnorr proof to this new config
+		// Write SSID, selfID to temporary hash
+		h := r.Hash()
+		_ = h.WriteAny(UpdatedConfig, r.SelfID())
+
+		msg.SchnorrResponse = r.SchnorrRand.Prove(h, PublicData[r.SelfID()].ECDSA, UpdatedSecretECDSA, nil)
+	}
+
+	// send to all
+	if err := r.BroadcastMessage(out, msg); err != nil {
+		return r, err
+	}
+
+	r.UpdateHashState(UpdatedConfig)
+	return &round5{
+		round4:        r,
+		UpdatedConfig: UpdatedConfig,
+	}, nil
+}
+
+// RoundNumber implements round.Content.
+func (message4) RoundNumber() round.Number { return 4 }
+
+// MessageContent implements round.Round.
+func (round4) MessageContent() round.Content { return &message4{} }
+
+// RoundNumber implements round.Content.
+func (broadcast4) RoundNumber() round.Number { return 4 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (round4) BroadcastContent() round.BroadcastContent { return &broadcast4{} }
+
+// Number implements round.Round.
+func (round4) Number() round.Number { return 4 }
@@ -0,0 +1,81 @@
+package reshare
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	sch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+)
+
+var _ round.Round = (*round5)(nil)
+
+type round5 struct {
+	*round4
+	// UpdatedConfig is the new config, which only contains the secrets if we are a receiver.
+	UpdatedConfig *config.Config
+}
+
+type broadcast5 struct {
+	round.NormalBroadcastContent
+	// SchnorrResponse is the Schnorr proof of knowledge of the new secret share, set by receivers.
+	SchnorrResponse *sch.Response
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - verify all Schnorr proof for the new ecdsa share.
+func (r *round5) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcast5)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+
+	if !r.isReceiver(from) {
+		return nil
+	}
+
+	if !body.SchnorrResponse.IsValid() {
+		return round.ErrNilFields
+	}
+
+	if !body.SchnorrResponse.Verify(r.HashForID(from),
+		r.UpdatedConfig.Public[from].ECDSA,
+		r.SchnorrCommitments[from], nil) {
+		return errors.New("failed to validate schnorr proof for received share")
+	}
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round5) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (r *round5) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round.
+//
+// Receivers output the new config, other parties output the public key.
+func (r *round5) Finalize(chan<- *round.Message) (round.Session, error) {
+	if !r.isReceiver(r.SelfID()) {
+		return r.ResultRound(r.PublicKey), nil
+	}
+	return r.ResultRound(r.UpdatedConfig), nil
+}
+
+// MessageContent implements round.Round.
+func (r *round5) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast5) RoundNumber() round.Number { return 5 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *round5) BroadcastContent() round.BroadcastContent {
+	return &broadcast5{
+		SchnorrResponse: sch.EmptyResponse(r.Group()),
+	}
+}
+
+// Number implements round.Round.
+func (round5) Number() round.Number { return 5 }
@@ -0,0 +1,121 @@
+package sign
+
+import (
+	"errors"
+
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/internal/abort"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var _ round.Round = (*abort1)(nil)
+
+// abort1 replaces round5 when Δ is inconsistent with [δ]G.
+// Every party reveals γᵢ, kᵢ and the αᵢⱼ it decrypted, so that all δⱼ can be recomputed.
+type abort1 struct {
+	*round4
+	GammaShares map[party.ID]*saferith.Int
+	KShares     map[party.ID]*saferith.Int
+	// DeltaAlphas[j][k] = αⱼₖ
+	DeltaAlphas map[party.ID]map[party.ID]*saferith.Int
+}
+
+type broadcastAbort1 struct {
+	round.NormalBroadcastContent
+	// GammaShare = γᵢ
+	GammaShare  *saferith.Int
+	KProof      *abort.Nth
+	DeltaProofs map[party.ID]*abort.Nth
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - verify that γⱼ is consistent with Γⱼ,
+// - verify the decryption of Kⱼ and of all Dⱼₖ.
+func (r *abort1) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcastAbort1)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.GammaShare == nil || body.DeltaProofs == nil {
+		return round.ErrNilFields
+	}
+
+	public := r.Paillier[from]
+	if !body.KProof.Verify(r.HashForID(from), public, r.K[from]) {
+		return errors.New("failed to verify validity of k")
+	}
+
+	BigGammaShareActual := r.Group().NewScalar().SetNat(body.GammaShare.Mod(r.Group().Order())).ActOnBase()
+	if !r.BigGammaShare[from].Equal(BigGammaShareActual) {
+		return errors.New("different BigGammaShare")
+	}
+
+	alphas := make(map[party.ID]*saferith.Int, r.N()-1)
+	for _, id := range r.PartyIDs() {
+		if id == from {
+			continue
+		}
+		deltaProof := body.DeltaProofs[id]
+		if !deltaProof.Verify(r.HashForID(from), public, r.DeltaCiphertext[id][from]) {
+			return errors.New("failed to validate Delta MtA Nth proof")
+		}
+		alphas[id] = deltaProof.Plaintext
+	}
+
+	r.DeltaAlphas[from] = alphas
+	r.GammaShares[from] = body.GammaShare
+	r.KShares[from] = body.KProof.Plaintext
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (abort1) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (abort1) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - recompute δⱼ = kⱼγⱼ + ∑ₗ (αⱼₗ + kₗγⱼ - αₗⱼ) and compare it to the broadcast value.
+func (r *abort1) Finalize(chan<- *round.Message) (round.Session, error) {
+	var (
+		culprits   []party.ID
+		delta, tmp saferith.Int
+	)
+	for _, j := range r.OtherPartyIDs() {
+		delta.Mul(r.KShares[j], r.GammaShares[j], -1)
+		for _, l := range r.PartyIDs() {
+			if l == j {
+				continue
+			}
+			delta.Add(&delta, r.DeltaAlphas[j][l], -1)
+			tmp.Mul(r.KShares[l], r.GammaShares[j], -1)
+			delta.Add(&delta, &tmp, -1)
+			tmp.SetInt(r.DeltaAlphas[l][j]).Neg(1)
+			delta.Add(&delta, &tmp, -1)
+		}
+		deltaScalar := r.Group().NewScalar().SetNat(delta.Mod(r.Group().Order()))
+		if !deltaScalar.Equal(r.DeltaShares[j]) {
+			culprits = append(culprits, j)
+		}
+	}
+	if len(culprits) == 0 {
+		return r.AbortRound(errors.New("abort1: failed to identify a culprit")), nil
+	}
+	return r.AbortRound(errors.New("abort1: detected culprit"), culprits...), nil
+}
+
+// MessageContent implements round.Round.
+func (abort1) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcastAbort1) RoundNumber() round.Number { return 5 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *abort1) BroadcastContent() round.BroadcastContent { return &broadcastAbort1{} }
+
+// Number implements round.Round.
+func (abort1) Number() round.Number { return 5 }
@@ -0,0 +1,158 @@
+package sign
+
+import (
+	"errors"
+
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	zkdec "github.com/taurusgroup/multi-party-sig/pkg/zk/dec"
+	zkmulstar "github.com/taurusgroup/multi-party-sig/pkg/zk/mulstar"
+)
+
+var _ round.Round = (*abort2)(nil)
+
+// abort2 follows round5 when the signature obtained from the σⱼ is invalid.
+// Every party proves that its σᵢ is the decryption of Uᵢ = Encᵢ(m⋅kᵢ + r⋅χᵢ),
+// which anyone can compute homomorphically from the ciphertexts exchanged in round 3.
+type abort2 struct {
+	*round5
+	// H[j] = Ĥⱼ = (xⱼ ⊙ Kⱼ) ⊕ Encⱼ(0)
+	H map[party.ID]*paillier.Ciphertext
+	// DecryptedSigmaShares[j] = Decⱼ(Uⱼ) (mod q)
+	DecryptedSigmaShares map[party.ID]curve.Scalar
+}
+
+type broadcastAbort2 struct {
+	round.NormalBroadcastContent
+	// H = Ĥᵢ = (xᵢ ⊙ Kᵢ) ⊕ Encᵢ(0)
+	H *paillier.Ciphertext
+	// SigmaShare = Decᵢ(Uᵢ) (mod q)
+	SigmaShare curve.Scalar
+}
+
+type messageAbort2 struct {
+	// ProofMul proves that Ĥᵢ encrypts kᵢ⋅xᵢ
+	ProofMul *zkmulstar.Proof
+	// ProofDec proves that Uᵢ decrypts to SigmaShare
+	ProofDec *zkdec.Proof
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - store Ĥⱼ, Decⱼ(Uⱼ).
+func (r *abort2) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcastAbort2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.H == nil || body.SigmaShare == nil {
+		return round.ErrNilFields
+	}
+	if !r.Paillier[from].ValidateCiphertexts(body.H) {
+		return errors.New("received invalid ciphertext")
+	}
+
+	r.H[from] = body.H
+	r.DecryptedSigmaShares[from] = body.SigmaShare
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+//
+// - verify Π(mul*)(Kⱼ, Ĥⱼ, Xⱼ) and Π(dec)(Uⱼ, Decⱼ(Uⱼ)).
+func (r *abort2) VerifyMessage(msg round.Message) error {
+	from, to := msg.From, msg.To
+	body, ok := msg.Content.(*messageAbort2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+
+	if !body.ProofMul.Verify(r.Group(), r.HashForID(from), zkmulstar.Public{
+		C:        r.K[from],
+		D:        r.H[from],
+		X:        r.ECDSA[from],
+		Verifier: r.Paillier[from],
+		Aux:      r.Pedersen[to],
+	}) {
+		return errors.New("failed to validate mul* proof")
+	}
+
+	if !body.ProofDec.Verify(r.HashForID(from), zkdec.Public{
+		C:      r.sigmaCiphertext(from, r.H[from]),
+		X:      r.DecryptedSigmaShares[from],
+		Prover: r.Paillier[from],
+		Aux:    r.Pedersen[to],
+	}) {
+		return errors.New("failed to validate dec proof")
+	}
+	return nil
+}
+
+// StoreMessage implements round.Round.
+func (abort2) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - compare the σⱼ received in round 5 with Decⱼ(Uⱼ).
+func (r *abort2) Finalize(chan<- *round.Message) (round.Session, error) {
+	var culprits []party.ID
+	for _, j := range r.OtherPartyIDs() {
+		if !r.DecryptedSigmaShares[j].Equal(r.SigmaShares[j]) {
+			culprits = append(culprits, j)
+		}
+	}
+	if len(culprits) == 0 {
+		return r.AbortRound(errors.New("abort2: failed to identify a culprit")), nil
+	}
+	return r.AbortRound(errors.New("abort2: detected culprit"), culprits...), nil
+}
+
+// sigmaCiphertext returns Uⱼ = Encⱼ(m⋅kⱼ + r⋅χⱼ), given Ĥⱼ = Encⱼ(kⱼ⋅xⱼ).
+//
+// Uⱼ = (m ⊙ Kⱼ) ⊕ r ⊙ (Ĥⱼ ⊕ ∑ₗ (D̂ⱼₗ ⊖ F̂ₗⱼ)).
+func (r *round5) sigmaCiphertext(j party.ID, H *paillier.Ciphertext) *paillier.Ciphertext {
+	public := r.Paillier[j]
+	minusOne := new(saferith.Int).SetUint64(1).Neg(1)
+
+	// Encⱼ(χⱼ) = Ĥⱼ ⊕ ∑ₗ (D̂ⱼₗ ⊖ F̂ₗⱼ)
+	Chi := H.Clone()
+	for _, l := range r.PartyIDs() {
+		if l == j {
+			continue
+		}
+		Chi.Add(public, r.ChiCiphertext[l][j])
+		Chi.Add(public, r.ChiF[j][l].Clone().Mul(public, minusOne))
+	}
+
+	m := curve.MakeInt(curve.FromHash(r.Group(), r.Message))
+	U := r.K[j].Clone().Mul(public, m)
+	return U.Add(public, Chi.Mul(public, curve.MakeInt(r.R)))
+}
+
+// MessageContent implements round.Round.
+func (r *abort2) MessageContent() round.Content {
+	return &messageAbort2{
+		ProofMul: zkmulstar.Empty(r.Group()),
+		ProofDec: zkdec.Empty(r.Group()),
+	}
+}
+
+// RoundNumber implements round.Content.
+func (messageAbort2) RoundNumber() round.Number { return 6 }
+
+// RoundNumber implements round.Content.
+func (broadcastAbort2) RoundNumber() round.Number { return 6 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *abort2) BroadcastContent() round.BroadcastContent {
+	return &broadcastAbort2{
+		SigmaShare: r.Group().NewScalar(),
+	}
+}
+
+// Number implements round.Round.
+func (abort2) Number() round.Number { return 6 }
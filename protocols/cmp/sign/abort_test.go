@@ -0,0 +1,120 @@
+package sign
+
+import (
+	mrand "math/rand"
+	"testing"
+
+	"github.com/cronokirby/saferith"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"golang.org/x/crypto/sha3"
+)
+
+// culprit is the party that deviates from the protocol in TestRoundFail.
+const culprit party.ID = "a"
+
+type TestRule struct {
+	AfterFinalize func(rNext round.Session)
+	BeforeSend    func(rNext round.Session, to party.ID, content round.Content)
+}
+
+func (tr *TestRule) ModifyBefore(round.Session) {}
+
+func (tr *TestRule) ModifyAfter(rNext round.Session) {
+	if rNext.SelfID() != culprit {
+		return
+	}
+	if tr.AfterFinalize != nil {
+		tr.AfterFinalize(rNext)
+	}
+}
+
+func (tr *TestRule) ModifyContent(rNext round.Session, to party.ID, content round.Content) {
+	if rNext.SelfID() != culprit {
+		return
+	}
+	if tr.BeforeSend != nil {
+		tr.BeforeSend(rNext, to, content)
+	}
+}
+
+func addOne(s curve.Scalar) curve.Scalar {
+	one := s.Curve().NewScalar().SetNat(new(saferith.Nat).SetUint64(1))
+	return s.Curve().NewScalar().Set(s).Add(one)
+}
+
+func TestRoundFail(t *testing.T) {
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+
+	N := 3
+	configs, partyIDs := test.GenerateConfig(curve.Secp256k1{}, N, N-1, mrand.New(mrand.NewSource(1)), pl)
+
+	messageHash := make([]byte, 64)
+	sha3.ShakeSum128(messageHash, []byte("hello"))
+
+	tests := []struct {
+		name string
+		r    TestRule
+	}{
+		{
+			"round 3 add one to delta share",
+			TestRule{
+				AfterFinalize: func(rNext round.Session) {
+					if r, ok := rNext.(*round4); ok {
+						r.DeltaShares[r.SelfID()] = addOne(r.DeltaShares[r.SelfID()])
+					}
+				},
+				BeforeSend: func(_ round.Session, _ party.ID, content round.Content) {
+					if c, ok := content.(*broadcast4); ok {
+						c.DeltaShare = addOne(c.DeltaShare)
+					}
+				},
+			},
+		},
+		{
+			"round 4 add one to sigma share",
+			TestRule{
+				AfterFinalize: func(rNext round.Session) {
+					if r, ok := rNext.(*round5); ok {
+						r.SigmaShares[r.SelfID()] = addOne(r.SigmaShares[r.SelfID()])
+					}
+				},
+				BeforeSend: func(_ round.Session, _ party.ID, content round.Content) {
+					if c, ok := content.(*broadcast5); ok {
+						c.SigmaShare = addOne(c.SigmaShare)
+					}
+				},
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			rounds := make([]round.Session, 0, N)
+			for _, id := range partyIDs {
+				r, err := StartSign(configs[id], partyIDs, messageHash, pl)(nil)
+				require.NoError(t, err)
+				rounds = append(rounds, r)
+			}
+			for {
+				err, done := test.Rounds(rounds, &testCase.r)
+				require.NoError(t, err, "failed to process round")
+				if done {
+					break
+				}
+			}
+			for _, r := range rounds {
+				require.IsType(t, &round.Abort{}, r, "expected abort round")
+				if r.SelfID() == culprit {
+					continue
+				}
+				assert.Equal(t, []party.ID{culprit}, r.(*round.Abort).Culprits)
+			}
+		})
+	}
+}
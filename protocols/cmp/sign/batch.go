@@ -0,0 +1,78 @@
+package sign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+)
+
+const protocolSignBatchID = "cmp/sign-batch"
+
+// StartSignBatch signs each of the messages in a single execution.
+//
+// Each message is signed by its own signing execution, and all executions advance together in a round.Batch,
+// so that the batch requires as many rounds and messages as a single signature.
+// The executions are finalized in parallel on pl.
+func StartSignBatch(config *config.Config, signers []party.ID, messages [][]byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if len(messages) == 0 {
+			return nil, errors.New("sign.Create: no messages")
+		}
+		if config.Group.NewBasePoint().XScalar() == nil {
+			return nil, fmt.Errorf("sign.Create: group %s does not support ECDSA", config.Group.Name())
+		}
+		auxInfo := []hash.WriterToWithDomain{config}
+		for _, message := range messages {
+			if len(message) == 0 {
+				return nil, errors.New("sign.Create: message is nil")
+			}
+			auxInfo = append(auxInfo, types.SigningMessage(message))
+		}
+
+		info := round.Info{
+			ProtocolID:       protocolSignBatchID,
+			FinalRoundNumber: protocolSignRounds,
+			SelfID:           config.ID,
+			PartyIDs:         signers,
+			Threshold:        config.Threshold,
+			Group:            config.Group,
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, pl, auxInfo...)
+		if err != nil {
+			return nil, fmt.Errorf("sign.Create: %w", err)
+		}
+
+		if !config.CanSign(helper.PartyIDs()) {
+			return nil, errors.New("sign.Create: signers is not a valid signing subset")
+		}
+
+		single := info
+		single.ProtocolID = protocolSignID
+		helpers, err := round.NewBatchHelpers(helper, single, len(messages))
+		if err != nil {
+			return nil, fmt.Errorf("sign.Create: %w", err)
+		}
+		sessions := make([]round.Session, len(messages))
+		for i, h := range helpers {
+			sessions[i] = newRound1(config, h, messages[i])
+		}
+
+		return round.NewBatch(helper, sessions, func(results []interface{}) interface{} {
+			signatures := make([]*ecdsa.Signature, len(results))
+			for i, result := range results {
+				signatures[i] = result.(*ecdsa.Signature)
+			}
+			return signatures
+		}), nil
+	}
+}
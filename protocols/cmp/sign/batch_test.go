@@ -0,0 +1,67 @@
+package sign
+
+import (
+	mrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestSignBatch(t *testing.T) {
+	group := curve.Secp256k1{}
+	N := 3
+	T := N - 1
+
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+	configs, partyIDs := test.GenerateConfig(group, N, T, mrand.New(mrand.NewSource(1)), pl)
+	publicPoint := configs[partyIDs[0]].PublicPoint()
+
+	messageHashes := make([][]byte, 3)
+	for i := range messageHashes {
+		messageHashes[i] = make([]byte, 64)
+		sha3.ShakeSum128(messageHashes[i], []byte{byte(i)})
+	}
+
+	rounds := make([]round.Session, 0, N)
+	for _, partyID := range partyIDs {
+		// each party finalizes its batch on its own pool
+		partyPool := pool.NewPool(1)
+		defer partyPool.TearDown()
+		r, err := StartSignBatch(configs[partyID], partyIDs, messageHashes, partyPool)(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+
+	for {
+		for _, r := range rounds {
+			// a broadcast round of the batch is reliable if the round of sign is, as for round2
+			if b, ok := r.(*round.BroadcastBatch); ok {
+				assert.Equal(t, r.Number() == 2, b.BroadcastContent().Reliable())
+			}
+		}
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r, "expected result round")
+		signatures, ok := r.(*round.Output).Result.([]*ecdsa.Signature)
+		require.True(t, ok, "result should be []*ecdsa.Signature")
+		require.Len(t, signatures, len(messageHashes))
+		for i, signature := range signatures {
+			assert.True(t, signature.Verify(publicPoint, messageHashes[i]), "signature %d should be valid", i)
+			assert.False(t, signature.Verify(publicPoint, messageHashes[(i+1)%len(messageHashes)]))
+		}
+	}
+}
@@ -1,8 +1,6 @@
 package sign
 
 import (
-	"crypto/rand"
-
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
@@ -51,14 +49,14 @@ func (round1) StoreMessage(round.Message) error { return nil }
 func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
 	// γᵢ <- 𝔽,
 	// Γᵢ = [γᵢ]⋅G
-	GammaShare, BigGammaShare := sample.ScalarPointPair(rand.Reader, r.Group())
+	GammaShare, BigGammaShare := sample.ScalarPointPair(r.Rand(), r.Group())
 	// Gᵢ = Encᵢ(γᵢ;νᵢ)
-	G, GNonce := r.Paillier[r.SelfID()].Enc(curve.MakeInt(GammaShare))
+	G, GNonce := r.Paillier[r.SelfID()].Enc(r.Rand(), curve.MakeInt(GammaShare))
 
 	// kᵢ <- 𝔽,
-	KShare := sample.Scalar(rand.Reader, r.Group())
+	KShare := sample.Scalar(r.Rand(), r.Group())
 	// Kᵢ = Encᵢ(kᵢ;ρᵢ)
-	K, KNonce := r.Paillier[r.SelfID()].Enc(curve.MakeInt(KShare))
+	K, KNonce := r.Paillier[r.SelfID()].Enc(r.Rand(), curve.MakeInt(KShare))
 
 	otherIDs := r.OtherPartyIDs()
 	broadcastMsg := broadcast2{K: K, G: G}
@@ -67,7 +65,7 @@ func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
 	}
 	errors := r.Pool.Parallelize(len(otherIDs), func(i int) interface{} {
 		j := otherIDs[i]
-		proof := zkenc.NewProof(r.Group(), r.HashForID(r.SelfID()), zkenc.Public{
+		proof := zkenc.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()), zkenc.Public{
 			K:      K,
 			Prover: r.Paillier[r.SelfID()],
 			Aux:    r.Pedersen[j],
@@ -9,6 +9,7 @@ import (
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	zkaffg "github.com/taurusgroup/multi-party-sig/pkg/zk/affg"
 	zkenc "github.com/taurusgroup/multi-party-sig/pkg/zk/enc"
 	zklogstar "github.com/taurusgroup/multi-party-sig/pkg/zk/logstar"
 )
@@ -102,31 +103,34 @@ func (round2) StoreMessage(round.Message) error { return nil }
 
 // Finalize implements round.Round
 //
-// - compute Hash(ssid, K₁, G₁, …, Kₙ, Gₙ).
+// - compute MtA for δ and χ with every other party,
+// - broadcast the resulting ciphertexts, so that they can be used to identify a culprit in case of an abort.
 func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
-	if err := r.BroadcastMessage(out, &broadcast3{
-		BigGammaShare: r.BigGammaShare[r.SelfID()],
-	}); err != nil {
-		return r, err
-	}
-
 	otherIDs := r.OtherPartyIDs()
+	n := len(otherIDs)
+
 	type mtaOut struct {
-		err       error
-		DeltaBeta *saferith.Int
-		ChiBeta   *saferith.Int
+		DeltaBeta  *saferith.Int
+		DeltaD     *paillier.Ciphertext
+		DeltaF     *paillier.Ciphertext
+		DeltaProof *zkaffg.Proof
+		ChiBeta    *saferith.Int
+		ChiD       *paillier.Ciphertext
+		ChiF       *paillier.Ciphertext
+		ChiProof   *zkaffg.Proof
+		ProofLog   *zklogstar.Proof
 	}
 	mtaOuts := r.Pool.Parallelize(len(otherIDs), func(i int) interface{} {
 		j := otherIDs[i]
 
-		DeltaBeta, DeltaD, DeltaF, DeltaProof := mta.ProveAffG(r.Group(), r.HashForID(r.SelfID()),
+		DeltaBeta, DeltaD, DeltaF, DeltaProof := mta.ProveAffG(r.Rand(), r.Group(), r.HashForID(r.SelfID()),
 			r.GammaShare, r.BigGammaShare[r.SelfID()], r.K[j],
 			r.SecretPaillier, r.Paillier[j], r.Pedersen[j])
-		ChiBeta, ChiD, ChiF, ChiProof := mta.ProveAffG(r.Group(),
+		ChiBeta, ChiD, ChiF, ChiProof := mta.ProveAffG(r.Rand(), r.Group(),
 			r.HashForID(r.SelfID()), curve.MakeInt(r.SecretECDSA), r.ECDSA[r.SelfID()], r.K[j],
 			r.SecretPaillier, r.Paillier[j], r.Pedersen[j])
 
-		proof := zklogstar.NewProof(r.Group(), r.HashForID(r.SelfID()),
+		proof := zklogstar.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()),
 			zklogstar.Public{
 				C:      r.G[r.SelfID()],
 				X:      r.BigGammaShare[r.SelfID()],
@@ -137,31 +141,54 @@ func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
 				Rho: r.GNonce,
 			})
 
-		err := r.SendMessage(out, &message3{
+		return mtaOut{
+			DeltaBeta:  DeltaBeta,
 			DeltaD:     DeltaD,
 			DeltaF:     DeltaF,
 			DeltaProof: DeltaProof,
+			ChiBeta:    ChiBeta,
 			ChiD:       ChiD,
 			ChiF:       ChiF,
 			ChiProof:   ChiProof,
 			ProofLog:   proof,
-		}, j)
-		return mtaOut{
-			err:       err,
-			DeltaBeta: DeltaBeta,
-			ChiBeta:   ChiBeta,
 		}
 	})
-	DeltaShareBetas := make(map[party.ID]*saferith.Int, len(otherIDs)-1)
-	ChiShareBetas := make(map[party.ID]*saferith.Int, len(otherIDs)-1)
+	DeltaCiphertext := make(map[party.ID]*paillier.Ciphertext, n)
+	ChiCiphertext := make(map[party.ID]*paillier.Ciphertext, n)
+	ChiF := make(map[party.ID]*paillier.Ciphertext, n)
+	DeltaShareBetas := make(map[party.ID]*saferith.Int, n)
+	ChiShareBetas := make(map[party.ID]*saferith.Int, n)
+
+	msgs := make(map[party.ID]*message3, n)
 	for idx, mtaOutRaw := range mtaOuts {
 		j := otherIDs[idx]
 		m := mtaOutRaw.(mtaOut)
-		if m.err != nil {
-			return r, m.err
-		}
 		DeltaShareBetas[j] = m.DeltaBeta
+		DeltaCiphertext[j] = m.DeltaD
 		ChiShareBetas[j] = m.ChiBeta
+		ChiCiphertext[j] = m.ChiD
+		ChiF[j] = m.ChiF
+		msgs[j] = &message3{
+			DeltaF:     m.DeltaF,
+			DeltaProof: m.DeltaProof,
+			ChiProof:   m.ChiProof,
+			ProofLog:   m.ProofLog,
+		}
+	}
+
+	if err := r.BroadcastMessage(out, &broadcast3{
+		BigGammaShare:   r.BigGammaShare[r.SelfID()],
+		DeltaCiphertext: DeltaCiphertext,
+		ChiCiphertext:   ChiCiphertext,
+		ChiF:            ChiF,
+	}); err != nil {
+		return r, err
+	}
+
+	for id, msg := range msgs {
+		if err := r.SendMessage(out, msg, id); err != nil {
+			return r, err
+		}
 	}
 
 	return &round3{
@@ -170,6 +197,9 @@ func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
 		ChiShareBeta:    ChiShareBetas,
 		DeltaShareAlpha: map[party.ID]*saferith.Int{},
 		ChiShareAlpha:   map[party.ID]*saferith.Int{},
+		DeltaCiphertext: map[party.ID]map[party.ID]*paillier.Ciphertext{r.SelfID(): DeltaCiphertext},
+		ChiCiphertext:   map[party.ID]map[party.ID]*paillier.Ciphertext{r.SelfID(): ChiCiphertext},
+		ChiF:            map[party.ID]map[party.ID]*paillier.Ciphertext{r.SelfID(): ChiF},
 	}, nil
 }
 
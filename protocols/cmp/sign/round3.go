@@ -26,14 +26,18 @@ type round3 struct {
 	ChiShareAlpha map[party.ID]*saferith.Int
 	// ChiShareBeta[j] = β̂ᵢⱼ
 	ChiShareBeta map[party.ID]*saferith.Int
+
+	// DeltaCiphertext[j][k] = Dₖⱼ
+	DeltaCiphertext map[party.ID]map[party.ID]*paillier.Ciphertext
+	// ChiCiphertext[j][k] = D̂ₖⱼ
+	ChiCiphertext map[party.ID]map[party.ID]*paillier.Ciphertext
+	// ChiF[j][k] = F̂ₖⱼ
+	ChiF map[party.ID]map[party.ID]*paillier.Ciphertext
 }
 
 type message3 struct {
-	DeltaD     *paillier.Ciphertext // DeltaD = Dᵢⱼ
 	DeltaF     *paillier.Ciphertext // DeltaF = Fᵢⱼ
 	DeltaProof *zkaffg.Proof
-	ChiD       *paillier.Ciphertext // DeltaD = D̂_{ij}
-	ChiF       *paillier.Ciphertext // ChiF = F̂ᵢⱼ
 	ChiProof   *zkaffg.Proof
 	ProofLog   *zklogstar.Proof
 }
@@ -41,12 +45,20 @@ type message3 struct {
 type broadcast3 struct {
 	round.NormalBroadcastContent
 	BigGammaShare curve.Point // BigGammaShare = Γⱼ
+	// DeltaCiphertext[k] = Dₖⱼ
+	DeltaCiphertext map[party.ID]*paillier.Ciphertext
+	// ChiCiphertext[k] = D̂ₖⱼ
+	ChiCiphertext map[party.ID]*paillier.Ciphertext
+	// ChiF[k] = F̂ₖⱼ
+	// The F̂ are broadcast so that every party can recompute Encⱼ(χⱼ) if the final signature is invalid.
+	ChiF map[party.ID]*paillier.Ciphertext
 }
 
 // StoreBroadcastMessage implements round.BroadcastRound.
 //
-// - store Γⱼ
+// - store Γⱼ, Dₖⱼ, D̂ₖⱼ, F̂ₖⱼ.
 func (r *round3) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
 	body, ok := msg.Content.(*broadcast3)
 	if !ok || body == nil {
 		return round.ErrInvalidContent
@@ -54,7 +66,26 @@ func (r *round3) StoreBroadcastMessage(msg round.Message) error {
 	if body.BigGammaShare.IsIdentity() {
 		return round.ErrNilFields
 	}
-	r.BigGammaShare[msg.From] = body.BigGammaShare
+	if body.DeltaCiphertext == nil || body.ChiCiphertext == nil || body.ChiF == nil {
+		return round.ErrNilFields
+	}
+
+	for _, id := range r.PartyIDs() {
+		if id == from {
+			continue
+		}
+		if !r.Paillier[id].ValidateCiphertexts(body.DeltaCiphertext[id], body.ChiCiphertext[id]) {
+			return errors.New("received invalid ciphertext")
+		}
+		if !r.Paillier[from].ValidateCiphertexts(body.ChiF[id]) {
+			return errors.New("received invalid ciphertext")
+		}
+	}
+
+	r.BigGammaShare[from] = body.BigGammaShare
+	r.DeltaCiphertext[from] = body.DeltaCiphertext
+	r.ChiCiphertext[from] = body.ChiCiphertext
+	r.ChiF[from] = body.ChiF
 	return nil
 }
 
@@ -70,7 +101,7 @@ func (r *round3) VerifyMessage(msg round.Message) error {
 
 	if !body.DeltaProof.Verify(r.HashForID(from), zkaffg.Public{
 		Kv:       r.K[to],
-		Dv:       body.DeltaD,
+		Dv:       r.DeltaCiphertext[from][to],
 		Fp:       body.DeltaF,
 		Xp:       r.BigGammaShare[from],
 		Prover:   r.Paillier[from],
@@ -82,8 +113,8 @@ func (r *round3) VerifyMessage(msg round.Message) error {
 
 	if !body.ChiProof.Verify(r.HashForID(from), zkaffg.Public{
 		Kv:       r.K[to],
-		Dv:       body.ChiD,
-		Fp:       body.ChiF,
+		Dv:       r.ChiCiphertext[from][to],
+		Fp:       r.ChiF[from][to],
 		Xp:       r.ECDSA[from],
 		Prover:   r.Paillier[from],
 		Verifier: r.Paillier[to],
@@ -109,15 +140,15 @@ func (r *round3) VerifyMessage(msg round.Message) error {
 // - Decrypt MtA shares,
 // - save αᵢⱼ, α̂ᵢⱼ.
 func (r *round3) StoreMessage(msg round.Message) error {
-	from, body := msg.From, msg.Content.(*message3)
+	from, to := msg.From, r.SelfID()
 
 	// αᵢⱼ
-	DeltaShareAlpha, err := r.SecretPaillier.Dec(body.DeltaD)
+	DeltaShareAlpha, err := r.SecretPaillier.Dec(r.DeltaCiphertext[from][to])
 	if err != nil {
 		return fmt.Errorf("failed to decrypt alpha share for delta: %w", err)
 	}
 	// α̂ᵢⱼ
-	ChiShareAlpha, err := r.SecretPaillier.Dec(body.ChiD)
+	ChiShareAlpha, err := r.SecretPaillier.Dec(r.ChiCiphertext[from][to])
 	if err != nil {
 		return fmt.Errorf("failed to decrypt alpha share for chi: %w", err)
 	}
@@ -178,7 +209,7 @@ func (r *round3) Finalize(out chan<- *round.Message) (round.Session, error) {
 	errs := r.Pool.Parallelize(len(otherIDs), func(i int) interface{} {
 		j := otherIDs[i]
 
-		proofLog := zklogstar.NewProof(r.Group(), r.HashForID(r.SelfID()), zklogstar.Public{
+		proofLog := zklogstar.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()), zklogstar.Public{
 			C:      r.K[r.SelfID()],
 			X:      BigDeltaShare,
 			G:      Gamma,
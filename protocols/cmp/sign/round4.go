@@ -3,6 +3,8 @@ package sign
 import (
 	"errors"
 
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/internal/abort"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
@@ -87,7 +89,7 @@ func (round4) StoreMessage(round.Message) error {
 //
 // - set δ = ∑ⱼ δⱼ
 // - set Δ = ∑ⱼ Δⱼ
-// - verify Δ = [δ]G
+// - verify Δ = [δ]G, otherwise reveal γᵢ, kᵢ and the αᵢⱼ to identify the culprit
 // - compute σᵢ = rχᵢ + kᵢm.
 func (r *round4) Finalize(out chan<- *round.Message) (round.Session, error) {
 	// δ = ∑ⱼ δⱼ
@@ -102,7 +104,25 @@ func (r *round4) Finalize(out chan<- *round.Message) (round.Session, error) {
 	// Δ == [δ]G
 	deltaComputed := Delta.ActOnBase()
 	if !deltaComputed.Equal(BigDelta) {
-		return r.AbortRound(errors.New("computed Δ is inconsistent with [δ]G")), nil
+		DeltaProofs := make(map[party.ID]*abort.Nth, r.N()-1)
+		for _, j := range r.OtherPartyIDs() {
+			deltaCiphertext := r.DeltaCiphertext[j][r.SelfID()] // Dᵢⱼ
+			DeltaProofs[j] = abort.ProveNth(r.Rand(), r.HashForID(r.SelfID()), r.SecretPaillier, deltaCiphertext)
+		}
+		msg := &broadcastAbort1{
+			GammaShare:  r.GammaShare,
+			KProof:      abort.ProveNth(r.Rand(), r.HashForID(r.SelfID()), r.SecretPaillier, r.K[r.SelfID()]),
+			DeltaProofs: DeltaProofs,
+		}
+		if err := r.BroadcastMessage(out, msg); err != nil {
+			return r, err
+		}
+		return &abort1{
+			round4:      r,
+			GammaShares: map[party.ID]*saferith.Int{r.SelfID(): r.GammaShare},
+			KShares:     map[party.ID]*saferith.Int{r.SelfID(): curve.MakeInt(r.KShare)},
+			DeltaAlphas: map[party.ID]map[party.ID]*saferith.Int{r.SelfID(): r.DeltaShareAlpha},
+		}, nil
 	}
 
 	deltaInv := r.Group().NewScalar().Set(Delta).Invert() // δ⁻¹
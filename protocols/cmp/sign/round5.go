@@ -1,12 +1,13 @@
 package sign
 
 import (
-	"errors"
-
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	zkdec "github.com/taurusgroup/multi-party-sig/pkg/zk/dec"
+	zkmulstar "github.com/taurusgroup/multi-party-sig/pkg/zk/mulstar"
 )
 
 var _ round.Round = (*round5)(nil)
@@ -62,8 +63,8 @@ func (round5) StoreMessage(round.Message) error { return nil }
 // Finalize implements round.Round
 //
 // - compute σ = ∑ⱼ σⱼ
-// - verify signature.
-func (r *round5) Finalize(chan<- *round.Message) (round.Session, error) {
+// - verify signature, otherwise prove that σᵢ was computed correctly to identify the culprit.
+func (r *round5) Finalize(out chan<- *round.Message) (round.Session, error) {
 	// compute σ = ∑ⱼ σⱼ
 	Sigma := r.Group().NewScalar()
 	for _, j := range r.PartyIDs() {
@@ -76,12 +77,70 @@ func (r *round5) Finalize(chan<- *round.Message) (round.Session, error) {
 	}
 
 	if !signature.Verify(r.PublicKey, r.Message) {
-		return r.AbortRound(errors.New("failed to validate signature")), nil
+		return r.abort(out)
 	}
 
 	return r.ResultRound(signature), nil
 }
 
+// abort reveals Ĥᵢ = Encᵢ(kᵢ⋅xᵢ) and Decᵢ(Uᵢ), where Uᵢ = Encᵢ(m⋅kᵢ + r⋅χᵢ),
+// and sends to each party a proof that both were computed correctly.
+func (r *round5) abort(out chan<- *round.Message) (round.Session, error) {
+	public := r.Paillier[r.SelfID()]
+
+	// Ĥᵢ = (xᵢ ⊙ Kᵢ) ⊕ Encᵢ(0;ρ)
+	H := r.K[r.SelfID()].Clone().Mul(public, curve.MakeInt(r.SecretECDSA))
+	HNonce := H.Randomize(r.Rand(), public, nil)
+
+	U := r.sigmaCiphertext(r.SelfID(), H)
+	SigmaShareInt, UNonce, err := r.SecretPaillier.DecWithRandomness(U)
+	if err != nil {
+		return r, err
+	}
+	SigmaShare := r.Group().NewScalar().SetNat(SigmaShareInt.Mod(r.Group().Order()))
+
+	if err = r.BroadcastMessage(out, &broadcastAbort2{
+		H:          H,
+		SigmaShare: SigmaShare,
+	}); err != nil {
+		return r, err
+	}
+
+	for _, j := range r.OtherPartyIDs() {
+		proofMul := zkmulstar.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()), zkmulstar.Public{
+			C:        r.K[r.SelfID()],
+			D:        H,
+			X:        r.ECDSA[r.SelfID()],
+			Verifier: public,
+			Aux:      r.Pedersen[j],
+		}, zkmulstar.Private{
+			X:   curve.MakeInt(r.SecretECDSA),
+			Rho: HNonce,
+		})
+		proofDec := zkdec.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()), zkdec.Public{
+			C:      U,
+			X:      SigmaShare,
+			Prover: public,
+			Aux:    r.Pedersen[j],
+		}, zkdec.Private{
+			Y:   SigmaShareInt,
+			Rho: UNonce,
+		})
+		if err = r.SendMessage(out, &messageAbort2{
+			ProofMul: proofMul,
+			ProofDec: proofDec,
+		}, j); err != nil {
+			return r, err
+		}
+	}
+
+	return &abort2{
+		round5:               r,
+		H:                    map[party.ID]*paillier.Ciphertext{r.SelfID(): H},
+		DecryptedSigmaShares: map[party.ID]curve.Scalar{r.SelfID(): SigmaShare},
+	}, nil
+}
+
 // MessageContent implements round.Round.
 func (r *round5) MessageContent() round.Content { return nil }
 
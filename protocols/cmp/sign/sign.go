@@ -19,17 +19,18 @@ import (
 // protocolSignID for the "3 round" variant using echo broadcast.
 const (
 	protocolSignID                  = "cmp/sign"
-	protocolSignRounds round.Number = 5
+	protocolSignRounds round.Number = 6
 )
 
-func StartSign(config *config.Config, signers []party.ID, message []byte, pl *pool.Pool) protocol.StartFunc {
+func StartSign(config *config.Config, signers []party.ID, message []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
-		group := config.Group
-
 		// this could be used to indicate a pre-signature later on
 		if len(message) == 0 {
 			return nil, errors.New("sign.Create: message is nil")
 		}
+		if config.Group.NewBasePoint().XScalar() == nil {
+			return nil, fmt.Errorf("sign.Create: group %s does not support ECDSA", config.Group.Name())
+		}
 
 		info := round.Info{
 			ProtocolID:       protocolSignID,
@@ -39,6 +40,7 @@ func StartSign(config *config.Config, signers []party.ID, message []byte, pl *po
 			Threshold:        config.Threshold,
 			Group:            config.Group,
 		}
+		info.Apply(opts...)
 
 		helper, err := round.NewSession(info, sessionID, pl, config, types.SigningMessage(message))
 		if err != nil {
@@ -49,34 +51,41 @@ func StartSign(config *config.Config, signers []party.ID, message []byte, pl *po
 			return nil, errors.New("sign.Create: signers is not a valid signing subset")
 		}
 
-		// Scale public data
-		T := helper.N()
-		ECDSA := make(map[party.ID]curve.Point, T)
-		Paillier := make(map[party.ID]*paillier.PublicKey, T)
-		Pedersen := make(map[party.ID]*pedersen.Parameters, T)
-		PublicKey := group.NewPoint()
-		lagrange := polynomial.Lagrange(group, signers)
-		// Scale own secret
-		SecretECDSA := group.NewScalar().Set(lagrange[config.ID]).Mul(config.ECDSA)
-		SecretPaillier := config.Paillier
-		for _, j := range helper.PartyIDs() {
-			public := config.Public[j]
-			// scale public key share
-			ECDSA[j] = lagrange[j].Act(public.ECDSA)
-			Paillier[j] = public.Paillier
-			Pedersen[j] = public.Pedersen
-			PublicKey = PublicKey.Add(ECDSA[j])
-		}
+		return newRound1(config, helper, message), nil
+	}
+}
+
+// newRound1 returns the first round of a signing execution of message among the parties of helper.
+func newRound1(config *config.Config, helper *round.Helper, message []byte) *round1 {
+	group := config.Group
+
+	// Scale public data
+	T := helper.N()
+	ECDSA := make(map[party.ID]curve.Point, T)
+	Paillier := make(map[party.ID]*paillier.PublicKey, T)
+	Pedersen := make(map[party.ID]*pedersen.Parameters, T)
+	PublicKey := group.NewPoint()
+	lagrange := polynomial.Lagrange(group, helper.PartyIDs())
+	// Scale own secret
+	SecretECDSA := group.NewScalar().Set(lagrange[config.ID]).Mul(config.ECDSA)
+	SecretPaillier := config.Paillier
+	for _, j := range helper.PartyIDs() {
+		public := config.Public[j]
+		// scale public key share
+		ECDSA[j] = lagrange[j].Act(public.ECDSA)
+		Paillier[j] = public.Paillier
+		Pedersen[j] = public.Pedersen
+		PublicKey = PublicKey.Add(ECDSA[j])
+	}
 
-		return &round1{
-			Helper:         helper,
-			PublicKey:      PublicKey,
-			SecretECDSA:    SecretECDSA,
-			SecretPaillier: SecretPaillier,
-			Paillier:       Paillier,
-			Pedersen:       Pedersen,
-			ECDSA:          ECDSA,
-			Message:        message,
-		}, nil
+	return &round1{
+		Helper:         helper,
+		PublicKey:      PublicKey,
+		SecretECDSA:    SecretECDSA,
+		SecretPaillier: SecretPaillier,
+		Paillier:       Paillier,
+		Pedersen:       Pedersen,
+		ECDSA:          ECDSA,
+		Message:        message,
 	}
 }
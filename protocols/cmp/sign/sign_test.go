@@ -15,9 +15,16 @@ import (
 )
 
 func TestRound(t *testing.T) {
+	testRound(t, curve.Secp256k1{})
+}
+
+func TestRoundP256(t *testing.T) {
+	testRound(t, curve.P256{})
+}
+
+func testRound(t *testing.T, group curve.Curve) {
 	pl := pool.NewPool(0)
 	defer pl.TearDown()
-	group := curve.Secp256k1{}
 
 	N := 6
 	T := N - 1
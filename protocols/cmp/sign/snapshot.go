@@ -0,0 +1,237 @@
+package sign
+
+import (
+	"fmt"
+
+	"github.com/cronokirby/saferith"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/paillier"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var (
+	_ round.Restorer    = (*round1)(nil)
+	_ round.Snapshotter = (*round2)(nil)
+	_ round.Snapshotter = (*round3)(nil)
+	_ round.Snapshotter = (*round4)(nil)
+	_ round.Snapshotter = (*round5)(nil)
+)
+
+// snapshot contains the state of the protocol from round2 onwards.
+// Fields which are set in later rounds are empty in the snapshots of earlier rounds.
+//
+// The abort rounds cannot be saved.
+type snapshot struct {
+	// round2
+	K, G           map[party.ID]*paillier.Ciphertext
+	BigGammaShare  map[party.ID][]byte
+	GammaShare     *saferith.Int
+	KShare         []byte
+	KNonce, GNonce *saferith.Nat
+
+	// round3
+	DeltaShareAlpha, DeltaShareBeta map[party.ID]*saferith.Int
+	ChiShareAlpha, ChiShareBeta     map[party.ID]*saferith.Int
+	DeltaCiphertext, ChiCiphertext  map[party.ID]map[party.ID]*paillier.Ciphertext
+	ChiF                            map[party.ID]map[party.ID]*paillier.Ciphertext
+
+	// round4
+	DeltaShares    map[party.ID][]byte
+	BigDeltaShares map[party.ID][]byte
+	Gamma          []byte
+	ChiShare       []byte
+
+	// round5
+	SigmaShares map[party.ID][]byte
+	Delta       []byte
+	BigDelta    []byte
+	BigR        []byte
+	R           []byte
+}
+
+// Snapshot implements round.Snapshotter.
+func (r *round2) Snapshot() ([]byte, error) { return marshalSnapshot(r, nil, nil, nil) }
+
+// Snapshot implements round.Snapshotter.
+func (r *round3) Snapshot() ([]byte, error) { return marshalSnapshot(r.round2, r, nil, nil) }
+
+// Snapshot implements round.Snapshotter.
+func (r *round4) Snapshot() ([]byte, error) { return marshalSnapshot(r.round2, r.round3, r, nil) }
+
+// Snapshot implements round.Snapshotter.
+func (r *round5) Snapshot() ([]byte, error) {
+	return marshalSnapshot(r.round2, r.round3, r.round4, r)
+}
+
+// marshalSnapshot encodes the state of the rounds which are not nil.
+func marshalSnapshot(r2 *round2, r3 *round3, r4 *round4, r5 *round5) ([]byte, error) {
+	var err error
+	s := &snapshot{
+		K:          r2.K,
+		G:          r2.G,
+		GammaShare: r2.GammaShare,
+		KNonce:     r2.KNonce,
+		GNonce:     r2.GNonce,
+	}
+	if s.BigGammaShare, err = round.MarshalPoints(r2.BigGammaShare); err != nil {
+		return nil, err
+	}
+	if s.KShare, err = r2.KShare.MarshalBinary(); err != nil {
+		return nil, err
+	}
+
+	if r3 != nil {
+		s.DeltaShareAlpha, s.DeltaShareBeta = r3.DeltaShareAlpha, r3.DeltaShareBeta
+		s.ChiShareAlpha, s.ChiShareBeta = r3.ChiShareAlpha, r3.ChiShareBeta
+		s.DeltaCiphertext, s.ChiCiphertext, s.ChiF = r3.DeltaCiphertext, r3.ChiCiphertext, r3.ChiF
+	}
+	if r4 != nil {
+		if s.DeltaShares, err = round.MarshalScalars(r4.DeltaShares); err != nil {
+			return nil, err
+		}
+		if s.BigDeltaShares, err = round.MarshalPoints(r4.BigDeltaShares); err != nil {
+			return nil, err
+		}
+		if s.Gamma, err = r4.Gamma.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if s.ChiShare, err = r4.ChiShare.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if r5 != nil {
+		if s.SigmaShares, err = round.MarshalScalars(r5.SigmaShares); err != nil {
+			return nil, err
+		}
+		if s.Delta, err = r5.Delta.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if s.BigDelta, err = r5.BigDelta.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if s.BigR, err = r5.BigR.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if s.R, err = r5.R.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	return cbor.Marshal(s)
+}
+
+// Restore implements round.Restorer.
+func (r *round1) Restore(number round.Number, data []byte) (round.Session, error) {
+	if number < 2 || number > 5 {
+		return nil, fmt.Errorf("sign: invalid round number %d", number)
+	}
+	s := &snapshot{}
+	if err := cbor.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	group := r.Group()
+
+	if s.K[r.SelfID()] == nil || s.G[r.SelfID()] == nil || s.GammaShare == nil || s.KNonce == nil || s.GNonce == nil {
+		return nil, fmt.Errorf("sign: paillier: %w", round.ErrNilFields)
+	}
+	BigGammaShare, err := round.UnmarshalPoints(group, s.BigGammaShare)
+	if err != nil {
+		return nil, fmt.Errorf("sign: big gamma shares: %w", err)
+	}
+	KShare := group.NewScalar()
+	if err = KShare.UnmarshalBinary(s.KShare); err != nil {
+		return nil, fmt.Errorf("sign: k share: %w", err)
+	}
+	r2 := &round2{
+		round1:        r,
+		K:             s.K,
+		G:             s.G,
+		BigGammaShare: BigGammaShare,
+		GammaShare:    s.GammaShare,
+		KShare:        KShare,
+		KNonce:        s.KNonce,
+		GNonce:        s.GNonce,
+	}
+	if number == 2 {
+		return r2, nil
+	}
+
+	if s.DeltaShareBeta == nil || s.ChiShareBeta == nil || s.DeltaCiphertext[r.SelfID()] == nil || s.ChiCiphertext[r.SelfID()] == nil || s.ChiF == nil {
+		return nil, fmt.Errorf("sign: mta: %w", round.ErrNilFields)
+	}
+	if s.DeltaShareAlpha == nil {
+		s.DeltaShareAlpha = map[party.ID]*saferith.Int{}
+	}
+	if s.ChiShareAlpha == nil {
+		s.ChiShareAlpha = map[party.ID]*saferith.Int{}
+	}
+	r3 := &round3{
+		round2:          r2,
+		DeltaShareAlpha: s.DeltaShareAlpha,
+		DeltaShareBeta:  s.DeltaShareBeta,
+		ChiShareAlpha:   s.ChiShareAlpha,
+		ChiShareBeta:    s.ChiShareBeta,
+		DeltaCiphertext: s.DeltaCiphertext,
+		ChiCiphertext:   s.ChiCiphertext,
+		ChiF:            s.ChiF,
+	}
+	if number == 3 {
+		return r3, nil
+	}
+
+	DeltaShares, err := round.UnmarshalScalars(group, s.DeltaShares)
+	if err != nil {
+		return nil, fmt.Errorf("sign: delta shares: %w", err)
+	}
+	BigDeltaShares, err := round.UnmarshalPoints(group, s.BigDeltaShares)
+	if err != nil {
+		return nil, fmt.Errorf("sign: big delta shares: %w", err)
+	}
+	Gamma := group.NewPoint()
+	if err = Gamma.UnmarshalBinary(s.Gamma); err != nil {
+		return nil, fmt.Errorf("sign: gamma: %w", err)
+	}
+	ChiShare := group.NewScalar()
+	if err = ChiShare.UnmarshalBinary(s.ChiShare); err != nil {
+		return nil, fmt.Errorf("sign: chi share: %w", err)
+	}
+	r4 := &round4{
+		round3:         r3,
+		DeltaShares:    DeltaShares,
+		BigDeltaShares: BigDeltaShares,
+		Gamma:          Gamma,
+		ChiShare:       ChiShare,
+	}
+	if number == 4 {
+		return r4, nil
+	}
+
+	SigmaShares, err := round.UnmarshalScalars(group, s.SigmaShares)
+	if err != nil {
+		return nil, fmt.Errorf("sign: sigma shares: %w", err)
+	}
+	Delta := group.NewScalar()
+	if err = Delta.UnmarshalBinary(s.Delta); err != nil {
+		return nil, fmt.Errorf("sign: delta: %w", err)
+	}
+	BigDelta := group.NewPoint()
+	if err = BigDelta.UnmarshalBinary(s.BigDelta); err != nil {
+		return nil, fmt.Errorf("sign: big delta: %w", err)
+	}
+	BigR := group.NewPoint()
+	if err = BigR.UnmarshalBinary(s.BigR); err != nil {
+		return nil, fmt.Errorf("sign: big R: %w", err)
+	}
+	R := group.NewScalar()
+	if err = R.UnmarshalBinary(s.R); err != nil {
+		return nil, fmt.Errorf("sign: R: %w", err)
+	}
+	return &round5{
+		round4:      r4,
+		SigmaShares: SigmaShares,
+		Delta:       Delta,
+		BigDelta:    BigDelta,
+		BigR:        BigR,
+		R:           R,
+	}, nil
+}
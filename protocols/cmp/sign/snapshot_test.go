@@ -0,0 +1,57 @@
+package sign
+
+import (
+	mrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestSignSnapshot(t *testing.T) {
+	N := 3
+	T := N - 1
+	configs, partyIDs := test.GenerateConfig(curve.Secp256k1{}, N, T, mrand.New(mrand.NewSource(1)), nil)
+	publicPoint := configs[partyIDs[0]].PublicPoint()
+
+	messageHash := make([]byte, 64)
+	sha3.ShakeSum128(messageHash, []byte("hello"))
+
+	sessionID := []byte("session")
+	rounds := make([]round.Session, 0, N)
+	for _, partyID := range partyIDs {
+		r, err := StartSign(configs[partyID], partyIDs, messageHash, nil)(sessionID)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+		// restart all parties from a snapshot of their current round
+		for i, r := range rounds {
+			data, err := round.MarshalSnapshot(r)
+			require.NoError(t, err, "failed to snapshot round", r.Number())
+			first, err := StartSign(configs[r.SelfID()], partyIDs, messageHash, nil)(sessionID)
+			require.NoError(t, err)
+			restored, err := round.UnmarshalSnapshot(first, data)
+			require.NoError(t, err, "failed to restore round", r.Number())
+			assert.Equal(t, r.Hash().Sum(), restored.Hash().Sum(), "hash state is different")
+			rounds[i] = restored
+		}
+	}
+
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r, "expected result round")
+		signature := r.(*round.Output).Result.(*ecdsa.Signature)
+		assert.True(t, signature.Verify(publicPoint, messageHash), "expected valid signature")
+	}
+}
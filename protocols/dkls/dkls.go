@@ -0,0 +1,53 @@
+package dkls
+
+import (
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/dkls/keygen"
+	"github.com/taurusgroup/multi-party-sig/protocols/dkls/sign"
+)
+
+type Config = keygen.Config
+
+// EmptyConfig creates an empty Config with a fixed group, ready for unmarshalling.
+//
+// This needs to be used for unmarshalling, otherwise the points on the curve can't
+// be decoded.
+func EmptyConfig(group curve.Curve) *Config {
+	return keygen.EmptyConfig(group)
+}
+
+// Keygen initiates the DKLs key generation protocol.
+//
+// This protocol establishes a new threshold signature key among a set of participants.
+// Any subset of t + 1 participants can create a signature with this shared key.
+//
+// The secret key is shared with Feldman's VSS, so that the result is compatible with polynomial.Exponent,
+// and every pair of participants sets up the OT extensions needed for signing.
+//
+// A pool can be passed to this function, to parallelize certain operations and improve performance.
+func Keygen(group curve.Curve, selfID party.ID, participants []party.ID, threshold int, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return keygen.StartKeygen(group, participants, threshold, selfID, nil, nil, nil, pl, opts...)
+}
+
+// Refresh allows the participants of a Feldman sharing to refresh their shares,
+// and to set up new OT extensions between them.
+//
+// The public key and the verification shares of this sharing are preserved, only the private shares change.
+// This can be used with an existing sharing, such as the result of frost.Keygen, by filling in the corresponding fields
+// of config, and leaving the setups empty.
+func Refresh(config *Config, participants []party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return keygen.StartKeygen(config.Group(), participants, config.Threshold, config.ID, config.PrivateShare, config.PublicKey, config.VerificationShares.Points, pl, opts...)
+}
+
+// Sign generates an ECDSA signature for messageHash among the given signers.
+//
+// The signature is produced after three rounds of messages, following https://eprint.iacr.org/2023/765.
+// The result is an ecdsa.Signature.
+//
+// The OT extensions of the config are reused across signatures, with a fresh context each time.
+func Sign(config *Config, signers []party.ID, messageHash []byte, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartSign(config, signers, messageHash, opts...)
+}
@@ -0,0 +1,97 @@
+package dkls
+
+import (
+	"crypto/sha256"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost"
+)
+
+// run executes the protocol started by start for each of the given parties, and returns their results.
+func run(t *testing.T, ids party.IDSlice, start func(id party.ID) protocol.StartFunc) map[party.ID]interface{} {
+	n := test.NewNetwork(ids)
+	results := make(map[party.ID]interface{}, len(ids))
+	var mtx sync.Mutex
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id party.ID) {
+			defer wg.Done()
+			h, err := protocol.NewMultiHandler(start(id), nil)
+			require.NoError(t, err)
+			test.HandlerLoop(id, h, n)
+			r, err := h.Result()
+			require.NoError(t, err)
+			mtx.Lock()
+			results[id] = r
+			mtx.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	return results
+}
+
+func checkSign(t *testing.T, configs map[party.ID]*Config, signers party.IDSlice, messageHash []byte) {
+	results := run(t, signers, func(id party.ID) protocol.StartFunc {
+		return Sign(configs[id], signers, messageHash)
+	})
+	for id, r := range results {
+		require.IsType(t, &ecdsa.Signature{}, r)
+		assert.True(t, r.(*ecdsa.Signature).Verify(configs[id].PublicKey, messageHash))
+	}
+}
+
+func TestDKLs(t *testing.T) {
+	N, T := 5, 2
+	partyIDs := test.PartyIDs(N)
+	messageHash := sha256.Sum256([]byte("hello"))
+
+	results := run(t, partyIDs, func(id party.ID) protocol.StartFunc {
+		return Keygen(curve.Secp256k1{}, id, partyIDs, T, nil)
+	})
+	configs := make(map[party.ID]*Config, N)
+	for id, r := range results {
+		require.IsType(t, &Config{}, r)
+		configs[id] = r.(*Config)
+	}
+
+	checkSign(t, configs, partyIDs[:T+1], messageHash[:])
+	checkSign(t, configs, partyIDs[N-T-1:], messageHash[:])
+	checkSign(t, configs, partyIDs, messageHash[:])
+}
+
+func TestRefreshFrost(t *testing.T) {
+	N, T := 4, 1
+	partyIDs := test.PartyIDs(N)
+	messageHash := sha256.Sum256([]byte("hello"))
+
+	results := run(t, partyIDs, func(id party.ID) protocol.StartFunc {
+		return frost.Keygen(curve.Secp256k1{}, id, partyIDs, T)
+	})
+
+	results = run(t, partyIDs, func(id party.ID) protocol.StartFunc {
+		c := results[id].(*frost.Config)
+		return Refresh(&Config{
+			ID:                 c.ID,
+			Threshold:          c.Threshold,
+			PrivateShare:       c.PrivateShare,
+			PublicKey:          c.PublicKey,
+			VerificationShares: c.VerificationShares,
+		}, partyIDs, nil)
+	})
+	configs := make(map[party.ID]*Config, N)
+	for id, r := range results {
+		require.IsType(t, &Config{}, r)
+		configs[id] = r.(*Config)
+	}
+
+	checkSign(t, configs, party.IDSlice{partyIDs[1], partyIDs[3]}, messageHash[:])
+}
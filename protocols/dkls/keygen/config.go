@@ -0,0 +1,73 @@
+package keygen
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// Config contains all the information produced after key generation, from the perspective
+// of a single participant.
+//
+// The secret key is shared with a polynomial of degree Threshold, so that any Threshold + 1
+// participants can sign together.
+//
+// To unmarshal this struct, EmptyConfig should be called first with a specific group.
+type Config struct {
+	// ID is the identifier for this participant.
+	ID party.ID
+	// Threshold is the number of accepted corruptions while still being able to sign.
+	Threshold int
+	// PrivateShare is the fraction of the secret key owned by this participant.
+	PrivateShare curve.Scalar
+	// PublicKey is the shared public key for this consortium of signers.
+	PublicKey curve.Point
+	// VerificationShares is a map between parties and a commitment to their private share.
+	VerificationShares *party.PointMap
+	// SendSetups is an implementation detail, needed to perform signing.
+	//
+	// SendSetups[j] is used to multiply with party j, when we play the role of the Sender.
+	SendSetups map[party.ID]*ot.CorreOTSendSetup
+	// ReceiveSetups is an implementation detail, needed to perform signing.
+	//
+	// ReceiveSetups[j] is used to multiply with party j, when we play the role of the Receiver.
+	ReceiveSetups map[party.ID]*ot.CorreOTReceiveSetup
+}
+
+// Group returns the elliptic curve group associated with this config.
+func (c *Config) Group() curve.Curve {
+	return c.PublicKey.Curve()
+}
+
+// PartyIDs returns the IDs of all participants of the key generation.
+func (c *Config) PartyIDs() party.IDSlice {
+	ids := make([]party.ID, 0, len(c.VerificationShares.Points))
+	for id := range c.VerificationShares.Points {
+		ids = append(ids, id)
+	}
+	return party.NewIDSlice(ids)
+}
+
+// CanSign checks whether the given signers can produce a signature with this config.
+func (c *Config) CanSign(signers party.IDSlice) error {
+	if len(signers) <= c.Threshold {
+		return fmt.Errorf("dkls: %d signers are not enough for threshold %d", len(signers), c.Threshold)
+	}
+	if !signers.Contains(c.ID) {
+		return fmt.Errorf("dkls: signers do not include %v", c.ID)
+	}
+	for _, j := range signers {
+		if _, ok := c.VerificationShares.Points[j]; !ok {
+			return fmt.Errorf("dkls: %v is not a participant", j)
+		}
+		if j == c.ID {
+			continue
+		}
+		if c.SendSetups[j] == nil || c.ReceiveSetups[j] == nil {
+			return fmt.Errorf("dkls: no multiplication setup with %v", j)
+		}
+	}
+	return nil
+}
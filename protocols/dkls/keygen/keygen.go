@@ -0,0 +1,106 @@
+package keygen
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+const (
+	// DKLs KeyGen with Threshold.
+	protocolID = "dkls/keygen-threshold"
+	// This protocol has 6 concrete rounds.
+	protocolRounds round.Number = 6
+)
+
+// These assert that our rounds implement the round.Round interface.
+var (
+	_ round.Round = (*round1)(nil)
+	_ round.Round = (*round2)(nil)
+	_ round.Round = (*round3)(nil)
+	_ round.Round = (*round4)(nil)
+	_ round.Round = (*round5)(nil)
+	_ round.Round = (*round6)(nil)
+)
+
+// StartKeygen starts the key generation protocol.
+//
+// The secret key is shared using Feldman's VSS, as in the Frost key generation, and
+// every pair of participants sets up two correlated OTs, one in each direction, which
+// are later used to multiply their secrets when signing.
+//
+// If privateShare and publicKey are not nil, a refresh of this existing sharing is done instead,
+// which also creates new OT setups.
+//
+// The group must support ECDSA, which excludes curves such as Edwards25519 whose points have no XScalar.
+func StartKeygen(group curve.Curve, participants []party.ID, threshold int, selfID party.ID, privateShare curve.Scalar, publicKey curve.Point, verificationShares map[party.ID]curve.Point, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if group.NewBasePoint().XScalar() == nil {
+			return nil, fmt.Errorf("keygen.StartKeygen: group %s does not support ECDSA", group.Name())
+		}
+
+		info := round.Info{
+			ProtocolID:       protocolID,
+			FinalRoundNumber: protocolRounds,
+			SelfID:           selfID,
+			PartyIDs:         participants,
+			Threshold:        threshold,
+			Group:            group,
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("keygen.StartKeygen: %w", err)
+		}
+
+		verificationSharesCopy := make(map[party.ID]curve.Point, len(participants))
+		for k, v := range verificationShares {
+			verificationSharesCopy[k] = v
+		}
+
+		refresh := true
+		if privateShare == nil || publicKey == nil {
+			refresh = false
+			privateShare = group.NewScalar()
+			publicKey = group.NewPoint()
+			for _, k := range helper.PartyIDs() {
+				verificationSharesCopy[k] = group.NewPoint()
+			}
+		}
+		for _, k := range helper.PartyIDs() {
+			if verificationSharesCopy[k] == nil {
+				return nil, fmt.Errorf("keygen.StartKeygen: missing verification share for %v", k)
+			}
+		}
+
+		receivers := make(map[party.ID]*ot.CorreOTSetupReceiver, helper.N()-1)
+		senders := make(map[party.ID]*ot.CorreOTSetupSender, helper.N()-1)
+		for _, j := range helper.OtherPartyIDs() {
+			receivers[j] = ot.NewCorreOTSetupReceiver(helper.Rand(), pl, setupHash(helper, selfID, j), group)
+			senders[j] = ot.NewCorreOTSetupSender(helper.Rand(), pl, setupHash(helper, j, selfID))
+		}
+
+		return &round1{
+			Helper:             helper,
+			threshold:          threshold,
+			refresh:            refresh,
+			privateShare:       privateShare,
+			verificationShares: verificationSharesCopy,
+			publicKey:          publicKey,
+			receivers:          receivers,
+			senders:            senders,
+		}, nil
+	}
+}
+
+// setupHash returns the context for the correlated OT setup where receiver and sender play these roles.
+func setupHash(helper *round.Helper, receiver, sender party.ID) *hash.Hash {
+	return helper.Hash().Fork(&hash.BytesWithDomain{TheDomain: "DKLs OT Setup", Bytes: nil}, receiver, sender)
+}
@@ -0,0 +1,136 @@
+package keygen
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+func runKeygen(t *testing.T, rounds []round.Session) map[party.ID]*Config {
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+	configs := make(map[party.ID]*Config, len(rounds))
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r, "expected result round")
+		result := r.(*round.Output).Result
+		require.IsType(t, &Config{}, result)
+		configs[r.SelfID()] = result.(*Config)
+	}
+	return configs
+}
+
+func checkOutput(t *testing.T, configs map[party.ID]*Config, parties party.IDSlice, threshold int) {
+	group := curve.Secp256k1{}
+
+	// Any threshold + 1 participants can recover the secret key.
+	signers := parties[:threshold+1]
+	lagrange := polynomial.Lagrange(group, signers)
+	privateKey := group.NewScalar()
+	for _, id := range signers {
+		privateKey.Add(group.NewScalar().Set(lagrange[id]).Mul(configs[id].PrivateShare))
+	}
+	publicKey := privateKey.ActOnBase()
+
+	for _, c := range configs {
+		require.True(t, publicKey.Equal(c.PublicKey), "different public key")
+		require.Equal(t, threshold, c.Threshold)
+		for _, id := range parties {
+			require.True(t, c.VerificationShares.Points[id].Equal(configs[id].PrivateShare.ActOnBase()), "different verification shares", id)
+		}
+		require.NoError(t, c.CanSign(parties))
+		require.Len(t, c.SendSetups, len(parties)-1)
+		require.Len(t, c.ReceiveSetups, len(parties)-1)
+	}
+}
+
+func TestKeygen(t *testing.T) {
+	group := curve.Secp256k1{}
+	N, T := 4, 2
+	partyIDs := test.PartyIDs(N)
+
+	rounds := make([]round.Session, 0, N)
+	for _, partyID := range partyIDs {
+		r, err := StartKeygen(group, partyIDs, T, partyID, nil, nil, nil, nil)(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+	configs := runKeygen(t, rounds)
+	checkOutput(t, configs, partyIDs, T)
+
+	rounds = rounds[:0]
+	for _, partyID := range partyIDs {
+		c := configs[partyID]
+		r, err := StartKeygen(group, partyIDs, T, partyID, c.PrivateShare, c.PublicKey, c.VerificationShares.Points, nil)(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+	refreshed := runKeygen(t, rounds)
+	checkOutput(t, refreshed, partyIDs, T)
+
+	for _, partyID := range partyIDs {
+		require.True(t, configs[partyID].PublicKey.Equal(refreshed[partyID].PublicKey), "refresh changed the public key")
+		require.False(t, configs[partyID].PrivateShare.Equal(refreshed[partyID].PrivateShare), "refresh did not change the share")
+	}
+}
+
+func TestConfigMarshal(t *testing.T) {
+	group := curve.Secp256k1{}
+	N, T := 3, 1
+	partyIDs := test.PartyIDs(N)
+
+	rounds := make([]round.Session, 0, N)
+	for _, partyID := range partyIDs {
+		r, err := StartKeygen(group, partyIDs, T, partyID, nil, nil, nil, nil)(nil)
+		require.NoError(t, err)
+		rounds = append(rounds, r)
+	}
+	configs := runKeygen(t, rounds)
+
+	unmarshalled := make(map[party.ID]*Config, N)
+	for id, c := range configs {
+		data, err := cbor.Marshal(c)
+		require.NoError(t, err)
+		u := EmptyConfig(group)
+		require.NoError(t, cbor.Unmarshal(data, u))
+		unmarshalled[id] = u
+
+		require.Equal(t, c.ID, u.ID)
+		require.Equal(t, c.Threshold, u.Threshold)
+		require.True(t, c.PrivateShare.Equal(u.PrivateShare))
+		require.True(t, c.PublicKey.Equal(u.PublicKey))
+		for _, j := range partyIDs {
+			require.True(t, c.VerificationShares.Points[j].Equal(u.VerificationShares.Points[j]))
+			if j == id {
+				continue
+			}
+			expected, _ := c.SendSetups[j].MarshalBinary()
+			actual, _ := u.SendSetups[j].MarshalBinary()
+			require.Equal(t, expected, actual)
+			expected, _ = c.ReceiveSetups[j].MarshalBinary()
+			actual, _ = u.ReceiveSetups[j].MarshalBinary()
+			require.Equal(t, expected, actual)
+		}
+	}
+	checkOutput(t, unmarshalled, partyIDs, T)
+
+	data, err := cbor.Marshal(configs[partyIDs[0]])
+	require.NoError(t, err)
+	require.Error(t, cbor.Unmarshal(data, &Config{}), "unmarshalling should require EmptyConfig")
+}
+
+func TestKeygenUnsupportedGroup(t *testing.T) {
+	partyIDs := test.PartyIDs(2)
+	_, err := StartKeygen(curve.Edwards25519{}, partyIDs, 1, partyIDs[0], nil, nil, nil, nil)(nil)
+	require.ErrorContains(t, err, "does not support ECDSA")
+}
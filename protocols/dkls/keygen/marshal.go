@@ -0,0 +1,100 @@
+package keygen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// EmptyConfig creates an empty Config with a fixed group, ready for unmarshalling.
+//
+// This needs to be used for unmarshalling, otherwise the points on the curve can't
+// be decoded.
+func EmptyConfig(group curve.Curve) *Config {
+	return &Config{
+		PrivateShare:       group.NewScalar(),
+		PublicKey:          group.NewPoint(),
+		VerificationShares: party.EmptyPointMap(group),
+	}
+}
+
+type configMarshal struct {
+	ID                 party.ID
+	Threshold          int
+	PrivateShare       curve.Scalar
+	PublicKey          curve.Point
+	VerificationShares *party.PointMap
+	SendSetups         map[party.ID]*ot.CorreOTSendSetup
+	ReceiveSetups      map[party.ID]*ot.CorreOTReceiveSetup
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The output contains the private share and the OT setups, and should be stored as secret key material.
+func (c *Config) MarshalBinary() ([]byte, error) {
+	return cbor.Marshal(&configMarshal{
+		ID:                 c.ID,
+		Threshold:          c.Threshold,
+		PrivateShare:       c.PrivateShare,
+		PublicKey:          c.PublicKey,
+		VerificationShares: c.VerificationShares,
+		SendSetups:         c.SendSetups,
+		ReceiveSetups:      c.ReceiveSetups,
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// The config must be initialized using EmptyConfig.
+func (c *Config) UnmarshalBinary(data []byte) error {
+	if c.PrivateShare == nil || c.PublicKey == nil || c.VerificationShares == nil {
+		return errors.New("config must be initialized using EmptyConfig")
+	}
+	group := c.PublicKey.Curve()
+	cm := &configMarshal{
+		PrivateShare:       group.NewScalar(),
+		PublicKey:          group.NewPoint(),
+		VerificationShares: party.EmptyPointMap(group),
+	}
+	if err := cbor.Unmarshal(data, cm); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	n := len(cm.VerificationShares.Points)
+	if cm.Threshold < 0 || cm.Threshold > n-1 {
+		return fmt.Errorf("config: threshold %d is invalid", cm.Threshold)
+	}
+	if cm.PublicKey.IsIdentity() {
+		return errors.New("config: public key is identity")
+	}
+	verificationShare, ok := cm.VerificationShares.Points[cm.ID]
+	if !ok {
+		return errors.New("config: no verification share for this party")
+	}
+	if !cm.PrivateShare.ActOnBase().Equal(verificationShare) {
+		return errors.New("config: private share does not match the verification share")
+	}
+	for id := range cm.VerificationShares.Points {
+		if id == cm.ID {
+			continue
+		}
+		if cm.SendSetups[id] == nil || cm.ReceiveSetups[id] == nil {
+			return fmt.Errorf("config: party %s: missing multiplication setup", id)
+		}
+	}
+
+	*c = Config{
+		ID:                 cm.ID,
+		Threshold:          cm.Threshold,
+		PrivateShare:       cm.PrivateShare,
+		PublicKey:          cm.PublicKey,
+		VerificationShares: cm.VerificationShares,
+		SendSetups:         cm.SendSetups,
+		ReceiveSetups:      cm.ReceiveSetups,
+	}
+	return nil
+}
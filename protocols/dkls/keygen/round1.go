@@ -0,0 +1,83 @@
+package keygen
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	zksch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+)
+
+// round1 starts the Feldman VSS of our contribution to the secret, and the OT setups where we are the Receiver.
+type round1 struct {
+	*round.Helper
+	// threshold is the degree of the polynomial used to share the secret.
+	threshold int
+	// refresh indicates whether or not we're doing a refresh instead of a key-generation.
+	refresh bool
+	// privateShare is our previous private share when refreshing, and 0 otherwise.
+	privateShare curve.Scalar
+	// verificationShares should hold the previous verification shares when refreshing, and identity points otherwise.
+	verificationShares map[party.ID]curve.Point
+	// publicKey should be the previous public key when refreshing, and 0 otherwise.
+	publicKey curve.Point
+	// receivers[j] sets up the correlated OT where we are the Receiver, and j the Sender.
+	receivers map[party.ID]*ot.CorreOTSetupReceiver
+	// senders[j] sets up the correlated OT where we are the Sender, and j the Receiver.
+	senders map[party.ID]*ot.CorreOTSetupSender
+}
+
+// VerifyMessage implements round.Round.
+func (r *round1) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (r *round1) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round.
+//
+// - sample a polynomial fᵢ of degree t, with a random constant, or 0 when refreshing.
+// - broadcast the commitment Φᵢ = fᵢ⋅G, along with a proof of knowledge of fᵢ(0).
+// - send the first OT setup message to every other party.
+func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
+	group := r.Group()
+
+	a_i0 := group.NewScalar()
+	if !r.refresh {
+		a_i0 = sample.Scalar(r.Rand(), group)
+	}
+	f_i := polynomial.NewPolynomial(r.Rand(), group, r.threshold, a_i0)
+	Phi_i := polynomial.NewPolynomialExponent(f_i)
+
+	var Sigma_i *zksch.Proof
+	if !r.refresh {
+		Sigma_i = zksch.NewProof(r.Rand(), r.HashForID(r.SelfID()), Phi_i.Constant(), a_i0, nil)
+	}
+
+	if err := r.BroadcastMessage(out, &broadcast2{
+		Phi_i:   Phi_i,
+		Sigma_i: Sigma_i,
+	}); err != nil {
+		return r, err
+	}
+
+	for _, j := range r.OtherPartyIDs() {
+		if err := r.SendMessage(out, &message2{OtMsg: r.receivers[j].Round1()}, j); err != nil {
+			return r, err
+		}
+	}
+
+	return &round2{
+		round1: r,
+		f_i:    f_i,
+		Phi:    map[party.ID]*polynomial.Exponent{r.SelfID(): Phi_i},
+		otMsgs: make(map[party.ID]*ot.CorreOTSetupSendRound1Message, r.N()-1),
+	}, nil
+}
+
+// MessageContent implements round.Round.
+func (round1) MessageContent() round.Content { return nil }
+
+// Number implements round.Round.
+func (round1) Number() round.Number { return 1 }
@@ -0,0 +1,128 @@
+package keygen
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	zksch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+)
+
+type round2 struct {
+	*round1
+	// f_i is the polynomial this participant uses to share their contribution to the secret.
+	f_i *polynomial.Polynomial
+	// Phi contains the polynomial commitment for each participant, ourselves included.
+	Phi map[party.ID]*polynomial.Exponent
+	// otMsgs[j] is our reply to j's first OT setup message.
+	otMsgs map[party.ID]*ot.CorreOTSetupSendRound1Message
+}
+
+type broadcast2 struct {
+	round.ReliableBroadcastContent
+	// Phi_i is the commitment to the polynomial that this participant generated.
+	Phi_i *polynomial.Exponent
+	// Sigma_i is the Schnorr proof of knowledge of the participant's secret.
+	Sigma_i *zksch.Proof
+}
+
+type message2 struct {
+	OtMsg *ot.CorreOTSetupReceiveRound1Message
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - check that Φⱼ has degree t, and verify the proof of knowledge of its constant, which is 0 when refreshing.
+func (r *round2) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcast2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+
+	if (!r.refresh && !body.Sigma_i.IsValid()) || body.Phi_i == nil {
+		return round.ErrNilFields
+	}
+	if body.Phi_i.Degree() != r.threshold {
+		return fmt.Errorf("party %s sent a polynomial of degree %d", from, body.Phi_i.Degree())
+	}
+
+	if r.refresh {
+		if !body.Phi_i.Constant().IsIdentity() {
+			return fmt.Errorf("party %s sent a non-zero constant while refreshing", from)
+		}
+	} else {
+		if !body.Sigma_i.Verify(r.HashForID(from), body.Phi_i.Constant(), nil) {
+			return fmt.Errorf("failed to verify Schnorr proof for party %s", from)
+		}
+	}
+
+	r.Phi[from] = body.Phi_i
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round2) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*message2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.OtMsg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+// StoreMessage implements round.Round.
+func (r *round2) StoreMessage(msg round.Message) (err error) {
+	from, body := msg.From, msg.Content.(*message2)
+	r.otMsgs[from], err = r.senders[from].Round1(body.OtMsg)
+	return
+}
+
+// Finalize implements round.Round
+//
+// - send fᵢ(j) to every other party j, along with our reply to their OT setup message.
+func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
+	for _, j := range r.OtherPartyIDs() {
+		if err := r.SendMessage(out, &message3{
+			F_ij:  r.f_i.Evaluate(j.Scalar(r.Group())),
+			OtMsg: r.otMsgs[j],
+		}, j); err != nil {
+			return r, err
+		}
+	}
+
+	selfShare := r.f_i.Evaluate(r.SelfID().Scalar(r.Group()))
+	return &round3{
+		round1:    r.round1,
+		Phi:       r.Phi,
+		shareFrom: map[party.ID]curve.Scalar{r.SelfID(): selfShare},
+		otMsgs:    make(map[party.ID]*ot.CorreOTSetupReceiveRound2Message, r.N()-1),
+	}, nil
+}
+
+// RoundNumber implements round.Content.
+func (message2) RoundNumber() round.Number { return 2 }
+
+// MessageContent implements round.Round.
+func (r *round2) MessageContent() round.Content {
+	return &message2{OtMsg: ot.EmptyCorreOTSetupReceiveRound1Message(r.Group())}
+}
+
+// RoundNumber implements round.Content.
+func (broadcast2) RoundNumber() round.Number { return 2 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *round2) BroadcastContent() round.BroadcastContent {
+	return &broadcast2{
+		Phi_i:   polynomial.EmptyExponent(r.Group()),
+		Sigma_i: zksch.EmptyProof(r.Group()),
+	}
+}
+
+// Number implements round.Round.
+func (round2) Number() round.Number { return 2 }
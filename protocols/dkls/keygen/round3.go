@@ -0,0 +1,81 @@
+package keygen
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// round3 does not embed round2, since it must not be a round.BroadcastRound.
+type round3 struct {
+	*round1
+	// Phi contains the polynomial commitment for each participant, ourselves included.
+	Phi map[party.ID]*polynomial.Exponent
+	// shareFrom[j] = fⱼ(i) is the secret share sent to us by party j, including ourselves.
+	shareFrom map[party.ID]curve.Scalar
+	// otMsgs[j] is our second OT setup message for j.
+	otMsgs map[party.ID]*ot.CorreOTSetupReceiveRound2Message
+}
+
+type message3 struct {
+	// F_ij = fᵢ(j) is the secret share sent from party i to party j.
+	F_ij  curve.Scalar
+	OtMsg *ot.CorreOTSetupSendRound1Message
+}
+
+// VerifyMessage implements round.Round.
+func (round3) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*message3)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.F_ij == nil || body.OtMsg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+// StoreMessage implements round.Round.
+//
+// - verify the VSS condition fⱼ(i)⋅G = Φⱼ(i).
+func (r *round3) StoreMessage(msg round.Message) (err error) {
+	from, body := msg.From, msg.Content.(*message3)
+
+	expected := body.F_ij.ActOnBase()
+	actual := r.Phi[from].Evaluate(r.SelfID().Scalar(r.Group()))
+	if !expected.Equal(actual) {
+		return errors.New("VSS failed to validate")
+	}
+	r.shareFrom[from] = body.F_ij
+
+	r.otMsgs[from], err = r.receivers[from].Round2(body.OtMsg)
+	return
+}
+
+// Finalize implements round.Round.
+func (r *round3) Finalize(out chan<- *round.Message) (round.Session, error) {
+	for _, j := range r.OtherPartyIDs() {
+		if err := r.SendMessage(out, &message4{OtMsg: r.otMsgs[j]}, j); err != nil {
+			return r, err
+		}
+	}
+	return &round4{
+		round3: r,
+		otMsgs: make(map[party.ID]*ot.CorreOTSetupSendRound2Message, r.N()-1),
+	}, nil
+}
+
+// RoundNumber implements round.Content.
+func (message3) RoundNumber() round.Number { return 3 }
+
+// MessageContent implements round.Round.
+func (r *round3) MessageContent() round.Content {
+	return &message3{F_ij: r.Group().NewScalar()}
+}
+
+// Number implements round.Round.
+func (round3) Number() round.Number { return 3 }
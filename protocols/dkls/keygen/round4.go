@@ -0,0 +1,59 @@
+package keygen
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+type round4 struct {
+	*round3
+	// otMsgs[j] is our second reply to j's OT setup messages.
+	otMsgs map[party.ID]*ot.CorreOTSetupSendRound2Message
+}
+
+type message4 struct {
+	OtMsg *ot.CorreOTSetupReceiveRound2Message
+}
+
+// VerifyMessage implements round.Round.
+func (round4) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*message4)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.OtMsg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+// StoreMessage implements round.Round.
+func (r *round4) StoreMessage(msg round.Message) error {
+	from, body := msg.From, msg.Content.(*message4)
+	r.otMsgs[from] = r.senders[from].Round2(body.OtMsg)
+	return nil
+}
+
+// Finalize implements round.Round.
+func (r *round4) Finalize(out chan<- *round.Message) (round.Session, error) {
+	for _, j := range r.OtherPartyIDs() {
+		if err := r.SendMessage(out, &message5{OtMsg: r.otMsgs[j]}, j); err != nil {
+			return r, err
+		}
+	}
+	return &round5{
+		round4:        r,
+		otMsgs:        make(map[party.ID]*ot.CorreOTSetupReceiveRound3Message, r.N()-1),
+		receiveSetups: make(map[party.ID]*ot.CorreOTReceiveSetup, r.N()-1),
+	}, nil
+}
+
+// RoundNumber implements round.Content.
+func (message4) RoundNumber() round.Number { return 4 }
+
+// MessageContent implements round.Round.
+func (round4) MessageContent() round.Content { return &message4{} }
+
+// Number implements round.Round.
+func (round4) Number() round.Number { return 4 }
@@ -0,0 +1,60 @@
+package keygen
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+type round5 struct {
+	*round4
+	// otMsgs[j] is our last OT setup message for j.
+	otMsgs map[party.ID]*ot.CorreOTSetupReceiveRound3Message
+	// receiveSetups[j] is the result of the OT setup where we are the Receiver, and j the Sender.
+	receiveSetups map[party.ID]*ot.CorreOTReceiveSetup
+}
+
+type message5 struct {
+	OtMsg *ot.CorreOTSetupSendRound2Message
+}
+
+// VerifyMessage implements round.Round.
+func (round5) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*message5)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.OtMsg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+// StoreMessage implements round.Round.
+func (r *round5) StoreMessage(msg round.Message) (err error) {
+	from, body := msg.From, msg.Content.(*message5)
+	r.otMsgs[from], r.receiveSetups[from], err = r.receivers[from].Round3(body.OtMsg)
+	return
+}
+
+// Finalize implements round.Round.
+func (r *round5) Finalize(out chan<- *round.Message) (round.Session, error) {
+	for _, j := range r.OtherPartyIDs() {
+		if err := r.SendMessage(out, &message6{OtMsg: r.otMsgs[j]}, j); err != nil {
+			return r, err
+		}
+	}
+	return &round6{
+		round5:     r,
+		sendSetups: make(map[party.ID]*ot.CorreOTSendSetup, r.N()-1),
+	}, nil
+}
+
+// RoundNumber implements round.Content.
+func (message5) RoundNumber() round.Number { return 5 }
+
+// MessageContent implements round.Round.
+func (round5) MessageContent() round.Content { return &message5{} }
+
+// Number implements round.Round.
+func (round5) Number() round.Number { return 5 }
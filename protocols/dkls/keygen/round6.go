@@ -0,0 +1,85 @@
+package keygen
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+type round6 struct {
+	*round5
+	// sendSetups[j] is the result of the OT setup where we are the Sender, and j the Receiver.
+	sendSetups map[party.ID]*ot.CorreOTSendSetup
+}
+
+type message6 struct {
+	OtMsg *ot.CorreOTSetupReceiveRound3Message
+}
+
+// VerifyMessage implements round.Round.
+func (round6) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*message6)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.OtMsg == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+// StoreMessage implements round.Round.
+func (r *round6) StoreMessage(msg round.Message) (err error) {
+	from, body := msg.From, msg.Content.(*message6)
+	r.sendSetups[from], err = r.senders[from].Round3(body.OtMsg)
+	return
+}
+
+// Finalize implements round.Round.
+//
+// - compute our share xᵢ = ∑ⱼ fⱼ(i), the public key X = ∑ⱼ Φⱼ(0), and the verification shares Xₗ = ∑ⱼ Φⱼ(l).
+func (r *round6) Finalize(chan<- *round.Message) (round.Session, error) {
+	group := r.Group()
+
+	privateShare := group.NewScalar().Set(r.privateShare)
+	for l, f_li := range r.shareFrom {
+		privateShare.Add(f_li)
+		delete(r.shareFrom, l)
+	}
+
+	publicKey := r.publicKey
+	exponents := make([]*polynomial.Exponent, 0, len(r.Phi))
+	for _, Phi_j := range r.Phi {
+		publicKey = publicKey.Add(Phi_j.Constant())
+		exponents = append(exponents, Phi_j)
+	}
+	verificationExponent, err := polynomial.Sum(exponents)
+	if err != nil {
+		return r, err
+	}
+	verificationShares := make(map[party.ID]curve.Point, len(r.verificationShares))
+	for l, X_l := range r.verificationShares {
+		verificationShares[l] = X_l.Add(verificationExponent.Evaluate(l.Scalar(group)))
+	}
+
+	return r.ResultRound(&Config{
+		ID:                 r.SelfID(),
+		Threshold:          r.threshold,
+		PrivateShare:       privateShare,
+		PublicKey:          publicKey,
+		VerificationShares: party.NewPointMap(verificationShares),
+		SendSetups:         r.sendSetups,
+		ReceiveSetups:      r.receiveSetups,
+	}), nil
+}
+
+// RoundNumber implements round.Content.
+func (message6) RoundNumber() round.Number { return 6 }
+
+// MessageContent implements round.Round.
+func (round6) MessageContent() round.Content { return &message6{} }
+
+// Number implements round.Round.
+func (round6) Number() round.Number { return 6 }
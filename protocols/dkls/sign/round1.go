@@ -0,0 +1,95 @@
+package sign
+
+import (
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/internal/params"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/protocols/dkls/keygen"
+)
+
+type round1 struct {
+	*round.Helper
+	config *keygen.Config
+	// hash is the message hash to be signed.
+	hash []byte
+}
+
+// VerifyMessage implements round.Round.
+func (r *round1) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (r *round1) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - sample the instance key kᵢ and the inversion mask ϕᵢ, and broadcast a commitment to Rᵢ = kᵢ⋅G.
+// - compute our additive share skᵢ = λᵢ⋅xᵢ of the secret key.
+// - for every other signer j, start the multiplication of ϕᵢ with the vector (kⱼ, skⱼ), as the Receiver.
+func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
+	group := r.Group()
+
+	k_i := sample.ScalarUnit(r.Rand(), group)
+	phi_i := sample.ScalarUnit(r.Rand(), group)
+	R_i := k_i.ActOnBase()
+
+	lambda := polynomial.Lagrange(group, r.PartyIDs())
+	sk_i := group.NewScalar().Set(lambda[r.SelfID()]).Mul(r.config.PrivateShare)
+	pk := make(map[party.ID]curve.Point, r.N())
+	for _, j := range r.PartyIDs() {
+		pk[j] = lambda[j].Act(r.config.VerificationShares.Points[j])
+	}
+
+	commitment, decommitment, err := r.HashForID(r.SelfID()).Commit(r.Rand(), R_i)
+	if err != nil {
+		return r, err
+	}
+	if err = r.BroadcastMessage(out, &broadcast2{Commitment: commitment}); err != nil {
+		return r, err
+	}
+
+	multiply := make(map[party.ID]*ot.VectorMultiplyReceiver, r.N()-1)
+	for _, j := range r.OtherPartyIDs() {
+		nonce := make([]byte, params.SecBytes)
+		if _, err = io.ReadFull(r.Rand(), nonce); err != nil {
+			return r, err
+		}
+		multiply[j], err = ot.NewVectorMultiplyReceiver(r.Rand(), multiplyHash(r.Helper, nonce, r.SelfID(), j), r.config.ReceiveSetups[j], phi_i, 2)
+		if err != nil {
+			return r, err
+		}
+		if err = r.SendMessage(out, &message2{
+			Nonce:  nonce,
+			MulMsg: multiply[j].Round1(),
+		}, j); err != nil {
+			return r, err
+		}
+	}
+
+	return &round2{
+		round1:       r,
+		k_i:          k_i,
+		phi_i:        phi_i,
+		sk_i:         sk_i,
+		pk:           pk,
+		R_i:          R_i,
+		decommitment: decommitment,
+		commitments:  make(map[party.ID]hash.Commitment, r.N()-1),
+		multiply:     multiply,
+		u_i:          group.NewScalar().Set(k_i).Mul(phi_i),
+		v_i:          group.NewScalar().Set(sk_i).Mul(phi_i),
+		mulMsgs:      make(map[party.ID]*message3, r.N()-1),
+	}, nil
+}
+
+// MessageContent implements round.Round.
+func (round1) MessageContent() round.Content { return nil }
+
+// Number implements round.Round.
+func (round1) Number() round.Number { return 1 }
@@ -0,0 +1,134 @@
+package sign
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/params"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+type round2 struct {
+	*round1
+	// k_i is our instance key, and phi_i our inversion mask.
+	k_i, phi_i curve.Scalar
+	// sk_i = λᵢ⋅xᵢ is our additive share of the secret key.
+	sk_i curve.Scalar
+	// pk[j] = λⱼ⋅Xⱼ is the public part of the additive share of j.
+	pk map[party.ID]curve.Point
+	// R_i = kᵢ⋅G is our nonce.
+	R_i          curve.Point
+	decommitment hash.Decommitment
+	// commitments[j] is the commitment to Rⱼ.
+	commitments map[party.ID]hash.Commitment
+	// multiply[j] computes shares of ϕᵢ⋅kⱼ and ϕᵢ⋅skⱼ, where we are the Receiver.
+	multiply map[party.ID]*ot.VectorMultiplyReceiver
+	// u_i and v_i accumulate our additive shares of k⋅ϕ and sk⋅ϕ.
+	u_i, v_i curve.Scalar
+	// mulMsgs[j] contains our reply to the multiplication started by j.
+	mulMsgs map[party.ID]*message3
+}
+
+type broadcast2 struct {
+	round.ReliableBroadcastContent
+	// Commitment = H(Rᵢ)
+	Commitment hash.Commitment
+}
+
+type message2 struct {
+	// Nonce is used to derive the context of the multiplication.
+	Nonce []byte
+	// MulMsg starts the multiplication of the sender's ϕ with our (k, sk).
+	MulMsg *ot.MultiplyReceiveRound1Message
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+func (r *round2) StoreBroadcastMessage(msg round.Message) error {
+	body, ok := msg.Content.(*broadcast2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if err := body.Commitment.Validate(); err != nil {
+		return err
+	}
+	r.commitments[msg.From] = body.Commitment
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round2) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*message2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.MulMsg == nil {
+		return round.ErrNilFields
+	}
+	if len(body.Nonce) != params.SecBytes {
+		return errors.New("invalid nonce length")
+	}
+	return nil
+}
+
+// StoreMessage implements round.Round.
+//
+// - complete the multiplication of (kᵢ, skᵢ) with ϕⱼ as the Sender, obtaining our shares cᵘᵢⱼ and cᵛᵢⱼ.
+// - prepare Γᵘᵢⱼ = cᵘᵢⱼ⋅G and Γᵛᵢⱼ = cᵛᵢⱼ⋅G, so that j can check the consistency of our inputs.
+func (r *round2) StoreMessage(msg round.Message) error {
+	from, body := msg.From, msg.Content.(*message2)
+
+	multiply := ot.NewVectorMultiplySender(r.Rand(), multiplyHash(r.Helper, body.Nonce, from, r.SelfID()), r.config.SendSetups[from], []curve.Scalar{r.k_i, r.sk_i})
+	mulMsg, c, err := multiply.Round1(body.MulMsg)
+	if err != nil {
+		return err
+	}
+	c_u, c_v := c[0], c[1]
+
+	r.u_i.Add(c_u)
+	r.v_i.Add(c_v)
+	r.mulMsgs[from] = &message3{
+		MulMsg: mulMsg,
+		GammaU: c_u.ActOnBase(),
+		GammaV: c_v.ActOnBase(),
+	}
+	return nil
+}
+
+// Finalize implements round.Round
+//
+// - broadcast Rᵢ with its decommitment, and send our multiplication messages.
+func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if err := r.BroadcastMessage(out, &broadcast3{
+		R_i:          r.R_i,
+		Decommitment: r.decommitment,
+	}); err != nil {
+		return r, err
+	}
+	for _, j := range r.OtherPartyIDs() {
+		if err := r.SendMessage(out, r.mulMsgs[j], j); err != nil {
+			return r, err
+		}
+	}
+	return &round3{
+		round2: r,
+		R:      map[party.ID]curve.Point{r.SelfID(): r.R_i},
+	}, nil
+}
+
+// RoundNumber implements round.Content.
+func (message2) RoundNumber() round.Number { return 2 }
+
+// MessageContent implements round.Round.
+func (round2) MessageContent() round.Content { return &message2{} }
+
+// RoundNumber implements round.Content.
+func (broadcast2) RoundNumber() round.Number { return 2 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (round2) BroadcastContent() round.BroadcastContent { return &broadcast2{} }
+
+// Number implements round.Round.
+func (round2) Number() round.Number { return 2 }
@@ -0,0 +1,157 @@
+package sign
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+type round3 struct {
+	*round2
+	// R[j] = Rⱼ is the nonce of each signer, ourselves included.
+	R map[party.ID]curve.Point
+}
+
+type broadcast3 struct {
+	round.ReliableBroadcastContent
+	// R_i = kᵢ⋅G
+	R_i          curve.Point
+	Decommitment hash.Decommitment
+}
+
+type message3 struct {
+	// MulMsg completes the multiplication of our ϕ with the sender's (k, sk).
+	MulMsg *ot.MultiplySendRound1Message
+	// GammaU = cᵘ⋅G and GammaV = cᵛ⋅G, where cᵘ and cᵛ are the sender's shares of the products.
+	GammaU, GammaV curve.Point
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - verify the decommitment of Rⱼ.
+func (r *round3) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcast3)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.R_i == nil {
+		return round.ErrNilFields
+	}
+	if body.R_i.IsIdentity() {
+		return errors.New("nonce is the identity point")
+	}
+	if err := body.Decommitment.Validate(); err != nil {
+		return err
+	}
+	if !r.HashForID(from).Decommit(r.commitments[from], body.Decommitment, body.R_i) {
+		return errors.New("failed to decommit nonce")
+	}
+	r.R[from] = body.R_i
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round3) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*message3)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.MulMsg == nil || body.GammaU == nil || body.GammaV == nil {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+// StoreMessage implements round.Round.
+//
+// - complete the multiplication with j, obtaining our shares dᵘᵢⱼ and dᵛᵢⱼ.
+// - check that dᵘᵢⱼ⋅G = ϕᵢ⋅Rⱼ - Γᵘⱼᵢ and dᵛᵢⱼ⋅G = ϕᵢ⋅pkⱼ - Γᵛⱼᵢ,
+// which ensures that j used the inputs kⱼ and skⱼ in the multiplication.
+func (r *round3) StoreMessage(msg round.Message) error {
+	from, body := msg.From, msg.Content.(*message3)
+
+	R_j, ok := r.R[from]
+	if !ok {
+		return errors.New("missing nonce")
+	}
+
+	d, err := r.multiply[from].Round2(body.MulMsg)
+	if err != nil {
+		return err
+	}
+	d_u, d_v := d[0], d[1]
+
+	if !d_u.ActOnBase().Equal(r.phi_i.Act(R_j).Sub(body.GammaU)) {
+		return errors.New("inconsistent nonce in multiplication")
+	}
+	if !d_v.ActOnBase().Equal(r.phi_i.Act(r.pk[from]).Sub(body.GammaV)) {
+		return errors.New("inconsistent secret share in multiplication")
+	}
+
+	r.u_i.Add(d_u)
+	r.v_i.Add(d_v)
+	return nil
+}
+
+// Finalize implements round.Round
+//
+// - compute R = ∑ⱼ Rⱼ, and broadcast uᵢ and wᵢ = H(m)⋅ϕᵢ + r⋅vᵢ, where r is the x coordinate of R.
+func (r *round3) Finalize(out chan<- *round.Message) (round.Session, error) {
+	group := r.Group()
+
+	R := group.NewPoint()
+	for _, R_j := range r.R {
+		R = R.Add(R_j)
+	}
+	if R.IsIdentity() {
+		return r, errors.New("nonce is the identity point")
+	}
+
+	m := curve.FromHash(group, r.hash)
+	w_i := group.NewScalar().Set(R.XScalar()).Mul(r.v_i)
+	w_i.Add(m.Mul(r.phi_i))
+
+	if err := r.BroadcastMessage(out, &broadcast4{
+		U_i: r.u_i,
+		W_i: w_i,
+	}); err != nil {
+		return r, err
+	}
+
+	return &round4{
+		round3: r,
+		RSum:   R,
+		U:      map[party.ID]curve.Scalar{r.SelfID(): r.u_i},
+		W:      map[party.ID]curve.Scalar{r.SelfID(): w_i},
+	}, nil
+}
+
+// RoundNumber implements round.Content.
+func (message3) RoundNumber() round.Number { return 3 }
+
+// MessageContent implements round.Round.
+func (r *round3) MessageContent() round.Content {
+	group := r.Group()
+	content := &message3{
+		GammaU: group.NewPoint(),
+		GammaV: group.NewPoint(),
+	}
+	content.MulMsg = ot.EmptyMultiplySendRound1Message(group)
+	return content
+}
+
+// RoundNumber implements round.Content.
+func (broadcast3) RoundNumber() round.Number { return 3 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *round3) BroadcastContent() round.BroadcastContent {
+	return &broadcast3{R_i: r.Group().NewPoint()}
+}
+
+// Number implements round.Round.
+func (round3) Number() round.Number { return 3 }
@@ -0,0 +1,89 @@
+package sign
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+type round4 struct {
+	*round3
+	// RSum = R = ∑ⱼ Rⱼ is the nonce of the signature.
+	RSum curve.Point
+	// U[j], W[j] = (uⱼ, wⱼ) are the shares of each signer, ourselves included.
+	U, W map[party.ID]curve.Scalar
+}
+
+type broadcast4 struct {
+	round.NormalBroadcastContent
+	// U_i is an additive share of k⋅ϕ.
+	U_i curve.Scalar
+	// W_i is an additive share of (H(m) + r⋅sk)⋅ϕ.
+	W_i curve.Scalar
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+func (r *round4) StoreBroadcastMessage(msg round.Message) error {
+	body, ok := msg.Content.(*broadcast4)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.U_i == nil || body.W_i == nil {
+		return round.ErrNilFields
+	}
+	r.U[msg.From] = body.U_i
+	r.W[msg.From] = body.W_i
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round4) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round4) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - compute s = (∑ⱼ wⱼ)/(∑ⱼ uⱼ) = (H(m) + r⋅sk)/k, and verify the signature (R, s).
+func (r *round4) Finalize(chan<- *round.Message) (round.Session, error) {
+	group := r.Group()
+
+	u := group.NewScalar()
+	w := group.NewScalar()
+	for _, j := range r.PartyIDs() {
+		u.Add(r.U[j])
+		w.Add(r.W[j])
+	}
+	if u.IsZero() {
+		return r, errors.New("shares of the inverted nonce sum to zero")
+	}
+
+	sig := &ecdsa.Signature{
+		R: r.RSum,
+		S: w.Mul(u.Invert()),
+	}
+	if !sig.Verify(r.config.PublicKey, r.hash) {
+		return r.AbortRound(errors.New("failed to validate signature")), nil
+	}
+	return r.ResultRound(sig), nil
+}
+
+// MessageContent implements round.Round.
+func (round4) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast4) RoundNumber() round.Number { return 4 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *round4) BroadcastContent() round.BroadcastContent {
+	return &broadcast4{
+		U_i: r.Group().NewScalar(),
+		W_i: r.Group().NewScalar(),
+	}
+}
+
+// Number implements round.Round.
+func (round4) Number() round.Number { return 4 }
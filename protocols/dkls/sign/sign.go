@@ -0,0 +1,83 @@
+package sign
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/dkls/keygen"
+)
+
+const (
+	// DKLs Sign with Threshold.
+	protocolID = "dkls/sign-threshold"
+	// This protocol has 4 concrete rounds.
+	protocolRounds round.Number = 4
+)
+
+// These assert that our rounds implement the round.Round interface.
+var (
+	_ round.Round = (*round1)(nil)
+	_ round.Round = (*round2)(nil)
+	_ round.Round = (*round3)(nil)
+	_ round.Round = (*round4)(nil)
+)
+
+// StartSign starts the signature protocol, given a message hash.
+//
+// This corresponds to Protocol 3.6 of https://eprint.iacr.org/2023/765, where the random
+// VOLE functionality is replaced, for each ordered pair of signers, by the OT based multiplication of
+// https://eprint.iacr.org/2018/499 of the Receiver's ϕ with the vector (k, sk) of the Sender.
+// Both products are derived from the same choice bits, so that the Receiver uses the same ϕ in each.
+//
+// The signers must contain at least config.Threshold + 1 participants of the key generation.
+func StartSign(config *keygen.Config, signers []party.ID, messageHash []byte, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if config.Group().NewBasePoint().XScalar() == nil {
+			return nil, fmt.Errorf("sign.StartSign: group %s does not support ECDSA", config.Group().Name())
+		}
+
+		signerIDs := party.NewIDSlice(signers)
+		if err := config.CanSign(signerIDs); err != nil {
+			return nil, fmt.Errorf("sign.StartSign: %w", err)
+		}
+
+		info := round.Info{
+			ProtocolID:       protocolID,
+			FinalRoundNumber: protocolRounds,
+			SelfID:           config.ID,
+			PartyIDs:         signerIDs,
+			Threshold:        config.Threshold,
+			Group:            config.Group(),
+		}
+		info.Apply(opts...)
+
+		publicKey, err := config.PublicKey.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartSign: %w", err)
+		}
+		helper, err := round.NewSession(info, sessionID, nil, &hash.BytesWithDomain{
+			TheDomain: "Public Key",
+			Bytes:     publicKey,
+		}, types.SigningMessage(messageHash))
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartSign: %w", err)
+		}
+
+		return &round1{
+			Helper: helper,
+			config: config,
+			hash:   messageHash,
+		}, nil
+	}
+}
+
+// multiplyHash returns the context for the multiplication between receiver and sender.
+//
+// The nonce is chosen by the receiver, so that the OT setups are never used twice with the same context.
+func multiplyHash(helper *round.Helper, nonce []byte, receiver, sender party.ID) *hash.Hash {
+	return helper.Hash().Fork(&hash.BytesWithDomain{TheDomain: "Multiply", Bytes: nonce}, receiver, sender)
+}
@@ -0,0 +1,109 @@
+package sign
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/protocols/dkls/keygen"
+)
+
+func generateConfigs(t *testing.T, group curve.Curve, partyIDs party.IDSlice, threshold int) map[party.ID]*keygen.Config {
+	rounds := make([]round.Session, 0, len(partyIDs))
+	for _, id := range partyIDs {
+		r, err := keygen.StartKeygen(group, partyIDs, threshold, id, nil, nil, nil, nil)(nil)
+		require.NoError(t, err)
+		rounds = append(rounds, r)
+	}
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err)
+		if done {
+			break
+		}
+	}
+	configs := make(map[party.ID]*keygen.Config, len(partyIDs))
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		configs[r.SelfID()] = r.(*round.Output).Result.(*keygen.Config)
+	}
+	return configs
+}
+
+func runSign(t *testing.T, configs map[party.ID]*keygen.Config, signers party.IDSlice, messageHash []byte, rule test.Rule) error {
+	rounds := make([]round.Session, 0, len(signers))
+	for _, id := range signers {
+		r, err := StartSign(configs[id], signers, messageHash)(nil)
+		require.NoError(t, err)
+		rounds = append(rounds, r)
+	}
+	for {
+		err, done := test.Rounds(rounds, rule)
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		signature := r.(*round.Output).Result.(*ecdsa.Signature)
+		assert.True(t, signature.Verify(configs[r.SelfID()].PublicKey, messageHash))
+	}
+	return nil
+}
+
+func TestSign(t *testing.T) {
+	N, T := 4, 2
+	messageHash := sha256.Sum256([]byte("hello"))
+
+	for _, group := range []curve.Curve{curve.Secp256k1{}, curve.P256{}} {
+		partyIDs := test.PartyIDs(N)
+		configs := generateConfigs(t, group, partyIDs, T)
+
+		require.NoError(t, runSign(t, configs, partyIDs[1:], messageHash[:], nil))
+		require.NoError(t, runSign(t, configs, partyIDs, messageHash[:], nil))
+
+		_, err := StartSign(configs[partyIDs[0]], partyIDs[:T], messageHash[:])(nil)
+		assert.Error(t, err, "signing with only threshold signers should fail")
+	}
+}
+
+// inconsistentMultiplication changes the value of Γᵘ sent by culprit.
+type inconsistentMultiplication struct {
+	culprit party.ID
+}
+
+func (inconsistentMultiplication) ModifyBefore(round.Session) {}
+func (inconsistentMultiplication) ModifyAfter(round.Session)  {}
+func (rule inconsistentMultiplication) ModifyContent(rNext round.Session, _ party.ID, content round.Content) {
+	if body, ok := content.(*message3); ok && rNext.SelfID() == rule.culprit {
+		body.GammaU = body.GammaU.Add(rNext.Group().NewBasePoint())
+	}
+}
+
+func TestSignUnsupportedGroup(t *testing.T) {
+	group := curve.Edwards25519{}
+	messageHash := sha256.Sum256([]byte("hello"))
+	config := &keygen.Config{ID: "a", PublicKey: group.NewBasePoint()}
+	_, err := StartSign(config, []party.ID{"a"}, messageHash[:])(nil)
+	assert.ErrorContains(t, err, "does not support ECDSA")
+}
+
+func TestSignInconsistentMultiplication(t *testing.T) {
+	N, T := 3, 1
+	messageHash := sha256.Sum256([]byte("hello"))
+
+	partyIDs := test.PartyIDs(N)
+	configs := generateConfigs(t, curve.Secp256k1{}, partyIDs, T)
+
+	err := runSign(t, configs, partyIDs, messageHash[:], inconsistentMultiplication{culprit: partyIDs[0]})
+	assert.ErrorContains(t, err, "inconsistent nonce in multiplication")
+}
@@ -1,6 +1,8 @@
 package doerner
 
 import (
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa/presignstore"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
 	"github.com/taurusgroup/multi-party-sig/pkg/pool"
@@ -40,8 +42,8 @@ func EmptyConfigSender(group curve.Curve) *ConfigSender {
 // a ConfigReceiver, but the Sender will get a ConfigSender instead.
 //
 // A pool can be passed to this function, to parallelize certain operations and improve performance.
-func Keygen(group curve.Curve, receiver bool, selfID, otherID party.ID, pl *pool.Pool) protocol.StartFunc {
-	return keygen.StartKeygen(group, receiver, selfID, otherID, nil, nil, pl)
+func Keygen(group curve.Curve, receiver bool, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return keygen.StartKeygen(group, receiver, selfID, otherID, nil, nil, pl, opts...)
 }
 
 // RefreshReceiver initiates a key-refresh protocol, from the Receiver's perspective.
@@ -51,15 +53,15 @@ func Keygen(group curve.Curve, receiver bool, selfID, otherID party.ID, pl *pool
 //
 // This won't change the value of the public key, but it will change the value of the chaining key.
 // If this isn't desirable, then the new chain key can simply be overwritten with the previous value.
-func RefreshReceiver(config *ConfigReceiver, selfID, otherID party.ID, pl *pool.Pool) protocol.StartFunc {
-	return keygen.StartKeygen(config.Group(), true, selfID, otherID, config.SecretShare, config.Public, pl)
+func RefreshReceiver(config *ConfigReceiver, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return keygen.StartKeygen(config.Group(), true, selfID, otherID, config.SecretShare, config.Public, pl, opts...)
 }
 
 // RefreshSender initiates a key-refresh protocol, from the Sender's perspective.
 //
 // See RefreshReceiver.
-func RefreshSender(config *ConfigSender, selfID, otherID party.ID, pl *pool.Pool) protocol.StartFunc {
-	return keygen.StartKeygen(config.Group(), false, selfID, otherID, config.SecretShare, config.Public, pl)
+func RefreshSender(config *ConfigSender, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return keygen.StartKeygen(config.Group(), false, selfID, otherID, config.SecretShare, config.Public, pl, opts...)
 }
 
 // SignReceiver initiates the signing process, given a message hash.
@@ -70,13 +72,53 @@ func RefreshSender(config *ConfigSender, selfID, otherID party.ID, pl *pool.Pool
 // The result, in both cases, will be an ecdsa.Signature type.
 //
 // A pool can be passed to this function, to parallelize certain operations and improve performance.
-func SignReceiver(config *ConfigReceiver, selfID, otherID party.ID, hash []byte, pl *pool.Pool) protocol.StartFunc {
-	return sign.StartSignReceiver(config, selfID, otherID, hash, pl)
+func SignReceiver(config *ConfigReceiver, selfID, otherID party.ID, hash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartSignReceiver(config, selfID, otherID, hash, pl, opts...)
 }
 
 // SignSender is like SignReceiver, but using the Sender's results from key generation.
 //
 // See SignReceiver for more information.
-func SignSender(config *ConfigSender, selfID, otherID party.ID, hash []byte, pl *pool.Pool) protocol.StartFunc {
-	return sign.StartSignSender(config, selfID, otherID, hash, pl)
+func SignSender(config *ConfigSender, selfID, otherID party.ID, hash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartSignSender(config, selfID, otherID, hash, pl, opts...)
+}
+
+// PresignReceiver generates a presignature, before the message to sign is known.
+//
+// This runs the expensive multiplications of SignReceiver ahead of time, so that PresignOnline
+// only requires each party to send a single message once the message is known.
+//
+// The result, for both the Receiver and the Sender, will be an *ecdsa.PreSignature.
+// It should be treated as secret key material, and used for at most one message,
+// for example by keeping it in a presignstore.Store.
+func PresignReceiver(config *ConfigReceiver, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartPresignReceiver(config, selfID, otherID, pl, opts...)
+}
+
+// PresignSender is like PresignReceiver, but using the Sender's results from key generation.
+//
+// See PresignReceiver for more information.
+func PresignSender(config *ConfigSender, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartPresignSender(config, selfID, otherID, pl, opts...)
+}
+
+// PresignOnline generates an ECDSA signature for a given message hash, using a presignature
+// from PresignReceiver or PresignSender, and the shared public key.
+// receiver must be true for the presignature obtained from PresignReceiver.
+//
+// As in SignReceiver, only the Sender reveals its share of the signature.
+// The Receiver outputs the signature, and sends it to the Sender only if it is valid.
+// Both handlers can be created as leaders.
+// The presignature must not be used again, even if this protocol fails.
+//
+// The result will be an ecdsa.Signature type.
+func PresignOnline(public curve.Point, preSignature *ecdsa.PreSignature, receiver bool, selfID, otherID party.ID, hash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartPresignOnline(public, preSignature, receiver, selfID, otherID, hash, pl, opts...)
+}
+
+// PresignOnlineFromStore is like PresignOnline, but uses the presignature with the given ID from `store`.
+//
+// The presignature is consumed before our share of the signature is sent, so that it can never be used twice.
+func PresignOnlineFromStore(public curve.Point, store presignstore.Store, preSignatureID []byte, receiver bool, selfID, otherID party.ID, hash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartPresignOnlineFromStore(public, store, preSignatureID, receiver, selfID, otherID, hash, pl, opts...)
 }
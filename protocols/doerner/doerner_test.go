@@ -6,9 +6,11 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/cronokirby/saferith"
 	"github.com/stretchr/testify/require"
 	"github.com/taurusgroup/multi-party-sig/internal/test"
 	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa/presignstore"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
 	"github.com/taurusgroup/multi-party-sig/pkg/pool"
@@ -168,6 +170,164 @@ func TestSign(t *testing.T) {
 	require.True(t, sig.Verify(configReceiver.Public, testHash))
 }
 
+func runPresign(partyIDs party.IDSlice, configSender *ConfigSender, configReceiver *ConfigReceiver) (*ecdsa.PreSignature, *ecdsa.PreSignature, error) {
+	h0, err := protocol.NewTwoPartyHandler(PresignReceiver(configReceiver, partyIDs[0], partyIDs[1], nil), []byte("session"), true)
+	if err != nil {
+		return nil, nil, err
+	}
+	h1, err := protocol.NewTwoPartyHandler(PresignSender(configSender, partyIDs[1], partyIDs[0], nil), []byte("session"), true)
+	if err != nil {
+		return nil, nil, err
+	}
+	var wg sync.WaitGroup
+	network := test.NewNetwork(partyIDs)
+	wg.Add(2)
+	go runHandler(&wg, partyIDs[0], h0, network)
+	go runHandler(&wg, partyIDs[1], h1, network)
+	wg.Wait()
+
+	resultRound0, err := h0.Result()
+	if err != nil {
+		return nil, nil, err
+	}
+	preSignatureReceiver, ok := resultRound0.(*ecdsa.PreSignature)
+	if !ok {
+		return nil, nil, errors.New("failed to cast result to PreSignature")
+	}
+	resultRound1, err := h1.Result()
+	if err != nil {
+		return nil, nil, err
+	}
+	preSignatureSender, ok := resultRound1.(*ecdsa.PreSignature)
+	if !ok {
+		return nil, nil, errors.New("failed to cast result to PreSignature")
+	}
+	return preSignatureSender, preSignatureReceiver, nil
+}
+
+func runPresignOnline(partyIDs party.IDSlice, start0, start1 protocol.StartFunc) (*ecdsa.Signature, error) {
+	h0, err := protocol.NewTwoPartyHandler(start0, []byte("session"), true)
+	if err != nil {
+		return nil, err
+	}
+	h1, err := protocol.NewTwoPartyHandler(start1, []byte("session"), true)
+	if err != nil {
+		return nil, err
+	}
+	var wg sync.WaitGroup
+	network := test.NewNetwork(partyIDs)
+	wg.Add(2)
+	go runHandler(&wg, partyIDs[0], h0, network)
+	go runHandler(&wg, partyIDs[1], h1, network)
+	wg.Wait()
+
+	resultRound0, err := h0.Result()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = h1.Result(); err != nil {
+		return nil, err
+	}
+	sig, ok := resultRound0.(*ecdsa.Signature)
+	if !ok {
+		return nil, errors.New("failed to cast result to Signature")
+	}
+	return sig, nil
+}
+
+func TestPresign(t *testing.T) {
+	partyIDs := test.PartyIDs(2)
+
+	configSender, configReceiver, err := runKeygen(partyIDs)
+	require.NoError(t, err)
+	public := configSender.Public
+
+	preSignatureSender, preSignatureReceiver, err := runPresign(partyIDs, configSender, configReceiver)
+	require.NoError(t, err)
+	require.NoError(t, preSignatureSender.Validate())
+	require.NoError(t, preSignatureReceiver.Validate())
+	require.Equal(t, preSignatureSender.ID, preSignatureReceiver.ID)
+	require.True(t, preSignatureSender.R.Equal(preSignatureReceiver.R))
+
+	sig, err := runPresignOnline(partyIDs,
+		PresignOnline(public, preSignatureReceiver, true, partyIDs[0], partyIDs[1], testHash, nil),
+		PresignOnline(public, preSignatureSender, false, partyIDs[1], partyIDs[0], testHash, nil))
+	require.NoError(t, err)
+	require.True(t, sig.Verify(public, testHash))
+
+	// Presignatures can be kept in a store, which ensures that they are used only once.
+	preSignatureSender, preSignatureReceiver, err = runPresign(partyIDs, configSender, configReceiver)
+	require.NoError(t, err)
+	storeReceiver, storeSender := presignstore.NewMemoryStore(), presignstore.NewMemoryStore()
+	require.NoError(t, storeReceiver.Put(preSignatureReceiver))
+	require.NoError(t, storeSender.Put(preSignatureSender))
+	id := preSignatureSender.ID
+
+	sig, err = runPresignOnline(partyIDs,
+		PresignOnlineFromStore(public, storeReceiver, id, true, partyIDs[0], partyIDs[1], testHash, nil),
+		PresignOnlineFromStore(public, storeSender, id, false, partyIDs[1], partyIDs[0], testHash, nil))
+	require.NoError(t, err)
+	require.True(t, sig.Verify(public, testHash))
+
+	_, err = PresignOnlineFromStore(public, storeReceiver, id, true, partyIDs[0], partyIDs[1], []byte("other hash"), nil)([]byte("session"))
+	require.ErrorIs(t, err, presignstore.ErrConsumed)
+}
+
+func TestPresignInvalidShare(t *testing.T) {
+	partyIDs := test.PartyIDs(2)
+
+	configSender, configReceiver, err := runKeygen(partyIDs)
+	require.NoError(t, err)
+	public := configSender.Public
+
+	preSignatureSender, preSignatureReceiver, err := runPresign(partyIDs, configSender, configReceiver)
+	require.NoError(t, err)
+	preSignatureSender.ChiShare.Add(testGroup.NewScalar().SetNat(new(saferith.Nat).SetUint64(1)))
+
+	sigmas := map[party.ID]ecdsa.SignatureShare{
+		partyIDs[0]: preSignatureReceiver.SignatureShare(testHash),
+		partyIDs[1]: preSignatureSender.SignatureShare(testHash),
+	}
+	require.Equal(t, []party.ID{partyIDs[1]}, preSignatureReceiver.VerifySignatureShares(sigmas, testHash))
+
+	_, err = runPresignOnline(partyIDs,
+		PresignOnline(public, preSignatureReceiver, true, partyIDs[0], partyIDs[1], testHash, nil),
+		PresignOnline(public, preSignatureSender, false, partyIDs[1], partyIDs[0], testHash, nil))
+	require.Error(t, err)
+}
+
+func TestPresignOnlineReceiverShare(t *testing.T) {
+	partyIDs := test.PartyIDs(2)
+
+	configSender, configReceiver, err := runKeygen(partyIDs)
+	require.NoError(t, err)
+	public := configSender.Public
+	preSignatureSender, preSignatureReceiver, err := runPresign(partyIDs, configSender, configReceiver)
+	require.NoError(t, err)
+
+	h0, err := protocol.NewTwoPartyHandler(PresignOnline(public, preSignatureReceiver, true, partyIDs[0], partyIDs[1], testHash, nil), []byte("session"), true)
+	require.NoError(t, err)
+	h1, err := protocol.NewTwoPartyHandler(PresignOnline(public, preSignatureSender, false, partyIDs[1], partyIDs[0], testHash, nil), []byte("session"), true)
+	require.NoError(t, err)
+
+	select {
+	case <-h0.Listen():
+		t.Fatal("the Receiver should not send its share of the signature")
+	default:
+	}
+	h0.Accept(<-h1.Listen())
+	sig, err := h0.Result()
+	require.NoError(t, err)
+
+	// the Receiver only sends the verified signature
+	msg := <-h0.Listen()
+	require.EqualValues(t, 3, msg.RoundNumber)
+	h1.Accept(msg)
+	sigSender, err := h1.Result()
+	require.NoError(t, err)
+	require.Equal(t, sig, sigSender)
+}
+
 func BenchmarkSign(t *testing.B) {
 	t.StopTimer()
 	partyIDs := test.PartyIDs(2)
@@ -177,3 +337,63 @@ func BenchmarkSign(t *testing.B) {
 		runSign(partyIDs, configSender, configReceiver)
 	}
 }
+
+func TestKeygenUnsupportedGroup(t *testing.T) {
+	partyIDs := test.PartyIDs(2)
+	_, err := Keygen(curve.Edwards25519{}, true, partyIDs[0], partyIDs[1], nil)(nil)
+	require.ErrorContains(t, err, "does not support ECDSA")
+}
+
+func TestKeygenResume(t *testing.T) {
+	partyIDs := test.PartyIDs(2)
+	pl := pool.NewPool(0)
+	defer pl.TearDown()
+
+	start := map[party.ID]protocol.StartFunc{
+		partyIDs[0]: Keygen(testGroup, true, partyIDs[0], partyIDs[1], pl),
+		partyIDs[1]: Keygen(testGroup, false, partyIDs[1], partyIDs[0], pl),
+	}
+	handlers := map[party.ID]*protocol.TwoPartyHandler{}
+	for _, id := range partyIDs {
+		h, err := protocol.NewTwoPartyHandler(start[id], []byte("session"), id == partyIDs[0])
+		require.NoError(t, err)
+		handlers[id] = h
+	}
+
+	// restart the recipient of each message from its saved state before delivering it
+	for delivered := true; delivered; {
+		delivered = false
+		for _, from := range partyIDs {
+			to := partyIDs[0]
+			if from == to {
+				to = partyIDs[1]
+			}
+			select {
+			case msg, ok := <-handlers[from].Listen():
+				if !ok {
+					continue
+				}
+				delivered = true
+				if _, err := handlers[to].Result(); err != nil {
+					state, err := handlers[to].MarshalBinary()
+					require.NoError(t, err)
+					handlers[to], err = protocol.ResumeTwoPartyHandler(start[to], state)
+					require.NoError(t, err)
+				}
+				handlers[to].Accept(msg)
+			default:
+			}
+		}
+	}
+
+	resultReceiver, err := handlers[partyIDs[0]].Result()
+	require.NoError(t, err)
+	resultSender, err := handlers[partyIDs[1]].Result()
+	require.NoError(t, err)
+	configReceiver, configSender := resultReceiver.(*ConfigReceiver), resultSender.(*ConfigSender)
+	checkKeygenOutput(t, configSender, configReceiver)
+
+	sig, err := runSign(partyIDs, configSender, configReceiver)
+	require.NoError(t, err)
+	require.True(t, sig.Verify(configReceiver.Public, testHash))
+}
@@ -1,12 +1,11 @@
 package keygen
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
 
 	"github.com/taurusgroup/multi-party-sig/internal/bip32"
-	"github.com/taurusgroup/multi-party-sig/internal/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
 	"github.com/taurusgroup/multi-party-sig/internal/params"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
@@ -100,8 +99,14 @@ func (c *ConfigSender) Group() curve.Curve {
 // The Receiver plays the role of "Bob", and the Sender plays the role of "Alice".
 //
 // If the secret share and public point are not nil, a refresh is done instead.
-func StartKeygen(group curve.Curve, receiver bool, selfID, otherID party.ID, secretShare curve.Scalar, public curve.Point, pl *pool.Pool) protocol.StartFunc {
+//
+// The group must support ECDSA, which excludes curves such as Edwards25519 whose points have no XScalar.
+func StartKeygen(group curve.Curve, receiver bool, selfID, otherID party.ID, secretShare curve.Scalar, public curve.Point, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
+		if group.NewBasePoint().XScalar() == nil {
+			return nil, fmt.Errorf("keygen.StartKeygen: group %s does not support ECDSA", group.Name())
+		}
+
 		info := round.Info{
 			ProtocolID:       "doerner/keygen",
 			FinalRoundNumber: 3,
@@ -110,36 +115,39 @@ func StartKeygen(group curve.Curve, receiver bool, selfID, otherID party.ID, sec
 			Threshold:        1,
 			Group:            group,
 		}
+		info.Apply(opts...)
 
 		helper, err := round.NewSession(info, sessionID, nil)
 		if err != nil {
 			return nil, fmt.Errorf("keygen.StartKeygen: %w", err)
 		}
 
+		// the arguments are not modified, so that the StartFunc can be called again to resume an execution
 		refresh := true
+		share := secretShare
 		if secretShare == nil && public == nil {
-			secretShare = sample.Scalar(rand.Reader, group)
+			share = sample.Scalar(helper.Rand(), group)
 			refresh = false
 		}
-		publicShare := secretShare.ActOnBase()
+		publicShare := share.ActOnBase()
 
 		if receiver {
 			return &round1R{
 				Helper:      helper,
 				refresh:     refresh,
-				secretShare: secretShare,
+				secretShare: share,
 				publicShare: publicShare,
 				public:      public,
-				receiver:    ot.NewCorreOTSetupReceiver(pl, helper.Hash(), helper.Group()),
+				receiver:    ot.NewCorreOTSetupReceiver(helper.Rand(), pl, helper.Hash(), helper.Group()),
 			}, nil
 		}
 		return &round1S{
 			Helper:      helper,
 			refresh:     refresh,
-			secretShare: secretShare,
+			secretShare: share,
 			publicShare: publicShare,
 			public:      public,
-			sender:      ot.NewCorreOTSetupSender(pl, helper.Hash()),
+			sender:      ot.NewCorreOTSetupSender(helper.Rand(), pl, helper.Hash()),
 		}, nil
 	}
 }
@@ -0,0 +1,234 @@
+package keygen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/internal/params"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	zksch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+)
+
+var (
+	_ round.Restorer    = (*round1R)(nil)
+	_ round.Snapshotter = (*round2R)(nil)
+	_ round.Snapshotter = (*round3R)(nil)
+	_ round.Restorer    = (*round1S)(nil)
+	_ round.Snapshotter = (*round2S)(nil)
+	_ round.Snapshotter = (*round3S)(nil)
+)
+
+// snapshot contains the state of either party from round 2 onwards.
+// Fields which are set in later rounds, or by the other party, are empty.
+type snapshot struct {
+	// The secret share is sampled when the protocol starts, and updated with the refresh scalars in round 2.
+	SecretShare []byte
+	PublicShare []byte
+	// Public is empty until the other party's public share is received, unless refreshing.
+	Public []byte
+	// OT is the state of the underlying OT setup.
+	OT []byte
+	// ChainKey is our contribution to the chain key, and then the collective chain key.
+	ChainKey      []byte
+	RefreshScalar []byte
+
+	// round2R
+	Proof            []byte
+	Decommit         hash.Decommitment
+	ChainKeyDecommit hash.Decommitment
+	RefreshDecommit  hash.Decommitment
+	OurChainKey      []byte
+
+	// round2S
+	ReceiverCommit hash.Commitment
+	ChainKeyCommit hash.Commitment
+	RefreshCommit  hash.Commitment
+}
+
+// Snapshot implements round.Snapshotter.
+func (r *round2R) Snapshot() ([]byte, error) { return r.marshalSnapshot() }
+
+// Snapshot implements round.Snapshotter.
+func (r *round3R) Snapshot() ([]byte, error) { return r.marshalSnapshot() }
+
+// Snapshot implements round.Snapshotter.
+func (r *round2S) Snapshot() ([]byte, error) { return r.marshalSnapshot() }
+
+// Snapshot implements round.Snapshotter.
+func (r *round3S) Snapshot() ([]byte, error) { return r.marshalSnapshot() }
+
+// marshalSnapshot encodes the state of the Receiver, which is the same in round2R and round3R.
+func (r *round2R) marshalSnapshot() ([]byte, error) {
+	s, err := marshalKey(r.secretShare, r.publicShare, r.public)
+	if err != nil {
+		return nil, err
+	}
+	if s.OT, err = r.receiver.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if s.RefreshScalar, err = r.refreshScalar.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if s.Proof, err = cbor.Marshal(r.proof); err != nil {
+		return nil, err
+	}
+	s.ChainKey = r.chainKey
+	s.Decommit = r.decommit
+	s.ChainKeyDecommit = r.chainKeyDecommit
+	s.RefreshDecommit = r.refreshDecommit
+	s.OurChainKey = r.ourChainKey
+	return cbor.Marshal(s)
+}
+
+// marshalSnapshot encodes the state of the Sender, which is the same in round2S and round3S.
+func (r *round2S) marshalSnapshot() ([]byte, error) {
+	s, err := marshalKey(r.secretShare, r.publicShare, r.public)
+	if err != nil {
+		return nil, err
+	}
+	if s.OT, err = r.sender.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if s.RefreshScalar, err = r.refreshScalar.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	s.ChainKey = r.chainKey
+	s.ReceiverCommit = r.receiverCommit
+	s.ChainKeyCommit = r.chainKeyCommit
+	s.RefreshCommit = r.refreshCommit
+	return cbor.Marshal(s)
+}
+
+// marshalKey returns a snapshot containing the shares of the key, where public may be nil.
+func marshalKey(secretShare curve.Scalar, publicShare, public curve.Point) (*snapshot, error) {
+	s := &snapshot{}
+	var err error
+	if s.SecretShare, err = secretShare.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if s.PublicShare, err = publicShare.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if public != nil {
+		if s.Public, err = public.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Restore implements round.Restorer.
+func (r *round1R) Restore(number round.Number, data []byte) (round.Session, error) {
+	s, err := unmarshalSnapshot(number, data)
+	if err != nil {
+		return nil, err
+	}
+	group := r.Group()
+	if r.secretShare, r.publicShare, r.public, err = unmarshalKey(group, s); err != nil {
+		return nil, err
+	}
+	if err = r.receiver.UnmarshalBinary(s.OT); err != nil {
+		return nil, fmt.Errorf("keygen: %w", err)
+	}
+	refreshScalar := group.NewScalar()
+	if err = refreshScalar.UnmarshalBinary(s.RefreshScalar); err != nil {
+		return nil, fmt.Errorf("keygen: refresh scalar: %w", err)
+	}
+	proof := zksch.EmptyProof(group)
+	if err = cbor.Unmarshal(s.Proof, proof); err != nil {
+		return nil, fmt.Errorf("keygen: proof: %w", err)
+	}
+	if len(s.OurChainKey) != params.SecBytes {
+		return nil, errors.New("keygen: invalid chain key")
+	}
+
+	r2 := &round2R{
+		round1R:          r,
+		proof:            proof,
+		decommit:         s.Decommit,
+		chainKeyDecommit: s.ChainKeyDecommit,
+		refreshDecommit:  s.RefreshDecommit,
+		refreshScalar:    refreshScalar,
+		ourChainKey:      s.OurChainKey,
+	}
+	if number == 2 {
+		return r2, nil
+	}
+	if r.public == nil || len(s.ChainKey) != params.SecBytes {
+		return nil, fmt.Errorf("keygen: %w", round.ErrNilFields)
+	}
+	r2.chainKey = s.ChainKey
+	return &round3R{round2R: r2}, nil
+}
+
+// Restore implements round.Restorer.
+func (r *round1S) Restore(number round.Number, data []byte) (round.Session, error) {
+	s, err := unmarshalSnapshot(number, data)
+	if err != nil {
+		return nil, err
+	}
+	group := r.Group()
+	if r.secretShare, r.publicShare, r.public, err = unmarshalKey(group, s); err != nil {
+		return nil, err
+	}
+	if err = r.sender.UnmarshalBinary(s.OT); err != nil {
+		return nil, fmt.Errorf("keygen: %w", err)
+	}
+	refreshScalar := group.NewScalar()
+	if err = refreshScalar.UnmarshalBinary(s.RefreshScalar); err != nil {
+		return nil, fmt.Errorf("keygen: refresh scalar: %w", err)
+	}
+	if len(s.ChainKey) != params.SecBytes {
+		return nil, errors.New("keygen: invalid chain key")
+	}
+	r.receiverCommit = s.ReceiverCommit
+	r.chainKeyCommit = s.ChainKeyCommit
+	r.refreshCommit = s.RefreshCommit
+
+	r2 := &round2S{
+		round1S:       r,
+		chainKey:      s.ChainKey,
+		refreshScalar: refreshScalar,
+	}
+	if number == 2 {
+		return r2, nil
+	}
+	if r.public == nil {
+		return nil, fmt.Errorf("keygen: %w", round.ErrNilFields)
+	}
+	return &round3S{round2S: r2}, nil
+}
+
+// unmarshalSnapshot decodes the snapshot of the round with the given number.
+func unmarshalSnapshot(number round.Number, data []byte) (*snapshot, error) {
+	if number < 2 || number > 3 {
+		return nil, fmt.Errorf("keygen: invalid round number %d", number)
+	}
+	s := &snapshot{}
+	if err := cbor.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("keygen: %w", err)
+	}
+	return s, nil
+}
+
+// unmarshalKey decodes the shares of the key encoded by marshalKey.
+func unmarshalKey(group curve.Curve, s *snapshot) (secretShare curve.Scalar, publicShare, public curve.Point, err error) {
+	secretShare = group.NewScalar()
+	if err = secretShare.UnmarshalBinary(s.SecretShare); err != nil {
+		return nil, nil, nil, fmt.Errorf("keygen: secret share: %w", err)
+	}
+	publicShare = group.NewPoint()
+	if err = publicShare.UnmarshalBinary(s.PublicShare); err != nil {
+		return nil, nil, nil, fmt.Errorf("keygen: public share: %w", err)
+	}
+	if len(s.Public) > 0 {
+		public = group.NewPoint()
+		if err = public.UnmarshalBinary(s.Public); err != nil {
+			return nil, nil, nil, fmt.Errorf("keygen: public key: %w", err)
+		}
+	}
+	return secretShare, publicShare, public, nil
+}
@@ -0,0 +1,52 @@
+package sign
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// messageOnline is the message sent by the Sender in the online phase.
+type messageOnline struct {
+	// Sigma = σᵢ = kᵢm+rχᵢ is the Sender's share of the signature.
+	Sigma curve.Scalar
+}
+
+func (messageOnline) RoundNumber() round.Number { return 2 }
+
+// online1 is the first round of the online phase, for both parties.
+//
+// Like in the signature protocol, only the Sender reveals its share of the signature.
+// The share of the Receiver depends on the output of its multiplications, which a cheating Sender
+// could have offset during presigning, so the Receiver only reveals the signature once it is valid.
+type online1 struct {
+	*round.Helper
+	// receiver is true if this party was the Receiver of the presigning protocol.
+	receiver bool
+	otherID  party.ID
+	// public is the shared public key.
+	public curve.Point
+	// The message hash to be signed.
+	hash         []byte
+	preSignature *ecdsa.PreSignature
+}
+
+func (r *online1) VerifyMessage(round.Message) error { return nil }
+
+func (r *online1) StoreMessage(round.Message) error { return nil }
+
+func (r *online1) Finalize(out chan<- *round.Message) (round.Session, error) {
+	sigma := r.preSignature.SignatureShare(r.hash)
+	if r.receiver {
+		return &online2R{online1: r, sigma: sigma}, nil
+	}
+	if err := r.SendMessage(out, &messageOnline{Sigma: sigma}, ""); err != nil {
+		return r, err
+	}
+	return &online2S{online1: r}, nil
+}
+
+func (online1) MessageContent() round.Content { return nil }
+
+func (online1) Number() round.Number { return 1 }
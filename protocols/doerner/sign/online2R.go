@@ -0,0 +1,67 @@
+package sign
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// messageOnlineSignature is the message sent by the Receiver once the signature is valid.
+type messageOnlineSignature struct {
+	// Sig is the final signature produced by the protocol.
+	Sig ecdsa.Signature
+}
+
+func (messageOnlineSignature) RoundNumber() round.Number { return 3 }
+
+// online2R is the final round of the online phase for the Receiver.
+type online2R struct {
+	*online1
+	// sigma = σᵢ is our share of the signature, which is never sent.
+	sigma curve.Scalar
+	// sigmaSender = σⱼ is the share of the Sender.
+	sigmaSender curve.Scalar
+}
+
+func (r *online2R) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*messageOnline)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Sigma == nil || body.Sigma.IsZero() {
+		return round.ErrNilFields
+	}
+	return nil
+}
+
+func (r *online2R) StoreMessage(msg round.Message) error {
+	r.sigmaSender = msg.Content.(*messageOnline).Sigma
+	return nil
+}
+
+// Finalize combines both shares, and identifies the culprit if the signature is invalid.
+// The signature is only sent to the Sender if it is valid.
+func (r *online2R) Finalize(out chan<- *round.Message) (round.Session, error) {
+	shares := map[party.ID]curve.Scalar{
+		r.SelfID(): r.sigma,
+		r.otherID:  r.sigmaSender,
+	}
+	sig := r.preSignature.Signature(shares)
+	if !sig.Verify(r.public, r.hash) {
+		culprits := r.preSignature.VerifySignatureShares(shares, r.hash)
+		return r.AbortRound(errors.New("signature failed to verify"), culprits...), nil
+	}
+	if err := r.SendMessage(out, &messageOnlineSignature{Sig: *sig}, ""); err != nil {
+		return r, err
+	}
+	return r.ResultRound(sig), nil
+}
+
+func (r *online2R) MessageContent() round.Content {
+	return &messageOnline{Sigma: r.Group().NewScalar()}
+}
+
+func (online2R) Number() round.Number { return 2 }
@@ -0,0 +1,22 @@
+package sign
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+)
+
+// online2S is the second round of the online phase for the Sender, which only waits for the signature.
+type online2S struct {
+	*online1
+}
+
+func (r *online2S) VerifyMessage(round.Message) error { return nil }
+
+func (r *online2S) StoreMessage(round.Message) error { return nil }
+
+func (r *online2S) Finalize(chan<- *round.Message) (round.Session, error) {
+	return &online3S{online2S: r}, nil
+}
+
+func (online2S) MessageContent() round.Content { return nil }
+
+func (online2S) Number() round.Number { return 2 }
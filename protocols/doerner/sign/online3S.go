@@ -0,0 +1,40 @@
+package sign
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+)
+
+// online3S is the final round of the online phase for the Sender.
+type online3S struct {
+	*online2S
+	sig ecdsa.Signature
+}
+
+func (r *online3S) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*messageOnlineSignature)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if !body.Sig.Verify(r.public, r.hash) {
+		return errors.New("failed to verify signature")
+	}
+	return nil
+}
+
+func (r *online3S) StoreMessage(msg round.Message) error {
+	r.sig = msg.Content.(*messageOnlineSignature).Sig
+	return nil
+}
+
+func (r *online3S) Finalize(chan<- *round.Message) (round.Session, error) {
+	return r.ResultRound(&r.sig), nil
+}
+
+func (r *online3S) MessageContent() round.Content {
+	return &messageOnlineSignature{Sig: ecdsa.EmptySignature(r.Group())}
+}
+
+func (online3S) Number() round.Number { return 3 }
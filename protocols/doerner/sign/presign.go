@@ -0,0 +1,176 @@
+package sign
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa"
+	"github.com/taurusgroup/multi-party-sig/pkg/ecdsa/presignstore"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/doerner/keygen"
+)
+
+// StartPresignReceiver starts the presigning protocol for the Receiver.
+//
+// This runs the multiplications of StartSignReceiver ahead of time, before the message is known.
+// The result is an *ecdsa.PreSignature, which can be used exactly once with StartPresignOnline.
+func StartPresignReceiver(config *keygen.ConfigReceiver, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		info := round.Info{
+			ProtocolID:       "doerner/presign",
+			FinalRoundNumber: 2,
+			SelfID:           selfID,
+			PartyIDs:         party.NewIDSlice([]party.ID{selfID, otherID}),
+			Threshold:        1,
+			Group:            config.Group(),
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartPresignReceiver: %w", err)
+		}
+
+		return &presign1R{Helper: helper, config: config, otherID: otherID}, nil
+	}
+}
+
+// StartPresignSender starts the presigning protocol for the Sender.
+//
+// See StartPresignReceiver.
+func StartPresignSender(config *keygen.ConfigSender, selfID, otherID party.ID, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		info := round.Info{
+			ProtocolID:       "doerner/presign",
+			FinalRoundNumber: 2,
+			SelfID:           selfID,
+			PartyIDs:         party.NewIDSlice([]party.ID{selfID, otherID}),
+			Threshold:        1,
+			Group:            config.Group(),
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartPresignSender: %w", err)
+		}
+
+		return &presign1S{Helper: helper, config: config, otherID: otherID}, nil
+	}
+}
+
+// StartPresignOnline signs the message hash with a presignature produced by StartPresignReceiver or StartPresignSender.
+// receiver must be true if the presignature was produced by StartPresignReceiver.
+//
+// The Sender sends its share of the signature, and the Receiver combines it with its own,
+// and sends the signature back only if it is valid. Both handlers can be leaders.
+// The presignature must never be used again, whether this protocol succeeds or not.
+func StartPresignOnline(public curve.Point, preSignature *ecdsa.PreSignature, receiver bool, selfID, otherID party.ID, messageHash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if public == nil || preSignature == nil {
+			return nil, errors.New("sign.StartPresignOnline: public key or preSignature is nil")
+		}
+		if len(messageHash) == 0 {
+			return nil, errors.New("sign.StartPresignOnline: message is nil")
+		}
+		if err := preSignature.Validate(); err != nil {
+			return nil, fmt.Errorf("sign.StartPresignOnline: %w", err)
+		}
+		signers := preSignature.SignerIDs()
+		if len(signers) != 2 || !signers.Contains(selfID, otherID) {
+			return nil, errors.New("sign.StartPresignOnline: preSignature was generated by different parties")
+		}
+
+		info := round.Info{
+			ProtocolID:       "doerner/presign-online",
+			FinalRoundNumber: 3,
+			SelfID:           selfID,
+			PartyIDs:         signers,
+			Threshold:        1,
+			Group:            preSignature.Group(),
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(
+			info,
+			sessionID,
+			nil,
+			hash.BytesWithDomain{
+				TheDomain: "PreSignatureID",
+				Bytes:     preSignature.ID,
+			},
+			types.SigningMessage(messageHash),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartPresignOnline: %w", err)
+		}
+
+		return &online1{
+			Helper:       helper,
+			receiver:     receiver,
+			otherID:      otherID,
+			public:       public,
+			hash:         messageHash,
+			preSignature: preSignature,
+		}, nil
+	}
+}
+
+// StartPresignOnlineFromStore consumes the presignature with the given ID from store, and signs the message hash with it.
+//
+// The presignature is consumed before our share of the signature is sent, and is not available anymore
+// even if the protocol fails.
+func StartPresignOnlineFromStore(public curve.Point, store presignstore.Store, preSignatureID []byte, receiver bool, selfID, otherID party.ID, messageHash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if store == nil {
+			return nil, errors.New("sign.StartPresignOnlineFromStore: store is nil")
+		}
+		if len(messageHash) == 0 {
+			return nil, errors.New("sign.StartPresignOnlineFromStore: message is nil")
+		}
+		preSignature, err := store.Consume(preSignatureID)
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartPresignOnlineFromStore: %w", err)
+		}
+		return StartPresignOnline(public, preSignature, receiver, selfID, otherID, messageHash, pl, opts...)(sessionID)
+	}
+}
+
+// newPreSignature creates the presignature of a party, given its shares of k⁻¹ and x⋅k⁻¹.
+//
+// The shares of the other party are implied by R̄ = G and S = X, since their shares sum to k⁻¹ and x⋅k⁻¹.
+// The ID is derived from the session and R, so that both parties obtain the same one.
+func newPreSignature(helper *round.Helper, otherID party.ID, public, R curve.Point, kShare, chiShare curve.Scalar) (*ecdsa.PreSignature, error) {
+	group := helper.Group()
+
+	h := helper.Hash()
+	_ = h.WriteAny(R)
+	id := types.EmptyRID()
+	if _, err := io.ReadFull(h.Digest(), id); err != nil {
+		return nil, err
+	}
+
+	RBar := kShare.Act(R)
+	S := chiShare.Act(R)
+	return &ecdsa.PreSignature{
+		ID: id,
+		R:  R,
+		RBar: party.NewPointMap(map[party.ID]curve.Point{
+			helper.SelfID(): RBar,
+			otherID:         group.NewBasePoint().Sub(RBar),
+		}),
+		S: party.NewPointMap(map[party.ID]curve.Point{
+			helper.SelfID(): S,
+			otherID:         public.Sub(S),
+		}),
+		KShare:   kShare,
+		ChiShare: chiShare,
+	}, nil
+}
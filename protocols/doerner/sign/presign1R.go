@@ -0,0 +1,56 @@
+package sign
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/protocols/doerner/keygen"
+)
+
+// presign1R is the first round of presigning from the Receiver's perspective.
+//
+// It sends the same message as round1R, since nothing in it depends on the message hash.
+type presign1R struct {
+	*round.Helper
+	config  *keygen.ConfigReceiver
+	otherID party.ID
+}
+
+func (r *presign1R) VerifyMessage(round.Message) error { return nil }
+
+func (r *presign1R) StoreMessage(round.Message) error { return nil }
+
+func (r *presign1R) Finalize(out chan<- *round.Message) (round.Session, error) {
+	kB := sample.Scalar(r.Rand(), r.Group())
+	D := kB.ActOnBase()
+	kB.Invert()
+	tag0 := &hash.BytesWithDomain{TheDomain: "Multiply0", Bytes: nil}
+	multiply0, err := ot.NewMultiplyReceiver(r.Rand(), r.Hash().Fork(tag0), r.config.Setup, kB)
+	if err != nil {
+		return r, err
+	}
+	tag1 := &hash.BytesWithDomain{TheDomain: "Multiply1", Bytes: nil}
+	multiply1, err := ot.NewMultiplyReceiver(r.Rand(), r.Hash().Fork(tag1), r.config.Setup, kB)
+	if err != nil {
+		return r, err
+	}
+	beta := r.Group().NewScalar().Set(r.config.SecretShare).Mul(kB)
+	tag2 := &hash.BytesWithDomain{TheDomain: "Multiply2", Bytes: nil}
+	multiply2, err := ot.NewMultiplyReceiver(r.Rand(), r.Hash().Fork(tag2), r.config.Setup, beta)
+	if err != nil {
+		return r, err
+	}
+	msg0 := multiply0.Round1()
+	msg1 := multiply1.Round1()
+	msg2 := multiply2.Round1()
+	if err := r.SendMessage(out, &message1R{D, msg0, msg1, msg2}, ""); err != nil {
+		return r, err
+	}
+	return &presign2R{presign1R: r, kBInv: kB, D: D, multiply0: multiply0, multiply1: multiply1, multiply2: multiply2}, nil
+}
+
+func (presign1R) MessageContent() round.Content { return nil }
+
+func (presign1R) Number() round.Number { return 1 }
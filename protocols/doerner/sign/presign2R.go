@@ -0,0 +1,122 @@
+package sign
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
+	zksch "github.com/taurusgroup/multi-party-sig/pkg/zk/sch"
+)
+
+type presign2R struct {
+	*presign1R
+	// kBInv is the inverse of our nonce.
+	kBInv curve.Scalar
+	// D is the commitment to our nonce.
+	D curve.Point
+	// We have three multiplication instances.
+	multiply0 *ot.MultiplyReceiver
+	multiply1 *ot.MultiplyReceiver
+	multiply2 *ot.MultiplyReceiver
+	// RPrime is the point sent by the Sender.
+	RPrime curve.Point
+	// RProof is a proof related to the derived point R.
+	RProof  *zksch.Proof
+	MulMsg0 *ot.MultiplySendRound1Message
+	MulMsg1 *ot.MultiplySendRound1Message
+	MulMsg2 *ot.MultiplySendRound1Message
+	// Encrypted version of phi.
+	MuPhi curve.Scalar
+}
+
+func (r *presign2R) VerifyMessage(msg round.Message) error {
+	body, ok := msg.Content.(*presignMessage1S)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+
+	if body.RPrime == nil || body.RProof == nil || body.MulMsg0 == nil || body.MulMsg1 == nil || body.MulMsg2 == nil || body.MuPhi == nil {
+		return round.ErrNilFields
+	}
+
+	return nil
+}
+
+func (r *presign2R) StoreMessage(msg round.Message) (err error) {
+	body := msg.Content.(*presignMessage1S)
+	r.RPrime = body.RPrime
+	r.RProof = body.RProof
+	r.MulMsg0 = body.MulMsg0
+	r.MulMsg1 = body.MulMsg1
+	r.MulMsg2 = body.MulMsg2
+	r.MuPhi = body.MuPhi
+	return nil
+}
+
+// Finalize completes the multiplications, as in round2R, and outputs our presignature.
+//
+// A dishonest Sender cannot be detected here, but the signature produced in the online phase will then fail to verify.
+func (r *presign2R) Finalize(chan<- *round.Message) (round.Session, error) {
+	group := r.Group()
+
+	hash := r.Hash()
+	_ = hash.WriteAny(r.RPrime)
+	R := sample.Scalar(hash.Digest(), group).Act(r.D).Add(r.RPrime)
+	if !r.RProof.Verify(r.Hash(), R, r.D) {
+		return r.AbortRound(errors.New("failed to verify schnorr proof"), r.otherID), nil
+	}
+
+	tB1, err := r.multiply0.Round2(r.MulMsg0)
+	if err != nil {
+		return r, err
+	}
+	tB21, err := r.multiply1.Round2(r.MulMsg1)
+	if err != nil {
+		return r, err
+	}
+	tB22, err := r.multiply2.Round2(r.MulMsg2)
+	if err != nil {
+		return r, err
+	}
+	tB2 := tB21.Add(tB22)
+
+	Gamma1 := tB1.Act(R)
+
+	hash = r.Hash()
+	_ = hash.WriteAny(Gamma1)
+	HGamma1 := sample.Scalar(hash.Digest(), group)
+
+	phi := HGamma1.Negate().Add(r.MuPhi)
+	theta := group.NewScalar().Set(phi).Mul(r.kBInv).Negate().Add(tB1)
+
+	Gamma2 := tB2.ActOnBase().Sub(theta.Act(r.config.Public))
+
+	hash = r.Hash()
+	_ = hash.WriteAny(Gamma2)
+	HGamma2 := sample.Scalar(hash.Digest(), group)
+
+	rInv := R.XScalar().Invert()
+	chiShare := HGamma2.Mul(rInv).Negate().Add(tB2)
+
+	preSignature, err := newPreSignature(r.Helper, r.otherID, r.config.Public, R, theta, chiShare)
+	if err != nil {
+		return r, err
+	}
+	return r.ResultRound(preSignature), nil
+}
+
+func (r *presign2R) MessageContent() round.Content {
+	group := r.Group()
+	return &presignMessage1S{
+		RPrime:  group.NewPoint(),
+		RProof:  zksch.EmptyProof(group),
+		MulMsg0: r.multiply0.EmptyMultiplySendRound1Message(),
+		MulMsg1: r.multiply1.EmptyMultiplySendRound1Message(),
+		MulMsg2: r.multiply2.EmptyMultiplySendRound1Message(),
+		MuPhi:   group.NewScalar(),
+	}
+}
+
+func (presign2R) Number() round.Number { return 2 }
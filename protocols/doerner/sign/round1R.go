@@ -1,13 +1,11 @@
 package sign
 
 import (
-	"crypto/rand"
-
-	"github.com/taurusgroup/multi-party-sig/internal/ot"
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/hash"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/ot"
 	"github.com/taurusgroup/multi-party-sig/protocols/doerner/keygen"
 )
 
@@ -34,22 +32,22 @@ func (r *round1R) VerifyMessage(round.Message) error { return nil }
 func (r *round1R) StoreMessage(round.Message) error { return nil }
 
 func (r *round1R) Finalize(out chan<- *round.Message) (round.Session, error) {
-	kB := sample.Scalar(rand.Reader, r.Group())
+	kB := sample.Scalar(r.Rand(), r.Group())
 	D := kB.ActOnBase()
 	kB.Invert()
 	tag0 := &hash.BytesWithDomain{TheDomain: "Multiply0", Bytes: nil}
-	multiply0, err := ot.NewMultiplyReceiver(r.Hash().Fork(tag0), r.config.Setup, kB)
+	multiply0, err := ot.NewMultiplyReceiver(r.Rand(), r.Hash().Fork(tag0), r.config.Setup, kB)
 	if err != nil {
 		return r, err
 	}
 	tag1 := &hash.BytesWithDomain{TheDomain: "Multiply1", Bytes: nil}
-	multiply1, err := ot.NewMultiplyReceiver(r.Hash().Fork(tag1), r.config.Setup, kB)
+	multiply1, err := ot.NewMultiplyReceiver(r.Rand(), r.Hash().Fork(tag1), r.config.Setup, kB)
 	if err != nil {
 		return r, err
 	}
 	beta := r.Group().NewScalar().Set(r.config.SecretShare).Mul(kB)
 	tag2 := &hash.BytesWithDomain{TheDomain: "Multiply1", Bytes: nil}
-	multiply2, err := ot.NewMultiplyReceiver(r.Hash().Fork(tag2), r.config.Setup, beta)
+	multiply2, err := ot.NewMultiplyReceiver(r.Rand(), r.Hash().Fork(tag2), r.config.Setup, beta)
 	if err != nil {
 		return r, err
 	}
@@ -16,7 +16,7 @@ import (
 // because we use a simple additive sharing instead of a polynomial sharing.
 //
 // The Receiver plays the role of "Bob".
-func StartSignReceiver(config *keygen.ConfigReceiver, selfID, otherID party.ID, hash []byte, pl *pool.Pool) protocol.StartFunc {
+func StartSignReceiver(config *keygen.ConfigReceiver, selfID, otherID party.ID, hash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
 		info := round.Info{
 			ProtocolID:       "doerner/keygen",
@@ -26,6 +26,7 @@ func StartSignReceiver(config *keygen.ConfigReceiver, selfID, otherID party.ID,
 			Threshold:        1,
 			Group:            config.Group(),
 		}
+		info.Apply(opts...)
 
 		helper, err := round.NewSession(info, sessionID, nil)
 		if err != nil {
@@ -42,7 +43,7 @@ func StartSignReceiver(config *keygen.ConfigReceiver, selfID, otherID party.ID,
 // because we use a simple additive sharing instead of a polynomial sharing.
 //
 // The Sender plays the role of "Alice".
-func StartSignSender(config *keygen.ConfigSender, selfID, otherID party.ID, hash []byte, pl *pool.Pool) protocol.StartFunc {
+func StartSignSender(config *keygen.ConfigSender, selfID, otherID party.ID, hash []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
 		info := round.Info{
 			ProtocolID:       "doerner/keygen",
@@ -52,6 +53,7 @@ func StartSignSender(config *keygen.ConfigSender, selfID, otherID party.ID, hash
 			Threshold:        1,
 			Group:            config.Group(),
 		}
+		info.Apply(opts...)
 
 		helper, err := round.NewSession(info, sessionID, nil)
 		if err != nil {
@@ -0,0 +1,82 @@
+package ecdh
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/elgamal"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost/keygen"
+)
+
+// StartDecryptCMP decrypts an ElGamal ciphertext encrypted to the public key of a CMP config.
+//
+// signers must contain at least Threshold+1 parties of the config, including ourselves.
+// The result is the curve.Point M - x⋅L, which is the message of elgamal.EncryptPoint.
+func StartDecryptCMP(c *config.Config, signers []party.ID, ciphertext *elgamal.Ciphertext, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	if !ciphertext.Valid() {
+		return invalidCiphertext
+	}
+	publicShares := make(map[party.ID]curve.Point, len(c.Public))
+	for j, public := range c.Public {
+		publicShares[j] = public.ECDSA
+	}
+	return start(protocolDecryptID, openElGamal(ciphertext), c.Group, c.ID, c.Threshold, c.ECDSA, publicShares, signers, ciphertext.L, pl, opts...)
+}
+
+// StartDecryptFROST decrypts an ElGamal ciphertext encrypted to the public key of a FROST config.
+//
+// See StartDecryptCMP.
+func StartDecryptFROST(c *keygen.Config, signers []party.ID, ciphertext *elgamal.Ciphertext, opts ...protocol.Option) protocol.StartFunc {
+	if !ciphertext.Valid() {
+		return invalidCiphertext
+	}
+	return start(protocolDecryptID, openElGamal(ciphertext), c.Curve(), c.ID, c.Threshold, c.PrivateShare, c.VerificationShares.Points, signers, ciphertext.L, nil, opts...)
+}
+
+// StartDecryptECIESCMP decrypts an ECIES ciphertext encrypted to the public key of a CMP config,
+// with the same associated data as was used for encryption.
+//
+// signers must contain at least Threshold+1 parties of the config, including ourselves.
+// The result is the decrypted payload, as a []byte.
+// If the payload fails to authenticate, the protocol aborts without culprits.
+func StartDecryptECIESCMP(c *config.Config, signers []party.ID, ciphertext *elgamal.ECIESCiphertext, associatedData []byte, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	if !ciphertext.Valid() {
+		return invalidCiphertext
+	}
+	publicShares := make(map[party.ID]curve.Point, len(c.Public))
+	for j, public := range c.Public {
+		publicShares[j] = public.ECDSA
+	}
+	return start(protocolDecryptID, openECIES(ciphertext, associatedData), c.Group, c.ID, c.Threshold, c.ECDSA, publicShares, signers, ciphertext.R, pl, opts...)
+}
+
+// StartDecryptECIESFROST decrypts an ECIES ciphertext encrypted to the public key of a FROST config.
+//
+// See StartDecryptECIESCMP.
+func StartDecryptECIESFROST(c *keygen.Config, signers []party.ID, ciphertext *elgamal.ECIESCiphertext, associatedData []byte, opts ...protocol.Option) protocol.StartFunc {
+	if !ciphertext.Valid() {
+		return invalidCiphertext
+	}
+	return start(protocolDecryptID, openECIES(ciphertext, associatedData), c.Curve(), c.ID, c.Threshold, c.PrivateShare, c.VerificationShares.Points, signers, ciphertext.R, nil, opts...)
+}
+
+func openElGamal(ciphertext *elgamal.Ciphertext) func(curve.Point) (interface{}, error) {
+	return func(shared curve.Point) (interface{}, error) {
+		return ciphertext.Open(shared), nil
+	}
+}
+
+func openECIES(ciphertext *elgamal.ECIESCiphertext, associatedData []byte) func(curve.Point) (interface{}, error) {
+	return func(shared curve.Point) (interface{}, error) {
+		return ciphertext.Open(shared, associatedData)
+	}
+}
+
+func invalidCiphertext([]byte) (round.Session, error) {
+	return nil, errors.New("ecdh: invalid ciphertext")
+}
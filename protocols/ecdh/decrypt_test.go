@@ -0,0 +1,99 @@
+package ecdh
+
+import (
+	"crypto/rand"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/elgamal"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost/keygen"
+)
+
+func TestDecryptCMP(t *testing.T) {
+	group := curve.Secp256k1{}
+	configs, _ := test.GenerateConfig(group, 4, 2, mrand.New(mrand.NewSource(1)), nil)
+	signers := []party.ID{"a", "b", "d"}
+	public := configs["a"].PublicPoint()
+
+	message := sample.Scalar(rand.Reader, group).ActOnBase()
+	ciphertext, _ := elgamal.EncryptPoint(rand.Reader, public, message)
+	starts := map[party.ID]protocol.StartFunc{}
+	for _, id := range signers {
+		starts[id] = StartDecryptCMP(configs[id], signers, ciphertext, nil)
+	}
+	rounds, err := run(t, starts, nil)
+	require.NoError(t, err)
+	checkOutput(t, rounds, message)
+
+	plaintext := []byte("hello, world")
+	associatedData := []byte("context")
+	eciesCiphertext, err := elgamal.EncryptECIES(rand.Reader, public, plaintext, associatedData)
+	require.NoError(t, err)
+	starts = map[party.ID]protocol.StartFunc{}
+	for _, id := range signers {
+		starts[id] = StartDecryptECIESCMP(configs[id], signers, eciesCiphertext, associatedData, nil)
+	}
+	rounds, err = run(t, starts, nil)
+	require.NoError(t, err)
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		assert.Equal(t, plaintext, r.(*round.Output).Result)
+	}
+
+	_, err = StartDecryptCMP(configs["a"], signers, elgamal.Empty(group), nil)(nil)
+	assert.Error(t, err, "identity ciphertext")
+}
+
+func TestDecryptECIESFROST(t *testing.T) {
+	group := curve.Secp256k1{}
+	partyIDs := test.PartyIDs(3)
+
+	starts := map[party.ID]protocol.StartFunc{}
+	for _, id := range partyIDs {
+		starts[id] = keygen.StartKeygenCommon(false, group, partyIDs, 1, id, nil, nil, nil)
+	}
+	rounds, err := run(t, starts, nil)
+	require.NoError(t, err)
+	configs := map[party.ID]*keygen.Config{}
+	for _, r := range rounds {
+		c := r.(*round.Output).Result.(*keygen.Config)
+		configs[c.ID] = c
+	}
+	public := configs[partyIDs[0]].PublicKey
+	signers := partyIDs[:2]
+
+	plaintext := []byte("hello, world")
+	ciphertext, err := elgamal.EncryptECIES(rand.Reader, public, plaintext, nil)
+	require.NoError(t, err)
+	starts = map[party.ID]protocol.StartFunc{}
+	for _, id := range signers {
+		starts[id] = StartDecryptECIESFROST(configs[id], signers, ciphertext, nil)
+	}
+	rounds, err = run(t, starts, nil)
+	require.NoError(t, err)
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		assert.Equal(t, plaintext, r.(*round.Output).Result)
+	}
+
+	// a tampered payload fails to authenticate, but no party is to blame.
+	ciphertext.Data[0] ^= 1
+	starts = map[party.ID]protocol.StartFunc{}
+	for _, id := range signers {
+		starts[id] = StartDecryptECIESFROST(configs[id], signers, ciphertext, nil)
+	}
+	rounds, err = run(t, starts, nil)
+	require.NoError(t, err)
+	for _, r := range rounds {
+		require.IsType(t, &round.Abort{}, r)
+		assert.Empty(t, r.(*round.Abort).Culprits)
+	}
+}
@@ -0,0 +1,133 @@
+// Package ecdh implements a threshold Diffie-Hellman key agreement, with a key shared by CMP or FROST.
+//
+// Given the point P of a counterparty, the signers jointly compute x⋅P, where x is the shared private key,
+// without reconstructing x. Each signer i broadcasts its partial λᵢ⋅xᵢ⋅P along with a proof that it
+// is consistent with its public share Xᵢ, so that invalid partials are attributed to their sender.
+//
+// The result can be used to derive a key shared with the counterparty.
+// The same protocol decrypts ElGamal and ECIES ciphertexts of pkg/elgamal encrypted to the public key X,
+// in which case P is the ephemeral key of the ciphertext (see StartDecryptCMP and StartDecryptECIESCMP).
+package ecdh
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost/keygen"
+)
+
+const (
+	protocolID        = "ecdh/threshold"
+	protocolDecryptID = "ecdh/decrypt"
+	// This protocol has 2 concrete rounds.
+	protocolRounds round.Number = 2
+)
+
+// StartECDHCMP computes x⋅P with the key of a CMP config.
+//
+// signers must contain at least Threshold+1 parties of the config, including ourselves.
+// The result is the curve.Point x⋅P.
+func StartECDHCMP(c *config.Config, signers []party.ID, peer curve.Point, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	publicShares := make(map[party.ID]curve.Point, len(c.Public))
+	for j, public := range c.Public {
+		publicShares[j] = public.ECDSA
+	}
+	return start(protocolID, nil, c.Group, c.ID, c.Threshold, c.ECDSA, publicShares, signers, peer, pl, opts...)
+}
+
+// StartECDHFROST computes x⋅P with the key of a FROST config.
+//
+// See StartECDHCMP.
+func StartECDHFROST(c *keygen.Config, signers []party.ID, peer curve.Point, opts ...protocol.Option) protocol.StartFunc {
+	return start(protocolID, nil, c.Curve(), c.ID, c.Threshold, c.PrivateShare, c.VerificationShares.Points, signers, peer, nil, opts...)
+}
+
+// StartECDHTaproot is like StartECDHFROST, but for a FROST Taproot config.
+//
+// The private key is the one matching the x-only public key of the config, with an even y-coordinate.
+func StartECDHTaproot(c *keygen.TaprootConfig, signers []party.ID, peer curve.Point, opts ...protocol.Option) protocol.StartFunc {
+	publicShares := make(map[party.ID]curve.Point, len(c.VerificationShares))
+	for j, share := range c.VerificationShares {
+		publicShares[j] = share
+	}
+	return start(protocolID, nil, curve.Secp256k1{}, c.ID, c.Threshold, c.PrivateShare, publicShares, signers, peer, nil, opts...)
+}
+
+// start creates the first round of the protocol.
+//
+// If output is not nil, it is applied to x⋅P to obtain the result of the protocol.
+func start(protocolID string, output func(curve.Point) (interface{}, error), group curve.Curve, selfID party.ID, threshold int, secret curve.Scalar, publicShares map[party.ID]curve.Point,
+	signers []party.ID, peer curve.Point, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		signerIDs := party.NewIDSlice(signers)
+		if !signerIDs.Valid() {
+			return nil, errors.New("ecdh: signers contain duplicates")
+		}
+		if len(signerIDs) <= threshold {
+			return nil, fmt.Errorf("ecdh: %d signers cannot use a key with threshold %d", len(signerIDs), threshold)
+		}
+		if !signerIDs.Contains(selfID) {
+			return nil, errors.New("ecdh: we are not one of the signers")
+		}
+		if secret == nil {
+			return nil, errors.New("ecdh: missing share of the key")
+		}
+		if peer == nil || peer.IsIdentity() {
+			return nil, errors.New("ecdh: peer point is invalid")
+		}
+		for _, j := range signerIDs {
+			if _, ok := publicShares[j]; !ok {
+				return nil, fmt.Errorf("ecdh: signer %s is not part of the config", j)
+			}
+		}
+
+		info := round.Info{
+			ProtocolID:       protocolID,
+			FinalRoundNumber: protocolRounds,
+			SelfID:           selfID,
+			PartyIDs:         signerIDs,
+			Threshold:        threshold,
+			Group:            group,
+		}
+		info.Apply(opts...)
+		helper, err := round.NewSession(info, sessionID, pl, &peerPoint{peer})
+		if err != nil {
+			return nil, fmt.Errorf("ecdh: %w", err)
+		}
+
+		return &round1{
+			Helper:       helper,
+			Peer:         peer,
+			PublicShares: publicShares,
+			SecretShare:  secret,
+			output:       output,
+		}, nil
+	}
+}
+
+// peerPoint is the point P all parties must agree on.
+type peerPoint struct {
+	P curve.Point
+}
+
+// WriteTo implements io.WriterTo interface.
+func (p *peerPoint) WriteTo(w io.Writer) (int64, error) {
+	data, err := p.P.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// Domain implements hash.WriterToWithDomain.
+func (peerPoint) Domain() string {
+	return "ECDH Peer Point"
+}
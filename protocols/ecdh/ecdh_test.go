@@ -0,0 +1,140 @@
+package ecdh
+
+import (
+	"crypto/rand"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost/keygen"
+)
+
+func run(t *testing.T, starts map[party.ID]protocol.StartFunc, rule test.Rule) ([]round.Session, error) {
+	rounds := make([]round.Session, 0, len(starts))
+	for _, start := range starts {
+		r, err := start(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+	for {
+		err, done := test.Rounds(rounds, rule)
+		if err != nil {
+			return rounds, err
+		}
+		if done {
+			break
+		}
+	}
+	return rounds, nil
+}
+
+func checkOutput(t *testing.T, rounds []round.Session, expected curve.Point) {
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		result := r.(*round.Output).Result
+		require.Implements(t, (*curve.Point)(nil), result)
+		assert.True(t, expected.Equal(result.(curve.Point)), "party %s computed a different point", r.SelfID())
+	}
+}
+
+func TestECDHCMP(t *testing.T) {
+	group := curve.Secp256k1{}
+	configs, _ := test.GenerateConfig(group, 4, 2, mrand.New(mrand.NewSource(1)), nil)
+	signers := []party.ID{"a", "c", "d"}
+
+	peerSecret, peer := sample.ScalarPointPair(rand.Reader, group)
+	expected := peerSecret.Act(configs["a"].PublicPoint())
+
+	starts := map[party.ID]protocol.StartFunc{}
+	for _, id := range signers {
+		starts[id] = StartECDHCMP(configs[id], signers, peer, nil)
+	}
+	rounds, err := run(t, starts, nil)
+	require.NoError(t, err)
+	checkOutput(t, rounds, expected)
+
+	_, err = StartECDHCMP(configs["a"], []party.ID{"a", "b"}, peer, nil)(nil)
+	assert.Error(t, err, "not enough signers")
+	_, err = StartECDHCMP(configs["a"], signers, group.NewPoint(), nil)(nil)
+	assert.Error(t, err, "identity peer point")
+}
+
+func TestECDHFROST(t *testing.T) {
+	group := curve.Secp256k1{}
+	partyIDs := test.PartyIDs(3)
+	peerSecret, peer := sample.ScalarPointPair(rand.Reader, group)
+
+	for _, taproot := range []bool{false, true} {
+		starts := map[party.ID]protocol.StartFunc{}
+		for _, id := range partyIDs {
+			starts[id] = keygen.StartKeygenCommon(taproot, group, partyIDs, 1, id, nil, nil, nil)
+		}
+		rounds, err := run(t, starts, nil)
+		require.NoError(t, err)
+
+		signers := partyIDs[1:]
+		starts = map[party.ID]protocol.StartFunc{}
+		var publicKey curve.Point
+		for _, r := range rounds {
+			result := r.(*round.Output).Result
+			if taproot {
+				c := result.(*keygen.TaprootConfig)
+				publicKey, _ = group.LiftX(c.PublicKey)
+				starts[c.ID] = StartECDHTaproot(c, signers, peer)
+			} else {
+				c := result.(*keygen.Config)
+				publicKey = c.PublicKey
+				starts[c.ID] = StartECDHFROST(c, signers, peer)
+			}
+		}
+		for id := range starts {
+			if !party.IDSlice(signers).Contains(id) {
+				delete(starts, id)
+			}
+		}
+		rounds, err = run(t, starts, nil)
+		require.NoError(t, err)
+		checkOutput(t, rounds, peerSecret.Act(publicKey))
+	}
+}
+
+// invalidShare changes the partial result sent by culprit.
+type invalidShare struct {
+	culprit party.ID
+}
+
+func (invalidShare) ModifyBefore(round.Session) {}
+func (invalidShare) ModifyAfter(round.Session)  {}
+func (rule invalidShare) ModifyContent(rNext round.Session, _ party.ID, content round.Content) {
+	if body, ok := content.(*broadcast2); ok && rNext.SelfID() == rule.culprit {
+		body.Share = body.Share.Add(rNext.Group().NewBasePoint())
+	}
+}
+
+func TestECDHCulprit(t *testing.T) {
+	group := curve.Secp256k1{}
+	configs, _ := test.GenerateConfig(group, 3, 1, mrand.New(mrand.NewSource(1)), nil)
+	signers := []party.ID{"a", "b", "c"}
+	_, peer := sample.ScalarPointPair(rand.Reader, group)
+
+	starts := map[party.ID]protocol.StartFunc{}
+	for _, id := range signers {
+		starts[id] = StartECDHCMP(configs[id], signers, peer, nil)
+	}
+	// The culprit does not verify its own share, so the rounds end up in different states.
+	rounds, _ := run(t, starts, invalidShare{culprit: "b"})
+	for _, r := range rounds {
+		if r.SelfID() == "b" {
+			continue
+		}
+		require.IsType(t, &round.Abort{}, r)
+		assert.Equal(t, []party.ID{"b"}, r.(*round.Abort).Culprits)
+	}
+}
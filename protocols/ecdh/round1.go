@@ -0,0 +1,66 @@
+package ecdh
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	zkdleq "github.com/taurusgroup/multi-party-sig/pkg/zk/dleq"
+)
+
+var _ round.Round = (*round1)(nil)
+
+type round1 struct {
+	*round.Helper
+
+	// Peer = P is the point of the counterparty.
+	Peer curve.Point
+	// PublicShares[j] = Xⱼ is the public key share of party j.
+	PublicShares map[party.ID]curve.Point
+	// SecretShare = xᵢ is our share of the private key.
+	SecretShare curve.Scalar
+
+	// output transforms x⋅P into the result of the protocol, if not nil.
+	output func(curve.Point) (interface{}, error)
+}
+
+// VerifyMessage implements round.Round.
+func (r *round1) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (r *round1) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - compute Sᵢ = λᵢ⋅xᵢ⋅P, and prove that it has the same discrete logarithm with respect to P as λᵢ⋅Xᵢ does with respect to G.
+func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
+	lagrange := polynomial.Lagrange(r.Group(), r.PartyIDs())
+	secret := r.Group().NewScalar().Set(lagrange[r.SelfID()]).Mul(r.SecretShare)
+	public := make(map[party.ID]curve.Point, r.N())
+	for _, j := range r.PartyIDs() {
+		public[j] = lagrange[j].Act(r.PublicShares[j])
+	}
+
+	share := secret.Act(r.Peer)
+	proof := zkdleq.NewProof(r.Rand(), r.Group(), r.HashForID(r.SelfID()), zkdleq.Public{
+		H: r.Peer,
+		X: public[r.SelfID()],
+		Y: share,
+	}, zkdleq.Private{A: secret})
+
+	if err := r.BroadcastMessage(out, &broadcast2{Share: share, Proof: proof}); err != nil {
+		return r, err
+	}
+	return &round2{
+		round1:  r,
+		Public:  public,
+		Shares:  map[party.ID]curve.Point{r.SelfID(): share},
+		Invalid: []party.ID{},
+	}, nil
+}
+
+// MessageContent implements round.Round.
+func (round1) MessageContent() round.Content { return nil }
+
+// Number implements round.Round.
+func (round1) Number() round.Number { return 1 }
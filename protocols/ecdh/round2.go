@@ -0,0 +1,102 @@
+package ecdh
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	zkdleq "github.com/taurusgroup/multi-party-sig/pkg/zk/dleq"
+)
+
+var _ round.Round = (*round2)(nil)
+
+type round2 struct {
+	*round1
+
+	// Public[j] = λⱼ⋅Xⱼ
+	Public map[party.ID]curve.Point
+	// Shares[j] = Sⱼ = λⱼ⋅xⱼ⋅P
+	Shares map[party.ID]curve.Point
+	// Invalid contains the parties whose share failed to verify.
+	Invalid []party.ID
+}
+
+type broadcast2 struct {
+	round.NormalBroadcastContent
+	// Share = Sᵢ = λᵢ⋅xᵢ⋅P
+	Share curve.Point
+	// Proof that Sᵢ and λᵢ⋅Xᵢ have the same discrete logarithm.
+	Proof *zkdleq.Proof
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - verify the proof for Sⱼ, and remember j as a culprit if it fails.
+func (r *round2) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcast2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.Share == nil || body.Proof == nil {
+		return round.ErrNilFields
+	}
+
+	if !body.Proof.Verify(r.HashForID(from), zkdleq.Public{
+		H: r.Peer,
+		X: r.Public[from],
+		Y: body.Share,
+	}) {
+		r.Invalid = append(r.Invalid, from)
+		return nil
+	}
+	r.Shares[from] = body.Share
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round2) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round2) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - abort with the parties whose share is invalid, if any.
+// - compute x⋅P = ∑ⱼ Sⱼ, and use it to decrypt if needed.
+func (r *round2) Finalize(chan<- *round.Message) (round.Session, error) {
+	if len(r.Invalid) > 0 {
+		return r.AbortRound(errors.New("ecdh: invalid partial result"), r.Invalid...), nil
+	}
+
+	result := r.Group().NewPoint()
+	for _, j := range r.PartyIDs() {
+		result = result.Add(r.Shares[j])
+	}
+	if r.output == nil {
+		return r.ResultRound(result), nil
+	}
+	output, err := r.output(result)
+	if err != nil {
+		return r.AbortRound(err), nil
+	}
+	return r.ResultRound(output), nil
+}
+
+// MessageContent implements round.Round.
+func (round2) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast2) RoundNumber() round.Number { return 2 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *round2) BroadcastContent() round.BroadcastContent {
+	return &broadcast2{
+		Share: r.Group().NewPoint(),
+		Proof: zkdleq.Empty(r.Group()),
+	}
+}
+
+// Number implements round.Round.
+func (round2) Number() round.Number { return 2 }
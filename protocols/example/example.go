@@ -15,7 +15,7 @@ const (
 )
 
 // StartXOR is a function that creates the first round with all necessary information to create a protocol.Handler.
-func StartXOR(selfID party.ID, partyIDs party.IDSlice) protocol.StartFunc {
+func StartXOR(selfID party.ID, partyIDs party.IDSlice, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
 		info := round.Info{
 			ProtocolID:       protocolID,
@@ -23,6 +23,7 @@ func StartXOR(selfID party.ID, partyIDs party.IDSlice) protocol.StartFunc {
 			SelfID:           selfID,
 			PartyIDs:         partyIDs,
 		}
+		info.Apply(opts...)
 		// create the helper with a description of the protocol
 		helper, err := round.NewSession(info, sessionID, nil)
 		if err != nil {
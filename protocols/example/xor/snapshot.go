@@ -0,0 +1,35 @@
+package xor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/types"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// Snapshot returns the values received so far, so that the execution can be resumed with Round1.Restore.
+func (r *Round2) Snapshot() ([]byte, error) {
+	return cbor.Marshal(r.received)
+}
+
+// Restore returns the round saved by Snapshot.
+// Since the first round has no state of its own, only Round2 can be restored.
+func (r *Round1) Restore(number round.Number, data []byte) (round.Session, error) {
+	if number != 2 {
+		return nil, fmt.Errorf("xor: invalid round number %d", number)
+	}
+	var received map[party.ID]types.RID
+	if err := cbor.Unmarshal(data, &received); err != nil {
+		return nil, fmt.Errorf("xor: %w", err)
+	}
+	if received == nil || received[r.SelfID()] == nil {
+		return nil, errors.New("xor: missing own value")
+	}
+	return &Round2{
+		Round1:   r,
+		received: received,
+	}, nil
+}
@@ -0,0 +1,176 @@
+// Package export implements the reconstruction of a threshold private key by a designated recovery party.
+//
+// This is meant as a break-glass procedure for disaster recovery, since it defeats the purpose of
+// threshold signing: after a successful execution, the recovery party holds the full private key.
+// The shares of the signers are encrypted to an ephemeral key of the recovery party,
+// so that other parties and observers of the network learn nothing about the key.
+//
+// To make accidental use harder, every entry point of this package requires the Acknowledgement constant to be passed.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/pool"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/cmp/config"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost/keygen"
+)
+
+const (
+	protocolID = "export/threshold"
+	// Rounds is the number of rounds before the output round.
+	Rounds round.Number = 3
+)
+
+// Acknowledgement must be passed to all functions of this package, as an explicit confirmation
+// that the caller intends to reconstruct the full private key.
+const Acknowledgement = "I understand that the full private key will be reconstructed by the recovery party"
+
+// StartExportCMP is called by a signer holding a CMP config, or by the recovery party if it holds one.
+//
+// signers must contain at least Threshold+1 parties of the config.
+// The recovery party may or may not be one of the signers.
+func StartExportCMP(c *config.Config, signers []party.ID, recovery party.ID, ack string, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	publicShares := make(map[party.ID]curve.Point, len(c.Public))
+	for j, public := range c.Public {
+		publicShares[j] = public.ECDSA
+	}
+	return start(c.Group, c.ID, c.Threshold, c.PublicPoint(), c.ECDSA, publicShares, signers, recovery, ack, pl, opts...)
+}
+
+// StartExportFROST is called by a signer holding a FROST config, or by the recovery party if it holds one.
+//
+// signers must contain at least Threshold+1 parties of the config.
+// The recovery party may or may not be one of the signers.
+func StartExportFROST(c *keygen.Config, signers []party.ID, recovery party.ID, ack string, opts ...protocol.Option) protocol.StartFunc {
+	return start(c.Curve(), c.ID, c.Threshold, c.PublicKey, c.PrivateShare, c.VerificationShares.Points, signers, recovery, ack, nil, opts...)
+}
+
+// StartExportTaproot is like StartExportFROST, but for a FROST Taproot config.
+//
+// The reconstructed key corresponds to the x-only public key of the config, with an even y-coordinate.
+func StartExportTaproot(c *keygen.TaprootConfig, signers []party.ID, recovery party.ID, ack string, opts ...protocol.Option) protocol.StartFunc {
+	group := curve.Secp256k1{}
+	publicKey, err := group.LiftX(c.PublicKey)
+	if err != nil {
+		return func([]byte) (round.Session, error) {
+			return nil, fmt.Errorf("export: %w", err)
+		}
+	}
+	publicShares := make(map[party.ID]curve.Point, len(c.VerificationShares))
+	for j, share := range c.VerificationShares {
+		publicShares[j] = share
+	}
+	return start(group, c.ID, c.Threshold, publicKey, c.PrivateShare, publicShares, signers, recovery, ack, nil, opts...)
+}
+
+// StartRecover is called by a recovery party which does not hold a share of the key.
+//
+// publicKey and threshold must be obtained from a trusted source,
+// and the reconstructed key is checked against publicKey.
+func StartRecover(group curve.Curve, selfID party.ID, publicKey curve.Point, threshold int, signers []party.ID, ack string, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return start(group, selfID, threshold, publicKey, nil, nil, signers, selfID, ack, pl, opts...)
+}
+
+func start(group curve.Curve, selfID party.ID, threshold int, publicKey curve.Point, secret curve.Scalar, publicShares map[party.ID]curve.Point,
+	signers []party.ID, recovery party.ID, ack string, pl *pool.Pool, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if ack != Acknowledgement {
+			return nil, errors.New("export: the reconstruction of the private key was not acknowledged")
+		}
+		signerIDs := party.NewIDSlice(signers)
+		if !signerIDs.Valid() {
+			return nil, errors.New("export: signers contain duplicates")
+		}
+		if len(signerIDs) <= threshold {
+			return nil, fmt.Errorf("export: %d signers cannot reconstruct a key with threshold %d", len(signerIDs), threshold)
+		}
+		if publicKey == nil || publicKey.IsIdentity() {
+			return nil, errors.New("export: public key is invalid")
+		}
+		if signerIDs.Contains(selfID) && secret == nil {
+			return nil, errors.New("export: signer must provide its share of the key")
+		}
+		if publicShares != nil {
+			for _, j := range signerIDs {
+				if _, ok := publicShares[j]; !ok {
+					return nil, fmt.Errorf("export: signer %s is not part of the config", j)
+				}
+			}
+		}
+
+		// all parties taking part in the protocol
+		partyIDs := signerIDs.Copy()
+		if !signerIDs.Contains(recovery) {
+			partyIDs = append(partyIDs, recovery)
+		}
+
+		info := round.Info{
+			ProtocolID:       protocolID,
+			FinalRoundNumber: Rounds,
+			SelfID:           selfID,
+			PartyIDs:         partyIDs,
+			Threshold:        threshold,
+			Group:            group,
+		}
+		info.Apply(opts...)
+		helper, err := round.NewSession(info, sessionID, pl, &exportInfo{
+			PublicKey: publicKey,
+			Signers:   signerIDs,
+			Recovery:  recovery,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("export: %w", err)
+		}
+
+		return &round1{
+			Helper:       helper,
+			Signers:      signerIDs,
+			Recovery:     recovery,
+			PublicKey:    publicKey,
+			PublicShares: publicShares,
+			SecretShare:  secret,
+		}, nil
+	}
+}
+
+// exportInfo contains the parameters of the export all parties must agree on.
+type exportInfo struct {
+	PublicKey curve.Point
+	Signers   party.IDSlice
+	Recovery  party.ID
+}
+
+// WriteTo implements io.WriterTo interface.
+func (i *exportInfo) WriteTo(w io.Writer) (total int64, err error) {
+	data, err := i.PublicKey.MarshalBinary()
+	if err != nil {
+		return
+	}
+	n, err := w.Write(data)
+	total = int64(n)
+	if err != nil {
+		return
+	}
+
+	n64, err := i.Signers.WriteTo(w)
+	total += n64
+	if err != nil {
+		return
+	}
+
+	n64, err = i.Recovery.WriteTo(w)
+	total += n64
+	return
+}
+
+// Domain implements hash.WriterToWithDomain.
+func (exportInfo) Domain() string {
+	return "Export Info"
+}
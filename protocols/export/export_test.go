@@ -0,0 +1,167 @@
+package export
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost/keygen"
+)
+
+func run(t *testing.T, starts map[party.ID]protocol.StartFunc) []round.Session {
+	rounds := make([]round.Session, 0, len(starts))
+	for _, start := range starts {
+		r, err := start(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+	return rounds
+}
+
+func checkOutput(t *testing.T, rounds []round.Session, recovery party.ID, publicKey curve.Point) *PrivateKey {
+	var key *PrivateKey
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		result := r.(*round.Output).Result
+		if r.SelfID() != recovery {
+			require.Implements(t, (*curve.Point)(nil), result)
+			assert.True(t, publicKey.Equal(result.(curve.Point)))
+			continue
+		}
+		require.IsType(t, &PrivateKey{}, result)
+		key = result.(*PrivateKey)
+	}
+	require.NotNil(t, key)
+	assert.True(t, publicKey.Equal(key.PublicKey()), "reconstructed key does not match the public key")
+	return key
+}
+
+func TestExportCMP(t *testing.T) {
+	group := curve.Secp256k1{}
+	configs, _ := test.GenerateConfig(group, 3, 1, mrand.New(mrand.NewSource(1)), nil)
+	publicKey := configs["a"].PublicPoint()
+	signers := []party.ID{"a", "b"}
+
+	t.Run("recovery is a signer", func(t *testing.T) {
+		starts := map[party.ID]protocol.StartFunc{}
+		for _, id := range signers {
+			starts[id] = StartExportCMP(configs[id], signers, "a", Acknowledgement, nil)
+		}
+		checkOutput(t, run(t, starts), "a", publicKey)
+	})
+
+	t.Run("recovery holds a share", func(t *testing.T) {
+		starts := map[party.ID]protocol.StartFunc{}
+		for _, id := range []party.ID{"a", "b", "c"} {
+			starts[id] = StartExportCMP(configs[id], signers, "c", Acknowledgement, nil)
+		}
+		checkOutput(t, run(t, starts), "c", publicKey)
+	})
+
+	t.Run("recovery without share", func(t *testing.T) {
+		starts := map[party.ID]protocol.StartFunc{}
+		for _, id := range signers {
+			starts[id] = StartExportCMP(configs[id], signers, "z", Acknowledgement, nil)
+		}
+		starts["z"] = StartRecover(group, "z", publicKey, 1, signers, Acknowledgement, nil)
+		checkOutput(t, run(t, starts), "z", publicKey)
+	})
+}
+
+func TestExportFROST(t *testing.T) {
+	partyIDs := test.PartyIDs(3)
+	for _, taproot := range []bool{false, true} {
+		starts := map[party.ID]protocol.StartFunc{}
+		for _, id := range partyIDs {
+			starts[id] = keygen.StartKeygenCommon(taproot, curve.Secp256k1{}, partyIDs, 1, id, nil, nil, nil)
+		}
+		rounds := run(t, starts)
+
+		signers := partyIDs[:2]
+		starts = map[party.ID]protocol.StartFunc{}
+		var publicKey curve.Point
+		for _, r := range rounds {
+			result := r.(*round.Output).Result
+			if taproot {
+				c := result.(*keygen.TaprootConfig)
+				publicKey, _ = curve.Secp256k1{}.LiftX(c.PublicKey)
+				starts[c.ID] = StartExportTaproot(c, signers, partyIDs[2], Acknowledgement)
+			} else {
+				c := result.(*keygen.Config)
+				publicKey = c.PublicKey
+				starts[c.ID] = StartExportFROST(c, signers, partyIDs[2], Acknowledgement)
+			}
+		}
+		checkOutput(t, run(t, starts), partyIDs[2], publicKey)
+	}
+}
+
+func TestExportAcknowledgement(t *testing.T) {
+	configs, _ := test.GenerateConfig(curve.Secp256k1{}, 2, 1, mrand.New(mrand.NewSource(1)), nil)
+	_, err := StartExportCMP(configs["a"], []party.ID{"a", "b"}, "a", "yes", nil)(nil)
+	assert.Error(t, err)
+
+	_, err = StartExportCMP(configs["a"], []party.ID{"a"}, "a", Acknowledgement, nil)(nil)
+	assert.Error(t, err, "not enough signers")
+}
+
+func TestPrivateKeyFormats(t *testing.T) {
+	data, _ := hex.DecodeString("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+
+	secret := curve.Secp256k1{}.NewScalar()
+	require.NoError(t, secret.UnmarshalBinary(data))
+	key := &PrivateKey{secret: secret}
+
+	raw, err := key.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, data, raw)
+
+	wif, err := key.WIF(true)
+	require.NoError(t, err)
+	assert.Equal(t, "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617", wif)
+
+	der, err := key.PKCS8()
+	require.NoError(t, err)
+	var info pkcs8
+	_, err = asn1.Unmarshal(der, &info)
+	require.NoError(t, err)
+	var params asn1.ObjectIdentifier
+	_, err = asn1.Unmarshal(info.Algo.Parameters.FullBytes, &params)
+	require.NoError(t, err)
+	assert.True(t, params.Equal(oidSecp256k1))
+	var inner ecPrivateKey
+	_, err = asn1.Unmarshal(info.PrivateKey, &inner)
+	require.NoError(t, err)
+	assert.Equal(t, data, inner.PrivateKey)
+
+	p256Secret := curve.P256{}.NewScalar()
+	require.NoError(t, p256Secret.UnmarshalBinary(data))
+	p256Key := &PrivateKey{secret: p256Secret}
+	der, err = p256Key.PKCS8()
+	require.NoError(t, err)
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	require.NoError(t, err)
+	require.IsType(t, &ecdsa.PrivateKey{}, parsed)
+	assert.Equal(t, data, parsed.(*ecdsa.PrivateKey).D.FillBytes(make([]byte, 32)))
+
+	_, err = p256Key.WIF(true)
+	assert.Error(t, err)
+	assert.NotContains(t, key.String(), hex.EncodeToString(data))
+}
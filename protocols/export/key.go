@@ -0,0 +1,170 @@
+package export
+
+import (
+	"crypto/ecdh"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+)
+
+// PrivateKey is the full private key reconstructed by the recovery party.
+//
+// It should be handled with the same care as any other unprotected private key,
+// and is never printed by the fmt package.
+type PrivateKey struct {
+	secret curve.Scalar
+}
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// Curve returns the group of the private key.
+func (k *PrivateKey) Curve() curve.Curve {
+	return k.secret.Curve()
+}
+
+// Scalar returns the private key x.
+func (k *PrivateKey) Scalar() curve.Scalar {
+	return k.Curve().NewScalar().Set(k.secret)
+}
+
+// PublicKey returns the public key X = x⋅G.
+func (k *PrivateKey) PublicKey() curve.Point {
+	return k.secret.ActOnBase()
+}
+
+// Bytes returns the private key as 32 big-endian bytes.
+//
+// For curve.Edwards25519, this is the secret scalar and not an RFC 8032 seed,
+// since the latter never exists in a threshold setting.
+func (k *PrivateKey) Bytes() ([]byte, error) {
+	return k.secret.MarshalBinary()
+}
+
+// WIF returns the private key in Wallet Import Format, for a compressed public key.
+// If mainnet is false, the testnet version byte is used.
+//
+// This is only supported for curve.Secp256k1.
+func (k *PrivateKey) WIF(mainnet bool) (string, error) {
+	if _, ok := k.Curve().(curve.Secp256k1); !ok {
+		return "", errors.New("export: WIF is only supported for secp256k1")
+	}
+	data, err := k.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	payload := make([]byte, 0, 1+32+1+4)
+	if mainnet {
+		payload = append(payload, 0x80)
+	} else {
+		payload = append(payload, 0xef)
+	}
+	payload = append(payload, data...)
+	// compressed public key
+	payload = append(payload, 0x01)
+	first := sha256.Sum256(payload)
+	checksum := sha256.Sum256(first[:])
+	payload = append(payload, checksum[:4]...)
+	return base58Encode(payload), nil
+}
+
+// PKCS8 returns the private key as an ASN.1 DER encoded PKCS #8 structure, as defined in RFC 5208 and RFC 5915.
+//
+// This is supported for curve.Secp256k1 and curve.P256.
+func (k *PrivateKey) PKCS8() ([]byte, error) {
+	data, err := k.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	switch k.Curve().(type) {
+	case curve.P256:
+		key, err := ecdh.P256().NewPrivateKey(data)
+		if err != nil {
+			return nil, err
+		}
+		return x509.MarshalPKCS8PrivateKey(key)
+	case curve.Secp256k1:
+		public := secp256k1.PrivKeyFromBytes(data).PubKey().SerializeUncompressed()
+		inner, err := asn1.Marshal(ecPrivateKey{
+			Version:    1,
+			PrivateKey: data,
+			PublicKey:  asn1.BitString{Bytes: public, BitLength: 8 * len(public)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		params, err := asn1.Marshal(oidSecp256k1)
+		if err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(pkcs8{
+			Algo: pkix.AlgorithmIdentifier{
+				Algorithm:  oidPublicKeyECDSA,
+				Parameters: asn1.RawValue{FullBytes: params},
+			},
+			PrivateKey: inner,
+		})
+	default:
+		return nil, errors.New("export: PKCS #8 is only supported for secp256k1 and P-256")
+	}
+}
+
+// String implements fmt.Stringer, and does not reveal the private key.
+func (k *PrivateKey) String() string {
+	return "export.PrivateKey{REDACTED}"
+}
+
+// GoString implements fmt.GoStringer, and does not reveal the private key.
+func (k *PrivateKey) GoString() string {
+	return k.String()
+}
+
+// pkcs8 reflects an ASN.1, PKCS #8 PrivateKey.
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// ecPrivateKey reflects an ASN.1 Elliptic Curve Private Key Structure, from RFC 5915.
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Encode encodes data using the Bitcoin base58 alphabet.
+func base58Encode(data []byte) string {
+	x := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	out := make([]byte, 0, len(data)*138/100+1)
+	for x.Sign() > 0 {
+		x.DivMod(x, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	// leading zeros are encoded as '1'
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
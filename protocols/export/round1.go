@@ -0,0 +1,61 @@
+package export
+
+import (
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var _ round.Round = (*round1)(nil)
+
+type round1 struct {
+	*round.Helper
+
+	// Signers send an encryption of their share to the recovery party.
+	Signers party.IDSlice
+	// Recovery is the party reconstructing the private key.
+	Recovery party.ID
+
+	// PublicKey = X is the public key of the exported private key.
+	PublicKey curve.Point
+	// PublicShares[j] = Xⱼ is the public key share of party j.
+	// nil if we are the recovery party without a share of the key.
+	PublicShares map[party.ID]curve.Point
+	// SecretShare = xᵢ is our share of the private key.
+	// nil if we are not one of the signers.
+	SecretShare curve.Scalar
+}
+
+// VerifyMessage implements round.Round.
+func (r *round1) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (r *round1) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - if recovery party, sample ephemeral encryption key y, Y = y⋅G.
+func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
+	msg := &broadcast2{}
+	var secret curve.Scalar
+	if r.SelfID() == r.Recovery {
+		var public curve.Point
+		secret, public = sample.ScalarPointPair(r.Rand(), r.Group())
+		msg.EncryptionKey = &encryptionKey{Y: public}
+	}
+
+	if err := r.BroadcastMessage(out, msg); err != nil {
+		return r, err
+	}
+	return &round2{
+		round1:           r,
+		EncryptionSecret: secret,
+	}, nil
+}
+
+// MessageContent implements round.Round.
+func (round1) MessageContent() round.Content { return nil }
+
+// Number implements round.Round.
+func (round1) Number() round.Number { return 1 }
@@ -0,0 +1,106 @@
+package export
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var _ round.Round = (*round2)(nil)
+
+type round2 struct {
+	*round1
+
+	// EncryptionSecret = y is the ephemeral decryption key of the recovery party.
+	// nil if we are not the recovery party.
+	EncryptionSecret curve.Scalar
+	// EncryptionKey = Y = y⋅G is the ephemeral encryption key of the recovery party.
+	EncryptionKey curve.Point
+}
+
+type broadcast2 struct {
+	round.ReliableBroadcastContent
+	// EncryptionKey is set by the recovery party.
+	EncryptionKey *encryptionKey
+}
+
+// encryptionKey is the ephemeral encryption key Y of the recovery party.
+type encryptionKey struct {
+	Y curve.Point
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - save the encryption key Y of the recovery party.
+func (r *round2) StoreBroadcastMessage(msg round.Message) error {
+	body, ok := msg.Content.(*broadcast2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if msg.From != r.Recovery {
+		return nil
+	}
+	if body.EncryptionKey == nil || body.EncryptionKey.Y == nil {
+		return round.ErrNilFields
+	}
+	if body.EncryptionKey.Y.IsIdentity() {
+		return errors.New("encryption key is identity")
+	}
+	r.EncryptionKey = body.EncryptionKey.Y
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round2) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round2) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - if signer and not the recovery party, encrypt xᵢ as (R = ρ⋅G, C = xᵢ + H(R, ρ⋅Y)).
+func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
+	msg := &broadcast3{}
+	if r.Signers.Contains(r.SelfID()) && r.SelfID() != r.Recovery {
+		nonce, R := sample.ScalarPointPair(r.Rand(), r.Group())
+		mask := r.mask(r.SelfID(), R, nonce.Act(r.EncryptionKey))
+		msg.Share = &encryptedShare{
+			R: R,
+			C: r.Group().NewScalar().Set(r.SecretShare).Add(mask),
+		}
+	}
+
+	if err := r.BroadcastMessage(out, msg); err != nil {
+		return r, err
+	}
+	return &round3{
+		round2:       r,
+		SharesToSelf: map[party.ID]curve.Scalar{},
+	}, nil
+}
+
+// mask returns H(R, K) as a scalar, where K = ρ⋅Y = y⋅R is the secret shared between party j and the recovery party.
+func (r *round2) mask(j party.ID, R, K curve.Point) curve.Scalar {
+	h := r.HashForID(j)
+	_ = h.WriteAny(R, K)
+	return sample.Scalar(h.Digest(), r.Group())
+}
+
+// MessageContent implements round.Round.
+func (round2) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast2) RoundNumber() round.Number { return 2 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *round2) BroadcastContent() round.BroadcastContent {
+	return &broadcast2{
+		EncryptionKey: &encryptionKey{Y: r.Group().NewPoint()},
+	}
+}
+
+// Number implements round.Round.
+func (round2) Number() round.Number { return 2 }
@@ -0,0 +1,114 @@
+package export
+
+import (
+	"errors"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var _ round.Round = (*round3)(nil)
+
+type round3 struct {
+	*round2
+
+	// SharesToSelf[j] = xⱼ, decrypted by the recovery party.
+	SharesToSelf map[party.ID]curve.Scalar
+}
+
+type broadcast3 struct {
+	round.NormalBroadcastContent
+	// Share is set by all signers except the recovery party.
+	Share *encryptedShare
+}
+
+// encryptedShare = (R, C) is the encryption of a share for the recovery party.
+type encryptedShare struct {
+	// R = ρ⋅G
+	R curve.Point
+	// C = xⱼ + H(R, ρ⋅Y)
+	C curve.Scalar
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - if recovery party, decrypt xⱼ = C - H(R, y⋅R)
+// - if we know the public shares, check xⱼ⋅G = Xⱼ.
+func (r *round3) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcast3)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if !r.Signers.Contains(from) || from == r.Recovery {
+		return nil
+	}
+	if body.Share == nil || body.Share.R == nil || body.Share.C == nil {
+		return round.ErrNilFields
+	}
+	if body.Share.R.IsIdentity() {
+		return errors.New("encrypted share has identity nonce")
+	}
+	if r.SelfID() != r.Recovery {
+		return nil
+	}
+
+	mask := r.mask(from, body.Share.R, r.EncryptionSecret.Act(body.Share.R))
+	share := r.Group().NewScalar().Set(body.Share.C).Sub(mask)
+	if r.PublicShares != nil && !share.ActOnBase().Equal(r.PublicShares[from]) {
+		return errors.New("decrypted share does not match public share")
+	}
+	r.SharesToSelf[from] = share
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round3) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round3) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - if recovery party, compute x = ∑ⱼ λⱼ⋅xⱼ and check x⋅G = X.
+//
+// The recovery party outputs the private key, other parties output the public key.
+func (r *round3) Finalize(chan<- *round.Message) (round.Session, error) {
+	if r.SelfID() != r.Recovery {
+		return r.ResultRound(r.PublicKey), nil
+	}
+	if r.SecretShare != nil && r.Signers.Contains(r.SelfID()) {
+		r.SharesToSelf[r.SelfID()] = r.SecretShare
+	}
+
+	lagrange := polynomial.Lagrange(r.Group(), r.Signers)
+	secret := r.Group().NewScalar()
+	for _, j := range r.Signers {
+		secret.Add(r.Group().NewScalar().Set(lagrange[j]).Mul(r.SharesToSelf[j]))
+	}
+	if !secret.ActOnBase().Equal(r.PublicKey) {
+		return r.AbortRound(errors.New("reconstructed private key does not match the public key")), nil
+	}
+	return r.ResultRound(&PrivateKey{secret: secret}), nil
+}
+
+// MessageContent implements round.Round.
+func (round3) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast3) RoundNumber() round.Number { return 3 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (r *round3) BroadcastContent() round.BroadcastContent {
+	return &broadcast3{
+		Share: &encryptedShare{
+			R: r.Group().NewPoint(),
+			C: r.Group().NewScalar(),
+		},
+	}
+}
+
+// Number implements round.Round.
+func (round3) Number() round.Number { return 3 }
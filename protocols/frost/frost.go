@@ -13,6 +13,7 @@ type (
 	Config        = keygen.Config
 	TaprootConfig = keygen.TaprootConfig
 	Signature     = sign.Signature
+	Commitments   = sign.Commitments
 )
 
 // EmptyConfig creates an empty Config with a specific group.
@@ -27,6 +28,11 @@ func EmptyConfig(group curve.Curve) *Config {
 	}
 }
 
+// EmptyCommitments creates empty Commitments with a specific group, ready for unmarshalling.
+func EmptyCommitments(group curve.Curve) *Commitments {
+	return sign.EmptyCommitments(group)
+}
+
 // Keygen initiates the Frost key generation protocol.
 //
 // This protocol establishes a new threshold signature key among a set of participants.
@@ -44,8 +50,8 @@ func EmptyConfig(group curve.Curve) *Config {
 //
 // This protocol corresponds to Figure 1 of the Frost paper:
 //   https://eprint.iacr.org/2020/852.pdf
-func Keygen(group curve.Curve, selfID party.ID, participants []party.ID, threshold int) protocol.StartFunc {
-	return keygen.StartKeygenCommon(false, group, participants, threshold, selfID, nil, nil, nil)
+func Keygen(group curve.Curve, selfID party.ID, participants []party.ID, threshold int, opts ...protocol.Option) protocol.StartFunc {
+	return keygen.StartKeygenCommon(false, group, participants, threshold, selfID, nil, nil, nil, opts...)
 }
 
 // KeygenTaproot is like Keygen, but will make Taproot / BIP-340 compatible keys.
@@ -53,13 +59,13 @@ func Keygen(group curve.Curve, selfID party.ID, participants []party.ID, thresho
 // This will also return TaprootResult instead of Result, at the end of the protocol.
 //
 // See: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki#specification
-func KeygenTaproot(selfID party.ID, participants []party.ID, threshold int) protocol.StartFunc {
-	return keygen.StartKeygenCommon(true, curve.Secp256k1{}, participants, threshold, selfID, nil, nil, nil)
+func KeygenTaproot(selfID party.ID, participants []party.ID, threshold int, opts ...protocol.Option) protocol.StartFunc {
+	return keygen.StartKeygenCommon(true, curve.Secp256k1{}, participants, threshold, selfID, nil, nil, nil, opts...)
 }
 
 // Refresh
-func Refresh(config *Config, participants []party.ID) protocol.StartFunc {
-	return keygen.StartKeygenCommon(false, config.Curve(), participants, config.Threshold, config.ID, config.PrivateShare, config.PublicKey, config.VerificationShares.Points)
+func Refresh(config *Config, participants []party.ID, opts ...protocol.Option) protocol.StartFunc {
+	return keygen.StartKeygenCommon(false, config.Curve(), participants, config.Threshold, config.ID, config.PrivateShare, config.PublicKey, config.VerificationShares.Points, opts...)
 }
 
 // RefreshTaproot is like Refresh, but will make Taproot / BIP-340 compatible keys.
@@ -67,7 +73,7 @@ func Refresh(config *Config, participants []party.ID) protocol.StartFunc {
 // This will also return TaprootResult instead of Result, at the end of the protocol.
 //
 // See: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki#specification
-func RefreshTaproot(config *TaprootConfig, participants []party.ID) protocol.StartFunc {
+func RefreshTaproot(config *TaprootConfig, participants []party.ID, opts ...protocol.Option) protocol.StartFunc {
 	publicKey, err := curve.Secp256k1{}.LiftX(config.PublicKey)
 	if err != nil {
 		return func([]byte) (round.Session, error) {
@@ -78,7 +84,7 @@ func RefreshTaproot(config *TaprootConfig, participants []party.ID) protocol.Sta
 	for k, v := range config.VerificationShares {
 		verificationShares[k] = v
 	}
-	return keygen.StartKeygenCommon(true, curve.Secp256k1{}, participants, config.Threshold, config.ID, config.PrivateShare, publicKey, verificationShares)
+	return keygen.StartKeygenCommon(true, curve.Secp256k1{}, participants, config.Threshold, config.ID, config.PrivateShare, publicKey, verificationShares, opts...)
 }
 
 // Sign initiates the protocol for producing a threshold signature, with Frost.
@@ -95,14 +101,37 @@ func RefreshTaproot(config *TaprootConfig, participants []party.ID) protocol.Sta
 //
 //
 // We merge the pre-processing and signing protocols into a single signing protocol
-// which doesn't require any pre-processing.
+// which doesn't require any pre-processing. Preprocess and SignWithCommitments
+// keep them separate instead.
 //
 // Another major difference is that there's no central "Signing Authority".
 // Instead, each participant independently verifies and broadcasts items as necessary.
 //
 // Differences stemming from this change are commented throughout the protocol.
-func Sign(config *Config, signers []party.ID, messageHash []byte) protocol.StartFunc {
-	return sign.StartSignCommon(false, config, signers, messageHash)
+func Sign(config *Config, signers []party.ID, messageHash []byte, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartSignCommon(false, config, signers, messageHash, opts...)
+}
+
+// Preprocess initiates the Frost preprocessing protocol, which generates count nonces for each participant.
+//
+// All participants of config must take part. The resulting *Commitments contains the nonce commitments
+// of all participants, which allow any subset of them to sign a message in a single round with SignWithCommitments.
+// Note: the Commitments contain our secret nonces and should be treated as secret key material.
+//
+// This protocol corresponds to Figure 2 of the Frost paper:
+//   https://eprint.iacr.org/2020/852.pdf
+func Preprocess(config *Config, count int, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartPreprocess(config, count, opts...)
+}
+
+// SignWithCommitments is like Sign, but uses the nonces at the given index of commitments,
+// so that the signature is produced after a single round of messages.
+//
+// All signers must use the same index, and the nonces at that index are consumed,
+// so that they are never used for two signatures. Available returns the indices which can still be used.
+// If the commitments are persisted, they must be stored again after starting this protocol.
+func SignWithCommitments(config *Config, commitments *Commitments, index int, signers []party.ID, messageHash []byte, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartSignWithCommitments(config, commitments, index, signers, messageHash, opts...)
 }
 
 // SignTaproot is like Sign, but will generate a Taproot / BIP-340 compatible signature.
@@ -110,7 +139,7 @@ func Sign(config *Config, signers []party.ID, messageHash []byte) protocol.Start
 // This needs to result of a Taproot compatible key generation phase, naturally.
 //
 // See: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
-func SignTaproot(config *TaprootConfig, signers []party.ID, messageHash []byte) protocol.StartFunc {
+func SignTaproot(config *TaprootConfig, signers []party.ID, messageHash []byte, opts ...protocol.Option) protocol.StartFunc {
 	publicKey, err := curve.Secp256k1{}.LiftX(config.PublicKey)
 	if err != nil {
 		return func([]byte) (round.Session, error) {
@@ -128,5 +157,19 @@ func SignTaproot(config *TaprootConfig, signers []party.ID, messageHash []byte)
 		PublicKey:          publicKey,
 		VerificationShares: party.NewPointMap(genericVerificationShares),
 	}
-	return sign.StartSignCommon(true, normalResult, signers, messageHash)
+	return sign.StartSignCommon(true, normalResult, signers, messageHash, opts...)
+}
+
+// SignEd25519 is like Sign, but will generate an RFC 8032 Ed25519 signature.
+//
+// The binding factors, challenge and signature encoding follow the FROST(Ed25519, SHA-512)
+// ciphersuite of RFC 9591, so that the signature is accepted by crypto/ed25519.Verify
+// for the public key obtained with config.PublicKey.MarshalBinary().
+//
+// This needs the result of a key generation phase over curve.Edwards25519.
+// Since Ed25519 hashes the message internally, message is the actual message being signed.
+//
+// See: https://www.rfc-editor.org/rfc/rfc9591.html
+func SignEd25519(config *Config, signers []party.ID, message []byte, opts ...protocol.Option) protocol.StartFunc {
+	return sign.StartSignEd25519(config, signers, message, opts...)
 }
@@ -2,10 +2,12 @@ package frost
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"fmt"
 	"sync"
 	"testing"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/taurusgroup/multi-party-sig/internal/test"
@@ -15,6 +17,22 @@ import (
 	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
 )
 
+// verifyScriptPathCommitment checks that outputKey commits to merkleRoot with internalKey,
+// as a BIP-341 verifier does for a script path spend, using the parity bit of the control block.
+func verifyScriptPathCommitment(t *testing.T, internalKey, outputKey taproot.PublicKey, merkleRoot []byte, odd bool) {
+	tweak, err := taproot.TapTweak(internalKey, merkleRoot)
+	require.NoError(t, err)
+	P, err := curve.Secp256k1{}.LiftX(internalKey)
+	require.NoError(t, err)
+	var Q curve.Point
+	Q, err = curve.Secp256k1{}.LiftX(outputKey)
+	require.NoError(t, err)
+	if odd {
+		Q = Q.Negate()
+	}
+	assert.True(t, Q.Equal(P.Add(tweak.ActOnBase())), "parity does not match the output key")
+}
+
 func do(t *testing.T, id party.ID, ids []party.ID, threshold int, message []byte, n *test.Network, wg *sync.WaitGroup) {
 	defer wg.Done()
 	h, err := protocol.NewMultiHandler(Keygen(curve.Secp256k1{}, id, ids, threshold), nil)
@@ -65,6 +83,25 @@ func do(t *testing.T, id party.ID, ids []party.ID, threshold int, message []byte
 	signature := signResult.(Signature)
 	assert.True(t, signature.Verify(c.PublicKey, message))
 
+	h, err = protocol.NewMultiHandler(Preprocess(c, 1), nil)
+	require.NoError(t, err)
+	test.HandlerLoop(c.ID, h, n)
+
+	r, err = h.Result()
+	require.NoError(t, err)
+	require.IsType(t, &Commitments{}, r)
+	commitments := r.(*Commitments)
+
+	h, err = protocol.NewMultiHandler(SignWithCommitments(c, commitments, 0, ids, message), nil)
+	require.NoError(t, err)
+	test.HandlerLoop(c.ID, h, n)
+
+	signResult, err = h.Result()
+	require.NoError(t, err)
+	require.IsType(t, Signature{}, signResult)
+	assert.True(t, signResult.(Signature).Verify(c.PublicKey, message))
+	assert.Empty(t, commitments.Available())
+
 	h, err = protocol.NewMultiHandler(SignTaproot(cTaproot, ids, message), nil)
 	require.NoError(t, err)
 
@@ -75,6 +112,52 @@ func do(t *testing.T, id party.ID, ids []party.ID, threshold int, message []byte
 	require.IsType(t, taproot.Signature{}, signResult)
 	taprootSignature := signResult.(taproot.Signature)
 	assert.True(t, cTaproot.PublicKey.Verify(taprootSignature, message))
+
+	merkleRoot := taproot.TaggedHash("TapLeaf", []byte("recovery"))
+	outputKey, odd, err := taproot.TweakPublicKey(cTaproot.PublicKey, merkleRoot)
+	require.NoError(t, err)
+	verifyScriptPathCommitment(t, cTaproot.PublicKey, outputKey, merkleRoot, odd)
+	cTweaked, err := cTaproot.TweakForScriptTree(merkleRoot)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(outputKey, cTweaked.PublicKey))
+	// the control block can be built from the tweaked config alone
+	verifyScriptPathCommitment(t, cTweaked.InternalKey, cTweaked.PublicKey, merkleRoot, cTweaked.OutputKeyOdd)
+	data, err := cbor.Marshal(cTweaked)
+	require.NoError(t, err)
+	cUnmarshalled := &TaprootConfig{}
+	require.NoError(t, cbor.Unmarshal(data, cUnmarshalled))
+	assert.True(t, bytes.Equal(cTweaked.InternalKey, cUnmarshalled.InternalKey))
+	assert.Equal(t, cTweaked.OutputKeyOdd, cUnmarshalled.OutputKeyOdd)
+
+	h, err = protocol.NewMultiHandler(SignTaproot(cTweaked, ids, message), nil)
+	require.NoError(t, err)
+
+	test.HandlerLoop(c.ID, h, n)
+
+	signResult, err = h.Result()
+	require.NoError(t, err)
+	require.IsType(t, taproot.Signature{}, signResult)
+	assert.True(t, outputKey.Verify(signResult.(taproot.Signature), message))
+
+	h, err = protocol.NewMultiHandler(Keygen(curve.Edwards25519{}, id, ids, threshold), nil)
+	require.NoError(t, err)
+	test.HandlerLoop(c.ID, h, n)
+
+	r, err = h.Result()
+	require.NoError(t, err)
+	require.IsType(t, &Config{}, r)
+	cEd25519 := r.(*Config)
+
+	h, err = protocol.NewMultiHandler(SignEd25519(cEd25519, ids, message), nil)
+	require.NoError(t, err)
+	test.HandlerLoop(c.ID, h, n)
+
+	signResult, err = h.Result()
+	require.NoError(t, err)
+	require.IsType(t, []byte{}, signResult)
+	publicKey, err := cEd25519.PublicKey.MarshalBinary()
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, message, signResult.([]byte)))
 }
 
 func TestFrost(t *testing.T) {
@@ -123,6 +123,13 @@ type TaprootConfig struct {
 	//
 	// This will later be used to verify the integrity of the signing protocol.
 	VerificationShares map[party.ID]*curve.Secp256k1Point
+	// InternalKey is the BIP-341 internal key P, when PublicKey is the output key Q of a script tree,
+	// as returned by TweakForScriptTree. It is nil otherwise.
+	InternalKey taproot.PublicKey
+	// OutputKeyOdd is true if the output key Q has an odd y coordinate, when InternalKey is set.
+	//
+	// Together with InternalKey, this gives the first 33 bytes of the control block of a script path spend.
+	OutputKeyOdd bool
 }
 
 // Clone creates a deep clone of this struct, and all the values contained inside
@@ -135,6 +142,11 @@ func (r *TaprootConfig) Clone() *TaprootConfig {
 	for k, v := range r.VerificationShares {
 		verificationSharesCopy[k] = v
 	}
+	var internalKeyCopy taproot.PublicKey
+	if r.InternalKey != nil {
+		internalKeyCopy = make([]byte, len(r.InternalKey))
+		copy(internalKeyCopy, r.InternalKey)
+	}
 	return &TaprootConfig{
 		ID:                 r.ID,
 		Threshold:          r.Threshold,
@@ -142,6 +154,8 @@ func (r *TaprootConfig) Clone() *TaprootConfig {
 		PublicKey:          publicKeyCopy,
 		ChainKey:           chainKeyCopy,
 		VerificationShares: verificationSharesCopy,
+		InternalKey:        internalKeyCopy,
+		OutputKeyOdd:       r.OutputKeyOdd,
 	}
 }
 
@@ -157,7 +171,11 @@ func (r *TaprootConfig) Derive(adjust *curve.Secp256k1Scalar, newChainKey []byte
 	if len(newChainKey) != params.SecBytes {
 		return nil, fmt.Errorf("expecte %d bytes for chain key, found %d", params.SecBytes, len(newChainKey))
 	}
+	return r.adjust(adjust, newChainKey)
+}
 
+// adjust returns the config for the public key P + adjust⋅G, with the given chain key.
+func (r *TaprootConfig) adjust(adjust *curve.Secp256k1Scalar, newChainKey []byte) (*TaprootConfig, error) {
 	adjustG := adjust.ActOnBase()
 	verificationShares := make(map[party.ID]*curve.Secp256k1Point, len(r.VerificationShares))
 	for k, v := range r.VerificationShares {
@@ -210,3 +228,31 @@ func (r *TaprootConfig) DeriveChild(i uint32) (*TaprootConfig, error) {
 	}
 	return r.Derive(scalar, newChainKey)
 }
+
+// TweakForScriptTree adjusts the shares to represent the BIP-341 output key Q = P + t⋅G,
+// where P is our public key, used as the internal key, and t = hash_TapTweak(P || merkleRoot).
+//
+// merkleRoot is the root of the script tree, and may be empty if the output has no script path.
+// The resulting config signs for the x-only key Q, which is a key path spend of the output.
+// It also records P in InternalKey, and the parity of Q in OutputKeyOdd, which are needed
+// in the control block to spend the output through its script path.
+//
+// See: https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki#constructing-and-spending-taproot-outputs
+func (r *TaprootConfig) TweakForScriptTree(merkleRoot []byte) (*TaprootConfig, error) {
+	_, odd, err := taproot.TweakPublicKey(r.PublicKey, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	tweak, err := taproot.TapTweak(r.PublicKey, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	tweaked, err := r.adjust(tweak, r.ChainKey)
+	if err != nil {
+		return nil, err
+	}
+	tweaked.InternalKey = make([]byte, len(r.PublicKey))
+	copy(tweaked.InternalKey, r.PublicKey)
+	tweaked.OutputKeyOdd = odd
+	return tweaked, nil
+}
@@ -24,7 +24,7 @@ var (
 	_ round.Round = (*round3)(nil)
 )
 
-func StartKeygenCommon(taproot bool, group curve.Curve, participants []party.ID, threshold int, selfID party.ID, privateShare curve.Scalar, publicKey curve.Point, verificationShares map[party.ID]curve.Point) protocol.StartFunc {
+func StartKeygenCommon(taproot bool, group curve.Curve, participants []party.ID, threshold int, selfID party.ID, privateShare curve.Scalar, publicKey curve.Point, verificationShares map[party.ID]curve.Point, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
 		info := round.Info{
 			FinalRoundNumber: protocolRounds,
@@ -33,6 +33,7 @@ func StartKeygenCommon(taproot bool, group curve.Curve, participants []party.ID,
 			Threshold:        threshold,
 			Group:            group,
 		}
+		info.Apply(opts...)
 		if taproot {
 			info.ProtocolID = protocolIDTaproot
 		} else {
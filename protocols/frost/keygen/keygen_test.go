@@ -1,6 +1,7 @@
 package keygen
 
 import (
+	mrand "math/rand"
 	"testing"
 
 	"github.com/fxamacker/cbor/v2"
@@ -11,6 +12,7 @@ import (
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/polynomial"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
 )
 
 func checkOutput(t *testing.T, rounds []round.Session, parties party.IDSlice) {
@@ -164,3 +166,40 @@ func TestKeygenTaproot(t *testing.T) {
 
 	checkOutputTaproot(t, rounds, partyIDs)
 }
+
+func TestKeygenDeterministic(t *testing.T) {
+	group := curve.Secp256k1{}
+	N := 3
+	partyIDs := test.PartyIDs(N)
+
+	run := func() map[party.ID]*Config {
+		rounds := make([]round.Session, 0, N)
+		for i, partyID := range partyIDs {
+			rand := mrand.New(mrand.NewSource(int64(i)))
+			r, err := StartKeygenCommon(false, group, partyIDs, N-1, partyID, nil, nil, nil, protocol.WithRand(rand))(nil)
+			require.NoError(t, err, "round creation should not result in an error")
+			rounds = append(rounds, r)
+		}
+		for {
+			err, done := test.Rounds(rounds, nil)
+			require.NoError(t, err, "failed to process round")
+			if done {
+				break
+			}
+		}
+		checkOutput(t, rounds, partyIDs)
+
+		results := make(map[party.ID]*Config, N)
+		for _, r := range rounds {
+			results[r.SelfID()] = r.(*round.Output).Result.(*Config)
+		}
+		return results
+	}
+
+	first, second := run(), run()
+	for _, id := range partyIDs {
+		assert.True(t, first[id].PublicKey.Equal(second[id].PublicKey), "different public key")
+		assert.True(t, first[id].PrivateShare.Equal(second[id].PrivateShare), "different private share")
+		assert.Equal(t, first[id].ChainKey, second[id].ChainKey, "different chain key")
+	}
+}
@@ -1,7 +1,6 @@
 package keygen
 
 import (
-	"crypto/rand"
 	"fmt"
 
 	"github.com/taurusgroup/multi-party-sig/internal/round"
@@ -73,10 +72,10 @@ func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
 	a_i0 := group.NewScalar()
 	a_i0_times_G := group.NewPoint()
 	if !r.refresh {
-		a_i0 = sample.Scalar(rand.Reader, r.Group())
+		a_i0 = sample.Scalar(r.Rand(), r.Group())
 		a_i0_times_G = a_i0.ActOnBase()
 	}
-	f_i := polynomial.NewPolynomial(r.Group(), r.threshold, a_i0)
+	f_i := polynomial.NewPolynomial(r.Rand(), r.Group(), r.threshold, a_i0)
 
 	// 2. "Every Pᵢ computes a proof of knowledge to the corresponding secret aᵢ₀
 	// by calculating σᵢ = (Rᵢ, μᵢ), such that:
@@ -96,7 +95,7 @@ func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
 	// Refresh: Don't create a proof.
 	var Sigma_i *zksch.Proof
 	if !r.refresh {
-		Sigma_i = zksch.NewProof(r.Helper.HashForID(r.SelfID()), a_i0_times_G, a_i0, nil)
+		Sigma_i = zksch.NewProof(r.Rand(), r.Helper.HashForID(r.SelfID()), a_i0_times_G, a_i0, nil)
 	}
 
 	// 3. "Every participant Pᵢ computes a public comment Φᵢ = <ϕᵢ₀, ..., ϕᵢₜ>
@@ -111,11 +110,11 @@ func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
 	Phi_i := polynomial.NewPolynomialExponent(f_i)
 
 	// c_i is our contribution to the chaining key
-	c_i, err := types.NewRID(rand.Reader)
+	c_i, err := types.NewRID(r.Rand())
 	if err != nil {
 		return r, fmt.Errorf("failed to sample ChainKey")
 	}
-	commitment, decommitment, err := r.HashForID(r.SelfID()).Commit(c_i)
+	commitment, decommitment, err := r.HashForID(r.SelfID()).Commit(r.Rand(), c_i)
 	if err != nil {
 		return r, fmt.Errorf("failed to commit to chain key")
 	}
@@ -0,0 +1,203 @@
+package sign
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+// ErrNoncesUsed is returned when preprocessed nonces were already used to sign a message.
+var ErrNoncesUsed = errors.New("frost: preprocessed nonces already used")
+
+// Commitments contains the output of the preprocessing protocol, from the perspective of a single participant.
+//
+// This corresponds to the list Lᵢ of Figure 2 in the Frost paper, together with the lists
+// published by all other participants:
+//
+//	https://eprint.iacr.org/2020/852.pdf
+//
+// The j-th nonces of a participant must only ever be used to sign a single message, which Consume enforces.
+// The secret nonces are erased once consumed, so that a marshalled Commitments keeps track of which were used.
+//
+// When unmarshalling, EmptyCommitments needs to be called to set the group, before
+// calling cbor.Unmarshal, or equivalent methods.
+type Commitments struct {
+	// ID is the same for all participants, and identifies the preprocessing execution.
+	ID []byte
+	// d[j], e[j] = (dᵢⱼ, eᵢⱼ) are our secret nonces, set to nil once used.
+	d, e []curve.Scalar
+	// D[l][j], E[l][j] = (Dₗⱼ, Eₗⱼ) are the commitments of each participant, ourself included.
+	D, E map[party.ID][]curve.Point
+
+	group curve.Curve
+	mtx   sync.Mutex
+}
+
+// EmptyCommitments creates empty Commitments with a specific group, ready for unmarshalling.
+func EmptyCommitments(group curve.Curve) *Commitments {
+	return &Commitments{group: group}
+}
+
+// Len returns the number of nonces which were preprocessed, including the ones which were used.
+func (c *Commitments) Len() int {
+	return len(c.d)
+}
+
+// Available returns the indices of the nonces which have not been used yet, in increasing order.
+func (c *Commitments) Available() []int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	available := make([]int, 0, len(c.d))
+	for j, d := range c.d {
+		if d != nil {
+			available = append(available, j)
+		}
+	}
+	return available
+}
+
+// commitments returns the commitments (Dₗⱼ, Eₗⱼ) at index j of each signer.
+func (c *Commitments) commitments(j int, signers []party.ID) (D, E map[party.ID]curve.Point, err error) {
+	if j < 0 || j >= len(c.d) {
+		return nil, nil, fmt.Errorf("frost: nonce index %d out of range", j)
+	}
+	D = make(map[party.ID]curve.Point, len(signers))
+	E = make(map[party.ID]curve.Point, len(signers))
+	for _, l := range signers {
+		if len(c.D[l]) != len(c.d) || len(c.E[l]) != len(c.d) {
+			return nil, nil, fmt.Errorf("frost: no preprocessed commitments for %v", l)
+		}
+		D[l], E[l] = c.D[l][j], c.E[l][j]
+	}
+	return D, E, nil
+}
+
+// Consume returns our secret nonces (dᵢⱼ, eᵢⱼ) at index j, and erases them,
+// so that ErrNoncesUsed is returned if they are requested again.
+func (c *Commitments) Consume(j int) (d, e curve.Scalar, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if j < 0 || j >= len(c.d) {
+		return nil, nil, fmt.Errorf("frost: nonce index %d out of range", j)
+	}
+	d, e = c.d[j], c.e[j]
+	if d == nil || e == nil {
+		return nil, nil, ErrNoncesUsed
+	}
+	c.d[j], c.e[j] = nil, nil
+	return d, e, nil
+}
+
+// commitmentsData is the serialized form of Commitments, where used nonces are empty.
+type commitmentsData struct {
+	ID     []byte
+	Nonces [][2][]byte
+	D, E   map[party.ID][][]byte
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// Note: the output contains the secret nonces which were not used yet, and should be treated as secret key material.
+// It must be stored again after every call to Consume, replacing the previous copy, since restoring an older copy
+// would allow the same nonces to sign two messages, which reveals the secret share.
+func (c *Commitments) MarshalBinary() ([]byte, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	data := commitmentsData{
+		ID:     c.ID,
+		Nonces: make([][2][]byte, len(c.d)),
+		D:      make(map[party.ID][][]byte, len(c.D)),
+		E:      make(map[party.ID][][]byte, len(c.E)),
+	}
+	var err error
+	for j := range c.d {
+		if c.d[j] == nil {
+			continue
+		}
+		if data.Nonces[j][0], err = c.d[j].MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if data.Nonces[j][1], err = c.e[j].MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	for l := range c.D {
+		if data.D[l], err = marshalPoints(c.D[l]); err != nil {
+			return nil, err
+		}
+		if data.E[l], err = marshalPoints(c.E[l]); err != nil {
+			return nil, err
+		}
+	}
+	return cbor.Marshal(data)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (c *Commitments) UnmarshalBinary(in []byte) error {
+	if c.group == nil {
+		return errors.New("frost: can't unmarshal Commitments with no group")
+	}
+	var data commitmentsData
+	if err := cbor.Unmarshal(in, &data); err != nil {
+		return err
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.ID = data.ID
+	c.d = make([]curve.Scalar, len(data.Nonces))
+	c.e = make([]curve.Scalar, len(data.Nonces))
+	for j, nonces := range data.Nonces {
+		if len(nonces[0]) == 0 {
+			continue
+		}
+		c.d[j], c.e[j] = c.group.NewScalar(), c.group.NewScalar()
+		if err := c.d[j].UnmarshalBinary(nonces[0]); err != nil {
+			return err
+		}
+		if err := c.e[j].UnmarshalBinary(nonces[1]); err != nil {
+			return err
+		}
+	}
+	c.D = make(map[party.ID][]curve.Point, len(data.D))
+	c.E = make(map[party.ID][]curve.Point, len(data.E))
+	var err error
+	for l := range data.D {
+		if c.D[l], err = unmarshalPoints(c.group, data.D[l]); err != nil {
+			return err
+		}
+		if c.E[l], err = unmarshalPoints(c.group, data.E[l]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func marshalPoints(points []curve.Point) ([][]byte, error) {
+	out := make([][]byte, len(points))
+	for j, p := range points {
+		var err error
+		if out[j], err = p.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// unmarshalPoints decodes a list of points, none of which may be the identity.
+func unmarshalPoints(group curve.Curve, data [][]byte) ([]curve.Point, error) {
+	out := make([]curve.Point, len(data))
+	for j := range data {
+		out[j] = group.NewPoint()
+		if err := out[j].UnmarshalBinary(data[j]); err != nil {
+			return nil, err
+		}
+		if out[j].IsIdentity() {
+			return nil, errors.New("nonce commitment is the identity point")
+		}
+	}
+	return out, nil
+}
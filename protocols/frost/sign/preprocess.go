@@ -0,0 +1,182 @@
+package sign
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/protocols/frost/keygen"
+)
+
+const (
+	// Frost nonce preprocessing.
+	protocolPreprocessID = "frost/preprocess"
+	// This protocol has 2 concrete rounds.
+	protocolPreprocessRounds round.Number = 2
+)
+
+// StartPreprocess generates count nonces for each participant of result,
+// and publishes their commitments to all other participants.
+//
+// This corresponds to Figure 2 of the Frost paper, where the commitments are broadcast instead of
+// being published to a signing authority:
+//
+//	https://eprint.iacr.org/2020/852.pdf
+func StartPreprocess(result *keygen.Config, count int, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if count <= 0 {
+			return nil, fmt.Errorf("sign.StartPreprocess: invalid number of nonces %d", count)
+		}
+		partyIDs := make([]party.ID, 0, len(result.VerificationShares.Points))
+		for id := range result.VerificationShares.Points {
+			partyIDs = append(partyIDs, id)
+		}
+		info := round.Info{
+			ProtocolID:       protocolPreprocessID,
+			FinalRoundNumber: protocolPreprocessRounds,
+			SelfID:           result.ID,
+			PartyIDs:         partyIDs,
+			Threshold:        result.Threshold,
+			Group:            result.PublicKey.Curve(),
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, nil, &hash.BytesWithDomain{
+			TheDomain: "Nonce Count",
+			Bytes:     binary.BigEndian.AppendUint64(nil, uint64(count)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartPreprocess: %w", err)
+		}
+		return &preprocess1{
+			Helper: helper,
+			count:  count,
+		}, nil
+	}
+}
+
+type preprocess1 struct {
+	*round.Helper
+	// count is the number of nonces each participant generates.
+	count int
+}
+
+type preprocessBroadcast2 struct {
+	// The commitments must be the same for all participants, since they are bound to each signature.
+	round.ReliableBroadcastContent
+	// D[j], E[j] = (Dᵢⱼ, Eᵢⱼ) are the encoded commitments of the sender.
+	D, E [][]byte
+}
+
+// VerifyMessage implements round.Round.
+func (preprocess1) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (preprocess1) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - sample (dᵢⱼ, eᵢⱼ) for each j, and broadcast (Dᵢⱼ, Eᵢⱼ) = (dᵢⱼ⋅G, eᵢⱼ⋅G).
+func (r *preprocess1) Finalize(out chan<- *round.Message) (round.Session, error) {
+	c := &Commitments{
+		ID:    r.SSID(),
+		d:     make([]curve.Scalar, r.count),
+		e:     make([]curve.Scalar, r.count),
+		D:     make(map[party.ID][]curve.Point, r.N()),
+		E:     make(map[party.ID][]curve.Point, r.N()),
+		group: r.Group(),
+	}
+	D := make([]curve.Point, r.count)
+	E := make([]curve.Point, r.count)
+	for j := 0; j < r.count; j++ {
+		c.d[j] = sample.ScalarUnit(r.Rand(), r.Group())
+		c.e[j] = sample.ScalarUnit(r.Rand(), r.Group())
+		D[j] = c.d[j].ActOnBase()
+		E[j] = c.e[j].ActOnBase()
+	}
+	c.D[r.SelfID()], c.E[r.SelfID()] = D, E
+
+	DBytes, err := marshalPoints(D)
+	if err != nil {
+		return r, err
+	}
+	EBytes, err := marshalPoints(E)
+	if err != nil {
+		return r, err
+	}
+	if err = r.BroadcastMessage(out, &preprocessBroadcast2{D: DBytes, E: EBytes}); err != nil {
+		return r, err
+	}
+	return &preprocess2{
+		preprocess1: r,
+		commitments: c,
+	}, nil
+}
+
+// MessageContent implements round.Round.
+func (preprocess1) MessageContent() round.Content { return nil }
+
+// Number implements round.Round.
+func (preprocess1) Number() round.Number { return 1 }
+
+type preprocess2 struct {
+	*preprocess1
+	commitments *Commitments
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - store the commitments of the sender, after checking that there are count of them, and none is the identity.
+func (r *preprocess2) StoreBroadcastMessage(msg round.Message) error {
+	body, ok := msg.Content.(*preprocessBroadcast2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if len(body.D) != r.count || len(body.E) != r.count {
+		return fmt.Errorf("expected %d nonce commitments", r.count)
+	}
+	D, err := unmarshalPoints(r.Group(), body.D)
+	if err != nil {
+		return err
+	}
+	E, err := unmarshalPoints(r.Group(), body.E)
+	if err != nil {
+		return err
+	}
+	r.commitments.D[msg.From], r.commitments.E[msg.From] = D, E
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (preprocess2) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (preprocess2) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - output the commitments of all participants, and our secret nonces.
+func (r *preprocess2) Finalize(chan<- *round.Message) (round.Session, error) {
+	if len(r.commitments.D) != r.N() {
+		return r, errors.New("missing nonce commitments")
+	}
+	return r.ResultRound(r.commitments), nil
+}
+
+// MessageContent implements round.Round.
+func (preprocess2) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (preprocessBroadcast2) RoundNumber() round.Number { return 2 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (preprocess2) BroadcastContent() round.BroadcastContent { return &preprocessBroadcast2{} }
+
+// Number implements round.Round.
+func (preprocess2) Number() round.Number { return 2 }
@@ -1,7 +1,7 @@
 package sign
 
 import (
-	"crypto/rand"
+	"io"
 
 	"github.com/taurusgroup/multi-party-sig/internal/round"
 	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
@@ -27,6 +27,12 @@ type round1 struct {
 	// and we need to make sure to generate our challenge in the correct way. Naturally,
 	// we also return a taproot.Signature instead a generic signature.
 	taproot bool
+	// ed25519 indicates whether or not we need to generate RFC 8032 Ed25519 signatures.
+	//
+	// If so, the binding factors and the challenge are computed as in the
+	// FROST(Ed25519, SHA-512) ciphersuite of RFC 9591, and we return the encoded
+	// signature as a byte slice.
+	ed25519 bool
 	// M is the hash of the message we're signing.
 	//
 	// This plays the same role as m in the Frost paper. One slight difference
@@ -75,7 +81,7 @@ func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
 	_, _ = nonceHasher.Write(r.Hash().Sum())
 	_, _ = nonceHasher.Write(r.M)
 	a := make([]byte, 32)
-	_, _ = rand.Read(a)
+	_, _ = io.ReadFull(r.Rand(), a)
 	_, _ = nonceHasher.Write(a)
 	nonceDigest := nonceHasher.Digest()
 
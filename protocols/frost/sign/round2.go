@@ -92,18 +92,25 @@ func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
 	//
 	// We also use a hash of the message, instead of the message directly.
 
-	rho := make(map[party.ID]curve.Scalar)
-	// This calculates H(m, B), allowing us to avoid re-hashing this data for
-	// each extra party l.
-	rhoPreHash := hash.New()
-	_ = rhoPreHash.WriteAny(r.M)
-	for _, l := range r.PartyIDs() {
-		_ = rhoPreHash.WriteAny(r.D[l], r.E[l])
-	}
-	for _, l := range r.PartyIDs() {
-		rhoHash := rhoPreHash.Clone()
-		_ = rhoHash.WriteAny(l)
-		rho[l] = sample.Scalar(rhoHash.Digest(), r.Group())
+	var rho map[party.ID]curve.Scalar
+	if r.ed25519 {
+		// RFC 9591 adjustment: the binding factors are computed with compute_binding_factors,
+		// over the actual message, and the commitment list sorted by identifier.
+		rho = ed25519BindingFactors(r.Group(), r.Y, r.M, r.PartyIDs(), r.D, r.E)
+	} else {
+		rho = make(map[party.ID]curve.Scalar)
+		// This calculates H(m, B), allowing us to avoid re-hashing this data for
+		// each extra party l.
+		rhoPreHash := hash.New()
+		_ = rhoPreHash.WriteAny(r.M)
+		for _, l := range r.PartyIDs() {
+			_ = rhoPreHash.WriteAny(r.D[l], r.E[l])
+		}
+		for _, l := range r.PartyIDs() {
+			rhoHash := rhoPreHash.Clone()
+			_ = rhoHash.WriteAny(l)
+			rho[l] = sample.Scalar(rhoHash.Digest(), r.Group())
+		}
 	}
 
 	R := r.Group().NewPoint()
@@ -134,6 +141,10 @@ func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
 		PBytes := r.Y.(*curve.Secp256k1Point).XBytes()
 		cHash := taproot.TaggedHash("BIP0340/challenge", RBytes, PBytes, r.M)
 		c = r.Group().NewScalar().SetNat(new(saferith.Nat).SetBytes(cHash))
+	} else if r.ed25519 {
+		// RFC 9591 adjustment: the challenge is the same as in RFC 8032,
+		// so that the resulting signature is a standard Ed25519 signature.
+		c = ed25519Challenge(R, r.Y, r.M)
 	} else {
 		cHash := hash.New()
 		_ = cHash.WriteAny(R, r.Y, r.M)
@@ -189,3 +200,29 @@ func (r *round2) BroadcastContent() round.BroadcastContent {
 
 // Number implements round.Round.
 func (round2) Number() round.Number { return 2 }
+
+// round2Preprocessed starts the signing protocol in round 2, when the commitments of round 1 were preprocessed.
+//
+// Unlike round2, it expects no messages, so that it can be finalized immediately.
+type round2Preprocessed struct {
+	*round.Helper
+	// next already contains the preprocessed nonces and commitments of all signers.
+	next *round2
+}
+
+// VerifyMessage implements round.Round.
+func (round2Preprocessed) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round2Preprocessed) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round.
+func (r *round2Preprocessed) Finalize(out chan<- *round.Message) (round.Session, error) {
+	return r.next.Finalize(out)
+}
+
+// MessageContent implements round.Round.
+func (round2Preprocessed) MessageContent() round.Content { return nil }
+
+// Number implements round.Round.
+func (round2Preprocessed) Number() round.Number { return 2 }
@@ -1,6 +1,7 @@
 package sign
 
 import (
+	"crypto/ed25519"
 	"fmt"
 
 	"github.com/taurusgroup/multi-party-sig/internal/round"
@@ -110,6 +111,17 @@ func (r *round3) Finalize(chan<- *round.Message) (round.Session, error) {
 			return r.AbortRound(fmt.Errorf("generated signature failed to verify")), nil
 		}
 
+		return r.ResultRound(sig), nil
+	} else if r.ed25519 {
+		// RFC 9591: the signature is encoded as SerializeElement(R) || SerializeScalar(z).
+		sig := make([]byte, 0, ed25519.SignatureSize)
+		sig = append(sig, ed25519SerializeElement(r.R)...)
+		sig = append(sig, ed25519SerializeScalar(z)...)
+
+		if !ed25519.Verify(ed25519SerializeElement(r.Y), r.M, sig) {
+			return r.AbortRound(fmt.Errorf("generated signature failed to verify")), nil
+		}
+
 		return r.ResultRound(sig), nil
 	} else {
 		sig := Signature{
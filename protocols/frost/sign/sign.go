@@ -1,9 +1,13 @@
 package sign
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 
 	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
 	"github.com/taurusgroup/multi-party-sig/pkg/party"
 	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
 	"github.com/taurusgroup/multi-party-sig/protocols/frost/keygen"
@@ -13,11 +17,14 @@ const (
 	// Frost Sign with Threshold.
 	protocolID        = "frost/sign-threshold"
 	protocolIDTaproot = "frost/sign-threshold-taproot"
+	protocolIDEd25519 = "frost/sign-threshold-ed25519"
+	// Frost Sign with Threshold, using preprocessed nonces.
+	protocolIDPreprocessed = "frost/sign-threshold-preprocessed"
 	// This protocol has 3 concrete rounds.
 	protocolRounds round.Number = 3
 )
 
-func StartSignCommon(taproot bool, result *keygen.Config, signers []party.ID, messageHash []byte) protocol.StartFunc {
+func StartSignCommon(taproot bool, result *keygen.Config, signers []party.ID, messageHash []byte, opts ...protocol.Option) protocol.StartFunc {
 	return func(sessionID []byte) (round.Session, error) {
 		info := round.Info{
 			FinalRoundNumber: protocolRounds,
@@ -26,6 +33,7 @@ func StartSignCommon(taproot bool, result *keygen.Config, signers []party.ID, me
 			Threshold:        result.Threshold,
 			Group:            result.PublicKey.Curve(),
 		}
+		info.Apply(opts...)
 		if taproot {
 			info.ProtocolID = protocolIDTaproot
 		} else {
@@ -46,3 +54,92 @@ func StartSignCommon(taproot bool, result *keygen.Config, signers []party.ID, me
 		}, nil
 	}
 }
+
+// StartSignEd25519 is like StartSignCommon, but produces an RFC 8032 Ed25519 signature,
+// following the FROST(Ed25519, SHA-512) ciphersuite of RFC 9591.
+//
+// The result must have been generated over curve.Edwards25519, and message is the raw message
+// being signed, since Ed25519 already hashes it internally.
+func StartSignEd25519(result *keygen.Config, signers []party.ID, message []byte, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if _, ok := result.PublicKey.(*curve.Edwards25519Point); !ok {
+			return nil, errors.New("sign.StartSignEd25519: config must use curve.Edwards25519")
+		}
+		info := round.Info{
+			ProtocolID:       protocolIDEd25519,
+			FinalRoundNumber: protocolRounds,
+			SelfID:           result.ID,
+			PartyIDs:         signers,
+			Threshold:        result.Threshold,
+			Group:            result.PublicKey.Curve(),
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartSignEd25519: %w", err)
+		}
+		return &round1{
+			Helper:  helper,
+			ed25519: true,
+			M:       message,
+			Y:       result.PublicKey,
+			YShares: result.VerificationShares.Points,
+			s_i:     result.PrivateShare,
+		}, nil
+	}
+}
+
+// StartSignWithCommitments is like StartSignCommon, but uses the j-th nonces of commitments,
+// which were generated by StartPreprocess, so that signing needs a single round of messages.
+//
+// The nonces are consumed before signing, and an error wrapping ErrNoncesUsed is returned if they were already used.
+// All signers must use the same index j.
+func StartSignWithCommitments(result *keygen.Config, commitments *Commitments, j int, signers []party.ID, messageHash []byte, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		info := round.Info{
+			ProtocolID:       protocolIDPreprocessed,
+			FinalRoundNumber: protocolRounds,
+			SelfID:           result.ID,
+			PartyIDs:         signers,
+			Threshold:        result.Threshold,
+			Group:            result.PublicKey.Curve(),
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, nil, &hash.BytesWithDomain{
+			TheDomain: "Preprocessed Nonces",
+			Bytes:     binary.BigEndian.AppendUint64(append([]byte(nil), commitments.ID...), uint64(j)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartSignWithCommitments: %w", err)
+		}
+		D, E, err := commitments.commitments(j, helper.PartyIDs())
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartSignWithCommitments: %w", err)
+		}
+		d_i, e_i, err := commitments.Consume(j)
+		if err != nil {
+			return nil, fmt.Errorf("sign.StartSignWithCommitments: %w", err)
+		}
+		if !d_i.ActOnBase().Equal(D[result.ID]) || !e_i.ActOnBase().Equal(E[result.ID]) {
+			return nil, errors.New("sign.StartSignWithCommitments: nonces do not match our commitments")
+		}
+		return &round2Preprocessed{
+			Helper: helper,
+			next: &round2{
+				round1: &round1{
+					Helper:  helper,
+					M:       messageHash,
+					Y:       result.PublicKey,
+					YShares: result.VerificationShares.Points,
+					s_i:     result.PrivateShare,
+				},
+				d_i: d_i,
+				e_i: e_i,
+				D:   D,
+				E:   E,
+			},
+		}, nil
+	}
+}
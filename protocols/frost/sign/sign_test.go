@@ -1,8 +1,10 @@
 package sign
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,7 +39,7 @@ func TestSign(t *testing.T) {
 	partyIDs := test.PartyIDs(N)
 
 	secret := sample.Scalar(rand.Reader, group)
-	f := polynomial.NewPolynomial(group, threshold, secret)
+	f := polynomial.NewPolynomial(rand.Reader, group, threshold, secret)
 	publicKey := secret.ActOnBase()
 	steak := []byte{0xDE, 0xAD, 0xBE, 0xEF}
 	chainKey := make([]byte, params.SecBytes)
@@ -106,7 +108,7 @@ func TestSignTaproot(t *testing.T) {
 	if !publicPoint.(*curve.Secp256k1Point).HasEvenY() {
 		secret.Negate()
 	}
-	f := polynomial.NewPolynomial(group, threshold, secret)
+	f := polynomial.NewPolynomial(rand.Reader, group, threshold, secret)
 	publicKey := taproot.PublicKey(publicPoint.(*curve.Secp256k1Point).XBytes())
 	steakHash := sha256.New()
 	_, _ = steakHash.Write([]byte{0xDE, 0xAD, 0xBE, 0xEF})
@@ -166,3 +168,265 @@ func TestSignTaproot(t *testing.T) {
 
 	checkOutputTaproot(t, rounds, newPublicKey, steak)
 }
+
+func TestSignEd25519(t *testing.T) {
+	group := curve.Edwards25519{}
+	threshold := 2
+
+	// these identifiers are ordered differently as strings and as scalars
+	partyIDs := party.NewIDSlice([]party.ID{"a", "b", "aa", "ab"})
+	N := len(partyIDs)
+
+	secret := sample.Scalar(rand.Reader, group)
+	f := polynomial.NewPolynomial(rand.Reader, group, threshold, secret)
+	publicKey := secret.ActOnBase()
+	message := []byte("hello ed25519")
+
+	privateShares := make(map[party.ID]curve.Scalar, N)
+	verificationShares := make(map[party.ID]curve.Point, N)
+	for _, id := range partyIDs {
+		privateShares[id] = f.Evaluate(id.Scalar(group))
+		verificationShares[id] = privateShares[id].ActOnBase()
+	}
+
+	rounds := make([]round.Session, 0, N)
+	for _, id := range partyIDs {
+		result := &keygen.Config{
+			ID:                 id,
+			Threshold:          threshold,
+			PublicKey:          publicKey,
+			PrivateShare:       privateShares[id],
+			VerificationShares: party.NewPointMap(verificationShares),
+		}
+		r, err := StartSignEd25519(result, partyIDs, message)(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+
+	publicKeyBytes, err := publicKey.MarshalBinary()
+	require.NoError(t, err)
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r, "expected result round")
+		resultRound := r.(*round.Output)
+		require.IsType(t, []byte{}, resultRound.Result, "expected ed25519 signature result")
+		assert.True(t, ed25519.Verify(publicKeyBytes, message, resultRound.Result.([]byte)), "expected valid signature")
+	}
+}
+
+// TestSignEd25519Vectors checks the FROST(Ed25519, SHA-512) test vectors of RFC 9591, Appendix E.1,
+// in which participants 1 and 3 sign, starting from the nonces of round one:
+//
+//	https://www.rfc-editor.org/rfc/rfc9591.html#name-frosted25519-sha-512-2
+func TestSignEd25519Vectors(t *testing.T) {
+	group := curve.Edwards25519{}
+	decode := func(s string) []byte {
+		data, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		return data
+	}
+	scalar := func(s string) curve.Scalar {
+		x, err := new(curve.Edwards25519Scalar).SetBytesLE(decode(s))
+		require.NoError(t, err)
+		return x
+	}
+	point := func(s string) curve.Point {
+		p := group.NewPoint()
+		require.NoError(t, p.UnmarshalBinary(decode(s)))
+		return p
+	}
+
+	publicKey := point("15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673")
+	message := decode("74657374")
+	// the scalars of these identifiers are 1 and 3, as in the RFC
+	p1, p3 := party.ID([]byte{1}), party.ID([]byte{3})
+	signers := party.NewIDSlice([]party.ID{p1, p3})
+	shares := map[party.ID]curve.Scalar{
+		p1: scalar("929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509"),
+		p3: scalar("d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02"),
+	}
+	hidingNonces := map[party.ID]curve.Scalar{
+		p1: scalar("812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407"),
+		p3: scalar("c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e"),
+	}
+	bindingNonces := map[party.ID]curve.Scalar{
+		p1: scalar("b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301"),
+		p3: scalar("243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d"),
+	}
+	D := map[party.ID]curve.Point{
+		p1: point("b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3"),
+		p3: point("cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91"),
+	}
+	E := map[party.ID]curve.Point{
+		p1: point("67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932"),
+		p3: point("7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552"),
+	}
+	bindingFactors := map[party.ID]string{
+		p1: "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+		p3: "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+	}
+	sigShares := map[party.ID]string{
+		p1: "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+		p3: "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+	}
+	// the group commitment is the first half of the signature
+	groupCommitment := "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe"
+	signature := decode("36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b")
+
+	verificationShares := make(map[party.ID]curve.Point, len(signers))
+	for _, id := range signers {
+		require.True(t, hidingNonces[id].ActOnBase().Equal(D[id]), "hiding nonce commitment")
+		require.True(t, bindingNonces[id].ActOnBase().Equal(E[id]), "binding nonce commitment")
+		verificationShares[id] = shares[id].ActOnBase()
+	}
+
+	rho := ed25519BindingFactors(group, publicKey, message, signers, D, E)
+	for _, id := range signers {
+		assert.Equal(t, bindingFactors[id], hex.EncodeToString(ed25519SerializeScalar(rho[id])), "binding factor")
+	}
+
+	rounds := make([]round.Session, 0, len(signers))
+	for _, id := range signers {
+		helper, err := round.NewSession(round.Info{
+			ProtocolID:       protocolIDEd25519,
+			FinalRoundNumber: protocolRounds,
+			SelfID:           id,
+			PartyIDs:         signers,
+			Threshold:        1,
+			Group:            group,
+		}, nil, nil)
+		require.NoError(t, err)
+		rounds = append(rounds, &round2{
+			round1: &round1{
+				Helper:  helper,
+				ed25519: true,
+				M:       message,
+				Y:       publicKey,
+				YShares: verificationShares,
+				s_i:     shares[id],
+			},
+			d_i: hidingNonces[id],
+			e_i: bindingNonces[id],
+			D:   D,
+			E:   E,
+		})
+	}
+
+	err, _ := test.Rounds(rounds, nil)
+	require.NoError(t, err, "failed to process round")
+	for _, r := range rounds {
+		require.IsType(t, &round3{}, r)
+		r3 := r.(*round3)
+		assert.Equal(t, groupCommitment, hex.EncodeToString(ed25519SerializeElement(r3.R)), "group commitment")
+		assert.Equal(t, sigShares[r3.SelfID()], hex.EncodeToString(ed25519SerializeScalar(r3.z[r3.SelfID()])), "signature share")
+	}
+
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+	publicKeyBytes, err := publicKey.MarshalBinary()
+	require.NoError(t, err)
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r, "expected result round")
+		result := r.(*round.Output).Result
+		assert.Equal(t, signature, result, "signature")
+		assert.True(t, ed25519.Verify(publicKeyBytes, message, result.([]byte)), "expected valid signature")
+	}
+}
+
+func TestSignWithCommitments(t *testing.T) {
+	group := curve.Secp256k1{}
+	N := 5
+	threshold := 2
+
+	partyIDs := test.PartyIDs(N)
+
+	secret := sample.Scalar(rand.Reader, group)
+	f := polynomial.NewPolynomial(rand.Reader, group, threshold, secret)
+	publicKey := secret.ActOnBase()
+	steak := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+
+	verificationShares := make(map[party.ID]curve.Point, N)
+	configs := make(map[party.ID]*keygen.Config, N)
+	for _, id := range partyIDs {
+		verificationShares[id] = f.Evaluate(id.Scalar(group)).ActOnBase()
+	}
+	for _, id := range partyIDs {
+		configs[id] = &keygen.Config{
+			ID:                 id,
+			Threshold:          threshold,
+			PublicKey:          publicKey,
+			PrivateShare:       f.Evaluate(id.Scalar(group)),
+			VerificationShares: party.NewPointMap(verificationShares),
+		}
+	}
+
+	rounds := make([]round.Session, 0, N)
+	for _, id := range partyIDs {
+		r, err := StartPreprocess(configs[id], 2)(nil)
+		require.NoError(t, err, "round creation should not result in an error")
+		rounds = append(rounds, r)
+	}
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+	commitments := make(map[party.ID]*Commitments, N)
+	for i, id := range partyIDs {
+		require.IsType(t, &round.Output{}, rounds[i], "expected result round")
+		commitments[id] = rounds[i].(*round.Output).Result.(*Commitments)
+		assert.Equal(t, []int{0, 1}, commitments[id].Available())
+	}
+
+	sign := func(signers party.IDSlice, j int) []round.Session {
+		rounds := make([]round.Session, 0, len(signers))
+		for _, id := range signers {
+			r, err := StartSignWithCommitments(configs[id], commitments[id], j, signers, steak)(nil)
+			require.NoError(t, err, "round creation should not result in an error")
+			rounds = append(rounds, r)
+		}
+		for {
+			err, done := test.Rounds(rounds, nil)
+			require.NoError(t, err, "failed to process round")
+			if done {
+				break
+			}
+		}
+		return rounds
+	}
+
+	signers := partyIDs[:threshold+1]
+	checkOutput(t, sign(signers, 0), publicKey, steak)
+
+	// the nonces can't be used again, even to sign the same message
+	_, err := StartSignWithCommitments(configs[signers[0]], commitments[signers[0]], 0, signers, steak)(nil)
+	assert.ErrorIs(t, err, ErrNoncesUsed)
+	assert.Equal(t, []int{1}, commitments[signers[0]].Available())
+
+	// the used nonces are still tracked after unmarshalling
+	for _, id := range partyIDs {
+		data, err := commitments[id].MarshalBinary()
+		require.NoError(t, err)
+		commitments[id] = EmptyCommitments(group)
+		require.NoError(t, commitments[id].UnmarshalBinary(data))
+	}
+	_, err = StartSignWithCommitments(configs[signers[0]], commitments[signers[0]], 0, signers, steak)(nil)
+	assert.ErrorIs(t, err, ErrNoncesUsed)
+
+	checkOutput(t, sign(partyIDs[1:], 1), publicKey, steak)
+}
@@ -0,0 +1,445 @@
+package musig2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cronokirby/saferith"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
+)
+
+// This file implements the algorithms of BIP-327, with the same names and encodings:
+//
+//	https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki
+//
+// Public keys and public nonces are encoded as compressed points, and scalars as 32 big-endian bytes.
+
+const (
+	// PublicKeyLength is the length of a plain public key.
+	PublicKeyLength = 33
+	// PubNonceLength is the length of a public nonce, or of an aggregate nonce.
+	PubNonceLength = 66
+	// SecNonceLength is the length of a secret nonce, which contains the public key of the signer.
+	SecNonceLength = 97
+	// PartialSignatureLength is the length of a partial signature.
+	PartialSignatureLength = 32
+)
+
+// Tweak is added to the aggregate public key Q, after negating Q if XOnly is set and Q has an odd y coordinate.
+type Tweak struct {
+	// Tweak is a 32 byte scalar.
+	Tweak []byte
+	// XOnly is set for a tweak of the x-only key, such as a BIP-341 TapTweak.
+	XOnly bool
+}
+
+// KeyAggContext is the result of aggregating public keys, with some tweaks applied.
+type KeyAggContext struct {
+	// Q is the aggregate public key.
+	Q *curve.Secp256k1Point
+	// gacc and tacc accumulate the negations and the tweaks applied to the aggregate key.
+	gacc, tacc *curve.Secp256k1Scalar
+	// publicKeys are the aggregated keys, in order, and secondKey is the first of them different from the first key.
+	publicKeys [][]byte
+	secondKey  []byte
+	listHash   []byte
+}
+
+// KeySort sorts public keys lexicographically, which makes KeyAgg independent of the order of the signers.
+func KeySort(publicKeys [][]byte) [][]byte {
+	sorted := make([][]byte, len(publicKeys))
+	copy(sorted, publicKeys)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+	return sorted
+}
+
+// KeyAgg aggregates plain public keys into a single public key.
+//
+// The result depends on the order of publicKeys, which can be sorted with KeySort.
+func KeyAgg(publicKeys [][]byte) (*KeyAggContext, error) {
+	if len(publicKeys) == 0 {
+		return nil, errors.New("musig2: no public keys")
+	}
+	ctx := &KeyAggContext{
+		gacc:       scalarOne(),
+		tacc:       new(curve.Secp256k1Scalar),
+		publicKeys: publicKeys,
+		secondKey:  make([]byte, PublicKeyLength),
+		listHash:   taproot.TaggedHash("KeyAgg list", publicKeys...),
+	}
+	for _, pk := range publicKeys[1:] {
+		if !bytes.Equal(pk, publicKeys[0]) {
+			ctx.secondKey = pk
+			break
+		}
+	}
+	Q := curve.Secp256k1{}.NewPoint()
+	for i, pk := range publicKeys {
+		P, err := cpoint(pk)
+		if err != nil {
+			return nil, fmt.Errorf("musig2: public key %d: %w", i, err)
+		}
+		Q = Q.Add(ctx.coefficient(pk).Act(P))
+	}
+	if Q.IsIdentity() {
+		return nil, errors.New("musig2: aggregate public key is infinite")
+	}
+	ctx.Q = Q.(*curve.Secp256k1Point)
+	return ctx, nil
+}
+
+// coefficient returns the coefficient aᵢ of the public key pk.
+func (c *KeyAggContext) coefficient(pk []byte) *curve.Secp256k1Scalar {
+	if bytes.Equal(pk, c.secondKey) {
+		return scalarOne()
+	}
+	return scalarFromHash(taproot.TaggedHash("KeyAgg coefficient", c.listHash, pk))
+}
+
+// ApplyTweak returns the context for the public key tweaked by tweak.
+func (c *KeyAggContext) ApplyTweak(tweak Tweak) (*KeyAggContext, error) {
+	t := new(curve.Secp256k1Scalar)
+	if err := t.UnmarshalBinary(tweak.Tweak); err != nil {
+		return nil, fmt.Errorf("musig2: invalid tweak: %w", err)
+	}
+	g := scalarOne()
+	if tweak.XOnly && !c.Q.HasEvenY() {
+		g.Negate()
+	}
+	Q := g.Act(c.Q).Add(t.ActOnBase())
+	if Q.IsIdentity() {
+		return nil, errors.New("musig2: tweaked public key is infinite")
+	}
+	tweaked := *c
+	tweaked.Q = Q.(*curve.Secp256k1Point)
+	tweaked.gacc = new(curve.Secp256k1Scalar)
+	tweaked.gacc.Set(g).Mul(c.gacc)
+	tweaked.tacc = new(curve.Secp256k1Scalar)
+	tweaked.tacc.Set(g).Mul(c.tacc).Add(t)
+	return &tweaked, nil
+}
+
+// PublicKey returns the x-only aggregate public key, which verifies the final signature.
+func (c *KeyAggContext) PublicKey() taproot.PublicKey {
+	return c.Q.XBytes()
+}
+
+// PlainPublicKey returns the aggregate public key as a compressed point.
+func (c *KeyAggContext) PlainPublicKey() []byte {
+	data, _ := c.Q.MarshalBinary()
+	return data
+}
+
+// NonceGen generates a secret nonce, and the corresponding public nonce.
+//
+// secretKey, aggregateKey and extra are optional, and may be nil. The message is optional as well,
+// and is considered absent if nil, while an empty non-nil message is signed as such.
+// The secret nonce must be used in a single call to SessionContext.Sign.
+func NonceGen(rand io.Reader, secretKey, publicKey, aggregateKey, message, extra []byte) (secNonce, pubNonce []byte, err error) {
+	randPrime := make([]byte, 32)
+	if _, err = io.ReadFull(rand, randPrime); err != nil {
+		return nil, nil, fmt.Errorf("musig2: %w", err)
+	}
+	return nonceGen(randPrime, secretKey, publicKey, aggregateKey, message, extra)
+}
+
+func nonceGen(randPrime, secretKey, publicKey, aggregateKey, message, extra []byte) (secNonce, pubNonce []byte, err error) {
+	if len(publicKey) != PublicKeyLength {
+		return nil, nil, errors.New("musig2: invalid public key length")
+	}
+	r := randPrime
+	if len(secretKey) > 0 {
+		if len(secretKey) != 32 {
+			return nil, nil, errors.New("musig2: invalid secret key length")
+		}
+		r = taproot.TaggedHash("MuSig/aux", randPrime)
+		for i := range r {
+			r[i] ^= secretKey[i]
+		}
+	}
+	messagePrefixed := []byte{0}
+	if message != nil {
+		messagePrefixed = append([]byte{1}, binary.BigEndian.AppendUint64(nil, uint64(len(message)))...)
+		messagePrefixed = append(messagePrefixed, message...)
+	}
+
+	secNonce = make([]byte, 0, SecNonceLength)
+	pubNonce = make([]byte, 0, PubNonceLength)
+	for i := byte(0); i < 2; i++ {
+		k := scalarFromHash(taproot.TaggedHash("MuSig/nonce",
+			r,
+			[]byte{byte(len(publicKey))}, publicKey,
+			[]byte{byte(len(aggregateKey))}, aggregateKey,
+			messagePrefixed,
+			binary.BigEndian.AppendUint32(nil, uint32(len(extra))), extra,
+			[]byte{i},
+		))
+		if k.IsZero() {
+			return nil, nil, errors.New("musig2: nonce is zero")
+		}
+		kBytes, _ := k.MarshalBinary()
+		secNonce = append(secNonce, kBytes...)
+		pubNonce = append(pubNonce, cbytes(k.ActOnBase())...)
+	}
+	secNonce = append(secNonce, publicKey...)
+	return secNonce, pubNonce, nil
+}
+
+// NonceAgg aggregates the public nonces of all signers.
+func NonceAgg(pubNonces [][]byte) ([]byte, error) {
+	aggNonce := make([]byte, 0, PubNonceLength)
+	for j := 0; j < 2; j++ {
+		R := curve.Secp256k1{}.NewPoint()
+		for i, pubNonce := range pubNonces {
+			if len(pubNonce) != PubNonceLength {
+				return nil, fmt.Errorf("musig2: public nonce %d: invalid length", i)
+			}
+			Rij, err := cpoint(pubNonce[j*PublicKeyLength : (j+1)*PublicKeyLength])
+			if err != nil {
+				return nil, fmt.Errorf("musig2: public nonce %d: %w", i, err)
+			}
+			R = R.Add(Rij)
+		}
+		aggNonce = append(aggNonce, cbytesExt(R)...)
+	}
+	return aggNonce, nil
+}
+
+// SessionContext contains the data which all signers agree on before signing a message.
+type SessionContext struct {
+	// AggNonce is the output of NonceAgg.
+	AggNonce []byte
+	// PublicKeys are the plain public keys of the signers, in the order in which they are aggregated.
+	PublicKeys [][]byte
+	// Tweaks are applied in order to the aggregate public key.
+	Tweaks []Tweak
+	// Message is the message being signed.
+	Message []byte
+}
+
+type sessionValues struct {
+	keys *KeyAggContext
+	b, e *curve.Secp256k1Scalar
+	R    *curve.Secp256k1Point
+}
+
+// KeyAggContext returns the aggregate public key of the signers, with all tweaks applied.
+func (s *SessionContext) KeyAggContext() (*KeyAggContext, error) {
+	keys, err := KeyAgg(s.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+	for _, tweak := range s.Tweaks {
+		if keys, err = keys.ApplyTweak(tweak); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func (s *SessionContext) values() (*sessionValues, error) {
+	keys, err := s.KeyAggContext()
+	if err != nil {
+		return nil, err
+	}
+	if len(s.AggNonce) != PubNonceLength {
+		return nil, errors.New("musig2: invalid aggregate nonce length")
+	}
+	Q := keys.Q.XBytes()
+	b := scalarFromHash(taproot.TaggedHash("MuSig/noncecoef", s.AggNonce, Q, s.Message))
+	R1, err := cpointExt(s.AggNonce[:PublicKeyLength])
+	if err != nil {
+		return nil, fmt.Errorf("musig2: aggregate nonce: %w", err)
+	}
+	R2, err := cpointExt(s.AggNonce[PublicKeyLength:])
+	if err != nil {
+		return nil, fmt.Errorf("musig2: aggregate nonce: %w", err)
+	}
+	R := R1.Add(b.Act(R2))
+	if R.IsIdentity() {
+		R = curve.Secp256k1{}.NewBasePoint()
+	}
+	RPoint := R.(*curve.Secp256k1Point)
+	e := scalarFromHash(taproot.TaggedHash("BIP0340/challenge", RPoint.XBytes(), Q, s.Message))
+	return &sessionValues{keys: keys, b: b, e: e, R: RPoint}, nil
+}
+
+// coefficient returns the key aggregation coefficient of pk, which must be one of the signers.
+func (v *sessionValues) coefficient(pk []byte) (*curve.Secp256k1Scalar, error) {
+	for _, other := range v.keys.publicKeys {
+		if bytes.Equal(other, pk) {
+			return v.keys.coefficient(pk), nil
+		}
+	}
+	return nil, errors.New("musig2: public key is not one of the signers")
+}
+
+// Sign returns the partial signature of a signer, given its secret nonce, and its secret key.
+//
+// The secret nonce is erased, so that it cannot be used to sign again.
+func (s *SessionContext) Sign(secNonce, secretKey []byte) ([]byte, error) {
+	if len(secNonce) != SecNonceLength {
+		return nil, errors.New("musig2: invalid secret nonce length")
+	}
+	k1, k2 := new(curve.Secp256k1Scalar), new(curve.Secp256k1Scalar)
+	err1 := k1.UnmarshalBinary(secNonce[:32])
+	err2 := k2.UnmarshalBinary(secNonce[32:64])
+	publicKeyNonce := append([]byte(nil), secNonce[64:]...)
+	for i := range secNonce {
+		secNonce[i] = 0
+	}
+	if err1 != nil || err2 != nil || k1.IsZero() || k2.IsZero() {
+		return nil, errors.New("musig2: invalid secret nonce")
+	}
+
+	v, err := s.values()
+	if err != nil {
+		return nil, err
+	}
+	if !v.R.HasEvenY() {
+		k1.Negate()
+		k2.Negate()
+	}
+	d := new(curve.Secp256k1Scalar)
+	if err = d.UnmarshalBinary(secretKey); err != nil || d.IsZero() {
+		return nil, errors.New("musig2: invalid secret key")
+	}
+	pk := cbytes(d.ActOnBase())
+	if !bytes.Equal(pk, publicKeyNonce) {
+		return nil, errors.New("musig2: public key does not match the secret nonce")
+	}
+	a, err := v.coefficient(pk)
+	if err != nil {
+		return nil, err
+	}
+	// d = g⋅gacc⋅d'
+	if !v.keys.Q.HasEvenY() {
+		d.Negate()
+	}
+	d.Mul(v.keys.gacc)
+
+	// s = k₁ + b⋅k₂ + e⋅a⋅d
+	sig := new(curve.Secp256k1Scalar)
+	sig.Set(v.e).Mul(a).Mul(d).Add(k1).Add(new(curve.Secp256k1Scalar).Set(v.b).Mul(k2))
+	psig, _ := sig.MarshalBinary()
+	return psig, nil
+}
+
+// Verify returns true if psig is a valid partial signature of the signer with the given public nonce and public key.
+func (s *SessionContext) Verify(psig, pubNonce, publicKey []byte) bool {
+	v, err := s.values()
+	if err != nil {
+		return false
+	}
+	return v.verify(psig, pubNonce, publicKey)
+}
+
+func (v *sessionValues) verify(psig, pubNonce, publicKey []byte) bool {
+	sig := new(curve.Secp256k1Scalar)
+	if err := sig.UnmarshalBinary(psig); err != nil {
+		return false
+	}
+	if len(pubNonce) != PubNonceLength {
+		return false
+	}
+	R1, err := cpoint(pubNonce[:PublicKeyLength])
+	if err != nil {
+		return false
+	}
+	R2, err := cpoint(pubNonce[PublicKeyLength:])
+	if err != nil {
+		return false
+	}
+	P, err := cpoint(publicKey)
+	if err != nil {
+		return false
+	}
+	a, err := v.coefficient(publicKey)
+	if err != nil {
+		return false
+	}
+	// Re = ±(R₁ + b⋅R₂)
+	Re := R1.Add(v.b.Act(R2))
+	if !v.R.HasEvenY() {
+		Re = Re.Negate()
+	}
+	// g' = g⋅gacc
+	g := new(curve.Secp256k1Scalar).Set(v.keys.gacc)
+	if !v.keys.Q.HasEvenY() {
+		g.Negate()
+	}
+	// s⋅G = Re + e⋅a⋅g'⋅P
+	ea := new(curve.Secp256k1Scalar).Set(v.e).Mul(a).Mul(g)
+	return sig.ActOnBase().Equal(Re.Add(ea.Act(P)))
+}
+
+// Aggregate combines the partial signatures of all signers into a BIP-340 signature.
+func (s *SessionContext) Aggregate(psigs [][]byte) (taproot.Signature, error) {
+	v, err := s.values()
+	if err != nil {
+		return nil, err
+	}
+	// s = ∑ᵢ sᵢ + e⋅g⋅tacc
+	sig := new(curve.Secp256k1Scalar).Set(v.e).Mul(v.keys.tacc)
+	if !v.keys.Q.HasEvenY() {
+		sig.Negate()
+	}
+	for i, psig := range psigs {
+		si := new(curve.Secp256k1Scalar)
+		if err = si.UnmarshalBinary(psig); err != nil {
+			return nil, fmt.Errorf("musig2: partial signature %d: %w", i, err)
+		}
+		sig.Add(si)
+	}
+	sigBytes, _ := sig.MarshalBinary()
+	return append(v.R.XBytes(), sigBytes...), nil
+}
+
+func scalarOne() *curve.Secp256k1Scalar {
+	one := new(curve.Secp256k1Scalar)
+	one.SetNat(new(saferith.Nat).SetUint64(1))
+	return one
+}
+
+// scalarFromHash interprets a hash as an integer modulo the group order.
+func scalarFromHash(h []byte) *curve.Secp256k1Scalar {
+	return curve.FromHash(curve.Secp256k1{}, h).(*curve.Secp256k1Scalar)
+}
+
+// cpoint decodes a compressed point.
+func cpoint(data []byte) (*curve.Secp256k1Point, error) {
+	if len(data) != PublicKeyLength || (data[0] != 2 && data[0] != 3) {
+		return nil, errors.New("invalid point encoding")
+	}
+	P := new(curve.Secp256k1Point)
+	if err := P.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return P, nil
+}
+
+// cpointExt is like cpoint, but decodes 33 zero bytes as the point at infinity.
+func cpointExt(data []byte) (curve.Point, error) {
+	if bytes.Equal(data, make([]byte, PublicKeyLength)) {
+		return curve.Secp256k1{}.NewPoint(), nil
+	}
+	return cpoint(data)
+}
+
+func cbytes(P curve.Point) []byte {
+	data, _ := P.MarshalBinary()
+	return data
+}
+
+// cbytesExt is like cbytes, but encodes the point at infinity as 33 zero bytes.
+func cbytesExt(P curve.Point) []byte {
+	if P.IsIdentity() {
+		return make([]byte, PublicKeyLength)
+	}
+	return cbytes(P)
+}
@@ -0,0 +1,429 @@
+package musig2
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fromHex(s string) []byte {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func toHex(data []byte) string {
+	return strings.ToUpper(hex.EncodeToString(data))
+}
+
+// pick returns the elements of values at the given indices.
+func pick(values [][]byte, indices []int) [][]byte {
+	picked := make([][]byte, len(indices))
+	for i, j := range indices {
+		picked[i] = values[j]
+	}
+	return picked
+}
+
+// pickTweaks returns the tweaks at the given indices, with the corresponding x-only flags.
+func pickTweaks(tweaks [][]byte, indices []int, xOnly []bool) []Tweak {
+	picked := make([]Tweak, len(indices))
+	for i, j := range indices {
+		picked[i] = Tweak{Tweak: tweaks[j], XOnly: xOnly[i]}
+	}
+	return picked
+}
+
+// The test vectors are taken from https://github.com/bitcoin/bips/tree/master/bip-0327/vectors.
+// Errors are checked by the part of the message which identifies the invalid contribution.
+
+func TestKeySortVectors(t *testing.T) {
+	publicKeys := [][]byte{
+		fromHex("02DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8"),
+		fromHex("02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9"),
+		fromHex("03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659"),
+		fromHex("023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66"),
+		fromHex("023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66"),
+		fromHex("02DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EFF"),
+		fromHex("02DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8"),
+	}
+	sorted := [][]byte{
+		fromHex("023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66"),
+		fromHex("023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66"),
+		fromHex("02DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8"),
+		fromHex("02DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8"),
+		fromHex("02DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EFF"),
+		fromHex("02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9"),
+		fromHex("03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659"),
+	}
+	assert.Equal(t, sorted, KeySort(publicKeys))
+}
+
+func TestKeyAggVectors(t *testing.T) {
+	publicKeys := [][]byte{
+		fromHex("02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9"),
+		fromHex("03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659"),
+		fromHex("023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66"),
+		fromHex("020000000000000000000000000000000000000000000000000000000000000005"),
+		fromHex("02FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30"),
+		fromHex("04F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9"),
+		fromHex("03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9"),
+	}
+	tweaks := [][]byte{
+		fromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141"),
+		fromHex("252E4BD67410A76CDF933D30EAA1608214037F1B105A013ECCD3C5C184A6110B"),
+	}
+
+	valid := []struct {
+		keyIndices []int
+		expected   string
+	}{
+		{[]int{0, 1, 2}, "90539EEDE565F5D054F32CC0C220126889ED1E5D193BAF15AEF344FE59D4610C"},
+		{[]int{2, 1, 0}, "6204DE8B083426DC6EAF9502D27024D53FC826BF7D2012148A0575435DF54B2B"},
+		{[]int{0, 0, 0}, "B436E3BAD62B8CD409969A224731C193D051162D8C5AE8B109306127DA3AA935"},
+		{[]int{0, 0, 1, 1}, "69BC22BFA5D106306E48A20679DE1D7389386124D07571D0D872686028C26A3E"},
+	}
+	for _, test := range valid {
+		ctx, err := KeyAgg(pick(publicKeys, test.keyIndices))
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, toHex(ctx.PublicKey()))
+	}
+
+	invalid := []struct {
+		name         string
+		keyIndices   []int
+		tweakIndices []int
+		xOnly        []bool
+		err          string
+	}{
+		{"invalid public key", []int{0, 3}, nil, nil, "public key 1"},
+		{"public key exceeds field size", []int{0, 4}, nil, nil, "public key 1"},
+		{"first byte of public key is not 2 or 3", []int{5, 0}, nil, nil, "public key 0"},
+		{"tweak is out of range", []int{0, 1}, []int{0}, []bool{true}, "invalid tweak"},
+		{"intermediate tweaking result is infinity", []int{6}, []int{1}, []bool{false}, "tweaked public key is infinite"},
+	}
+	for _, test := range invalid {
+		t.Run(test.name, func(t *testing.T) {
+			s := &SessionContext{
+				PublicKeys: pick(publicKeys, test.keyIndices),
+				Tweaks:     pickTweaks(tweaks, test.tweakIndices, test.xOnly),
+			}
+			_, err := s.KeyAggContext()
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestNonceGenVectors(t *testing.T) {
+	secNonce, _, err := nonceGen(
+		bytes.Repeat([]byte{0x0F}, 32),
+		bytes.Repeat([]byte{0x02}, 32),
+		fromHex("024D4B6CD1361032CA9BD2AEB9D900AA4D45D9EAD80AC9423374C451A7254D0766"),
+		bytes.Repeat([]byte{0x07}, 32),
+		bytes.Repeat([]byte{0x01}, 32),
+		bytes.Repeat([]byte{0x08}, 32),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "B114E502BEAA4E301DD08A50264172C84E41650E6CB726B410C0694D59EFFB6495B5CAF28D045B973D63E3C99A44B807BDE375FD6CB39E46DC4A511708D0E9D2024D4B6CD1361032CA9BD2AEB9D900AA4D45D9EAD80AC9423374C451A7254D0766", toHex(secNonce))
+}
+
+func TestNonceAggVectors(t *testing.T) {
+	pubNonces := [][]byte{
+		fromHex("020151C80F435648DF67A22B749CD798CE54E0321D034B92B709B567D60A42E66603BA47FBC1834437B3212E89A84D8425E7BF12E0245D98262268EBDCB385D50641"),
+		fromHex("03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B833"),
+		fromHex("020151C80F435648DF67A22B749CD798CE54E0321D034B92B709B567D60A42E6660279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798"),
+		fromHex("03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60379BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798"),
+		fromHex("04FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B833"),
+		fromHex("03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A60248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B831"),
+		fromHex("03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A602FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30"),
+	}
+
+	valid := []struct {
+		nonceIndices []int
+		expected     string
+	}{
+		{[]int{0, 1}, "035FE1873B4F2967F52FEA4A06AD5A8ECCBE9D0FD73068012C894E2E87CCB5804B024725377345BDE0E9C33AF3C43C0A29A9249F2F2956FA8CFEB55C8573D0262DC8"},
+		// The sum of the second points is infinity, which is encoded as 33 zero bytes.
+		{[]int{2, 3}, "035FE1873B4F2967F52FEA4A06AD5A8ECCBE9D0FD73068012C894E2E87CCB5804B000000000000000000000000000000000000000000000000000000000000000000"},
+	}
+	for _, test := range valid {
+		aggNonce, err := NonceAgg(pick(pubNonces, test.nonceIndices))
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, toHex(aggNonce))
+	}
+
+	invalid := []struct {
+		name         string
+		nonceIndices []int
+		err          string
+	}{
+		{"wrong tag in the first half", []int{0, 4}, "public nonce 1"},
+		{"second half is not an x coordinate", []int{5, 1}, "public nonce 0"},
+		{"second half exceeds field size", []int{6, 1}, "public nonce 0"},
+	}
+	for _, test := range invalid {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NonceAgg(pick(pubNonces, test.nonceIndices))
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+var (
+	signSecretKey  = fromHex("7FB9E0E687ADA1EEBF7ECFE2F21E73EBDB51A7D450948DFE8D76D7F2D1007671")
+	signPublicKeys = [][]byte{
+		fromHex("03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9"),
+		fromHex("02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9"),
+		fromHex("02DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA661"),
+		fromHex("020000000000000000000000000000000000000000000000000000000000000007"),
+	}
+	signSecNonces = [][]byte{
+		fromHex("508B81A611F100A6B2B6B29656590898AF488BCF2E1F55CF22E5CFB84421FE61FA27FD49B1D50085B481285E1CA205D55C82CC1B31FF5CD54A489829355901F703935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9"),
+		fromHex("0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000003935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9"),
+	}
+	signPubNonces = [][]byte{
+		fromHex("0337C87821AFD50A8644D820A8F3E02E499C931865C2360FB43D0A0D20DAFE07EA0287BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480"),
+		fromHex("0279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F817980279BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798"),
+		fromHex("032DE2662628C90B03F5E720284EB52FF7D71F4284F627B68A853D78C78E1FFE9303E4C5524E83FFE1493B9077CF1CA6BEB2090C93D930321071AD40B2F44E599046"),
+		fromHex("0237C87821AFD50A8644D820A8F3E02E499C931865C2360FB43D0A0D20DAFE07EA0387BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480"),
+		fromHex("0200000000000000000000000000000000000000000000000000000000000000090287BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480"),
+	}
+	signAggNonces = [][]byte{
+		fromHex("028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9"),
+		fromHex("000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"),
+		fromHex("048465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9"),
+		fromHex("028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61020000000000000000000000000000000000000000000000000000000000000009"),
+		fromHex("028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD6102FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30"),
+	}
+	signMessages = [][]byte{
+		fromHex("F95466D086770E689964664219266FE5ED215C92AE20BAB5C9D79ADDDDF3C0CF"),
+		{},
+		fromHex("2626262626262626262626262626262626262626262626262626262626262626262626262626"),
+	}
+)
+
+func TestSignVerifyVectors(t *testing.T) {
+	valid := []struct {
+		name                     string
+		keyIndices, nonceIndices []int
+		aggNonceIndex, msgIndex  int
+		signerIndex              int
+		expected                 string
+	}{
+		{"signer first", []int{0, 1, 2}, []int{0, 1, 2}, 0, 0, 0, "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB"},
+		{"signer second", []int{1, 0, 2}, []int{1, 0, 2}, 0, 0, 1, "9FF2F7AAA856150CC8819254218D3ADEEB0535269051897724F9DB3789513A52"},
+		{"signer last", []int{1, 2, 0}, []int{1, 2, 0}, 0, 0, 2, "FA23C359F6FAC4E7796BB93BC9F0532A95468C539BA20FF86D7C76ED92227900"},
+		{"aggregate nonce is infinity", []int{0, 1}, []int{0, 3}, 1, 0, 0, "AE386064B26105404798F75DE2EB9AF5EDA5387B064B83D049CB7C5E08879531"},
+		{"empty message", []int{0, 1, 2}, []int{0, 1, 2}, 0, 1, 0, "D7D63FFD644CCDA4E62BC2BC0B1D02DD32A1DC3030E155195810231D1037D82D"},
+		{"38-byte message", []int{0, 1, 2}, []int{0, 1, 2}, 0, 2, 0, "E184351828DA5094A97C79CABDAAA0BFB87608C32E8829A4DF5340A6F243B78C"},
+	}
+	for _, test := range valid {
+		t.Run(test.name, func(t *testing.T) {
+			pubNonces := pick(signPubNonces, test.nonceIndices)
+			aggNonce := signAggNonces[test.aggNonceIndex]
+			if test.aggNonceIndex == 0 {
+				computed, err := NonceAgg(pubNonces)
+				require.NoError(t, err)
+				assert.Equal(t, aggNonce, computed)
+			}
+			s := &SessionContext{
+				AggNonce:   aggNonce,
+				PublicKeys: pick(signPublicKeys, test.keyIndices),
+				Message:    signMessages[test.msgIndex],
+			}
+			psig, err := s.Sign(bytes.Clone(signSecNonces[0]), signSecretKey)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, toHex(psig))
+			assert.True(t, s.Verify(psig, pubNonces[test.signerIndex], s.PublicKeys[test.signerIndex]))
+		})
+	}
+
+	signInvalid := []struct {
+		name                    string
+		keyIndices              []int
+		aggNonceIndex, msgIndex int
+		secNonceIndex           int
+		err                     string
+	}{
+		{"signer is not in the public keys", []int{1, 2}, 0, 0, 0, "not one of the signers"},
+		{"invalid public key", []int{1, 0, 3}, 0, 0, 0, "public key 2"},
+		{"wrong tag in the first half of the aggregate nonce", []int{1, 2, 0}, 2, 0, 0, "aggregate nonce"},
+		{"second half of the aggregate nonce is not an x coordinate", []int{1, 2, 0}, 3, 0, 0, "aggregate nonce"},
+		{"second half of the aggregate nonce exceeds field size", []int{1, 2, 0}, 4, 0, 0, "aggregate nonce"},
+		{"secret nonce is zero, which may indicate nonce reuse", []int{0, 1, 2}, 0, 0, 1, "invalid secret nonce"},
+	}
+	for _, test := range signInvalid {
+		t.Run(test.name, func(t *testing.T) {
+			s := &SessionContext{
+				AggNonce:   signAggNonces[test.aggNonceIndex],
+				PublicKeys: pick(signPublicKeys, test.keyIndices),
+				Message:    signMessages[test.msgIndex],
+			}
+			_, err := s.Sign(bytes.Clone(signSecNonces[test.secNonceIndex]), signSecretKey)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+
+	verifyInvalid := []struct {
+		name                     string
+		psig                     string
+		keyIndices, nonceIndices []int
+		msgIndex, signerIndex    int
+	}{
+		{"negated signature", "FED54434AD4CFE953FC527DC6A5E5BE8F6234907B7C187559557CE87A0541C46", []int{0, 1, 2}, []int{0, 1, 2}, 0, 0},
+		{"wrong signer", "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB", []int{0, 1, 2}, []int{0, 1, 2}, 0, 1},
+		{"signature exceeds group size", "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", []int{0, 1, 2}, []int{0, 1, 2}, 0, 0},
+		{"invalid public nonce", "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB", []int{0, 1, 2}, []int{4, 1, 2}, 0, 0},
+		{"invalid public key", "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB", []int{3, 1, 2}, []int{0, 1, 2}, 0, 0},
+	}
+	for _, test := range verifyInvalid {
+		t.Run(test.name, func(t *testing.T) {
+			pubNonces := pick(signPubNonces, test.nonceIndices)
+			s := &SessionContext{
+				AggNonce:   signAggNonces[0],
+				PublicKeys: pick(signPublicKeys, test.keyIndices),
+				Message:    signMessages[test.msgIndex],
+			}
+			assert.False(t, s.Verify(fromHex(test.psig), pubNonces[test.signerIndex], s.PublicKeys[test.signerIndex]))
+		})
+	}
+}
+
+func TestTweakVectors(t *testing.T) {
+	publicKeys := [][]byte{
+		fromHex("03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9"),
+		fromHex("02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9"),
+		fromHex("02DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659"),
+	}
+	tweaks := [][]byte{
+		fromHex("E8F791FF9225A2AF0102AFFF4A9A723D9612A682A25EBE79802B263CDFCD83BB"),
+		fromHex("AE2EA797CC0FE72AC5B97B97F3C6957D7E4199A167A58EB08BCAFFDA70AC0455"),
+		fromHex("F52ECBC565B3D8BEA2DFD5B75A4F457E54369809322E4120831626F290FA87E0"),
+		fromHex("1969AD73CC177FA0B4FCED6DF1F7BF9907E665FDE9BA196A74FED0A3CF5AEF9D"),
+		fromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141"),
+	}
+	aggNonce := signAggNonces[0]
+	// All cases use the keys and nonces 1, 2, 0, so that the signer is the last one.
+	keys := pick(publicKeys, []int{1, 2, 0})
+	pubNonces := pick(signPubNonces, []int{1, 2, 0})
+
+	valid := []struct {
+		name         string
+		tweakIndices []int
+		xOnly        []bool
+		expected     string
+	}{
+		{"single x-only tweak", []int{0}, []bool{true}, "E28A5C66E61E178C2BA19DB77B6CF9F7E2F0F56C17918CD13135E60CC848FE91"},
+		{"single plain tweak", []int{0}, []bool{false}, "38B0767798252F21BF5702C48028B095428320F73A4B14DB1E25DE58543D2D2D"},
+		{"plain tweak then x-only tweak", []int{0, 1}, []bool{false, true}, "408A0A21C4A0F5DACAF9646AD6EB6FECD7F7A11F03ED1F48DFFF2185BC2C2408"},
+		{"plain, plain, x-only, x-only", []int{0, 1, 2, 3}, []bool{false, false, true, true}, "45ABD206E61E3DF2EC9E264A6FEC8292141A633C28586388235541F9ADE75435"},
+		{"x-only, plain, x-only, plain", []int{0, 1, 2, 3}, []bool{true, false, true, false}, "B255FDCAC27B40C7CE7848E2D3B7BF5EA0ED756DA81565AC804CCCA3E1D5D239"},
+	}
+	for _, test := range valid {
+		t.Run(test.name, func(t *testing.T) {
+			s := &SessionContext{
+				AggNonce:   aggNonce,
+				PublicKeys: keys,
+				Tweaks:     pickTweaks(tweaks, test.tweakIndices, test.xOnly),
+				Message:    signMessages[0],
+			}
+			psig, err := s.Sign(bytes.Clone(signSecNonces[0]), signSecretKey)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, toHex(psig))
+			assert.True(t, s.Verify(psig, pubNonces[2], keys[2]))
+		})
+	}
+
+	t.Run("tweak exceeds group size", func(t *testing.T) {
+		s := &SessionContext{
+			AggNonce:   aggNonce,
+			PublicKeys: keys,
+			Tweaks:     pickTweaks(tweaks, []int{4}, []bool{false}),
+			Message:    signMessages[0],
+		}
+		_, err := s.Sign(bytes.Clone(signSecNonces[0]), signSecretKey)
+		assert.ErrorContains(t, err, "invalid tweak")
+	})
+}
+
+func TestSigAggVectors(t *testing.T) {
+	publicKeys := [][]byte{
+		fromHex("03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9"),
+		fromHex("02D2DC6F5DF7C56ACF38C7FA0AE7A759AE30E19B37359DFDE015872324C7EF6E05"),
+		fromHex("03C7FB101D97FF930ACD0C6760852EF64E69083DE0B06AC6335724754BB4B0522C"),
+		fromHex("02352433B21E7E05D3B452B81CAE566E06D2E003ECE16D1074AABA4289E0E3D581"),
+	}
+	tweaks := [][]byte{
+		fromHex("B511DA492182A91B0FFB9A98020D55F260AE86D7ECBD0399C7383D59A5F2AF7C"),
+		fromHex("A815FE049EE3C5AAB66310477FBC8BCCCAC2F3395F59F921C364ACD78A2F48DC"),
+		fromHex("75448A87274B056468B977BE06EB1E9F657577B7320B0A3376EA51FD420D18A8"),
+	}
+	psigs := [][]byte{
+		fromHex("B15D2CD3C3D22B04DAE438CE653F6B4ECF042F42CFDED7C41B64AAF9B4AF53FB"),
+		fromHex("6193D6AC61B354E9105BBDC8937A3454A6D705B6D57322A5A472A02CE99FCB64"),
+		fromHex("9A87D3B79EC67228CB97878B76049B15DBD05B8158D17B5B9114D3C226887505"),
+		fromHex("66F82EA90923689B855D36C6B7E032FB9970301481B99E01CDB4D6AC7C347A15"),
+		fromHex("4F5AEE41510848A6447DCD1BBC78457EF69024944C87F40250D3EF2C25D33EFE"),
+		fromHex("DDEF427BBB847CC027BEFF4EDB01038148917832253EBC355FC33F4A8E2FCCE4"),
+		fromHex("97B890A26C981DA8102D3BC294159D171D72810FDF7C6A691DEF02F0F7AF3FDC"),
+		fromHex("53FA9E08BA5243CBCB0D797C5EE83BC6728E539EB76C2D0BF0F971EE4E909971"),
+		fromHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141"),
+	}
+	message := fromHex("599C67EA410D005B9DA90817CF03ED3B1C868E4DA4EDF00A5880B0082C237869")
+
+	valid := []struct {
+		aggNonce     string
+		keyIndices   []int
+		tweakIndices []int
+		xOnly        []bool
+		psigIndices  []int
+		expected     string
+	}{
+		{
+			"0341432722C5CD0268D829C702CF0D1CBCE57033EED201FD335191385227C3210C03D377F2D258B64AADC0E16F26462323D701D286046A2EA93365656AFD9875982B",
+			[]int{0, 1}, nil, nil, []int{0, 1},
+			"041DA22223CE65C92C9A0D6C2CAC828AAF1EEE56304FEC371DDF91EBB2B9EF0912F1038025857FEDEB3FF696F8B99FA4BB2C5812F6095A2E0004EC99CE18DE1E",
+		},
+		{
+			"0208C5C438C710F4F96A61E9FF3C37758814B8C3AE12BFEA0ED2C87FF6954FF186020B1816EA104B4FCA2D304D733E0E19CEAD51303FF6420BFD222335CAA402916D",
+			[]int{0, 2}, []int{0}, []bool{false}, []int{4, 5},
+			"5C558E1DCADE86DA0B2F02626A512E30A22CF5255CAEA7EE32C38E9A71A0E9148BA6C0E6EC7683B64220F0298696F1B878CD47B107B81F7188812D593971E0CC",
+		},
+		{
+			"02B5AD07AFCD99B6D92CB433FBD2A28FDEB98EAE2EB09B6014EF0F8197CD58403302E8616910F9293CF692C49F351DB86B25E352901F0E237BAFDA11F1C1CEF29FFD",
+			[]int{0, 3}, []int{0, 1, 2}, []bool{true, false, true}, []int{6, 7},
+			"839B08820B681DBA8DAF4CC7B104E8F2638F9388F8D7A555DC17B6E6971D7426CE07BF6AB01F1DB50E4E33719295F4094572B79868E440FB3DEFD3FAC1DB589E",
+		},
+	}
+	for _, test := range valid {
+		s := &SessionContext{
+			AggNonce:   fromHex(test.aggNonce),
+			PublicKeys: pick(publicKeys, test.keyIndices),
+			Tweaks:     pickTweaks(tweaks, test.tweakIndices, test.xOnly),
+			Message:    message,
+		}
+		sig, err := s.Aggregate(pick(psigs, test.psigIndices))
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, toHex(sig))
+		keys, err := s.KeyAggContext()
+		require.NoError(t, err)
+		assert.True(t, keys.PublicKey().Verify(sig, message))
+	}
+
+	t.Run("partial signature exceeds group size", func(t *testing.T) {
+		s := &SessionContext{
+			AggNonce:   fromHex("02B5AD07AFCD99B6D92CB433FBD2A28FDEB98EAE2EB09B6014EF0F8197CD58403302E8616910F9293CF692C49F351DB86B25E352901F0E237BAFDA11F1C1CEF29FFD"),
+			PublicKeys: pick(publicKeys, []int{0, 3}),
+			Tweaks:     pickTweaks(tweaks, []int{0, 1, 2}, []bool{true, false, true}),
+			Message:    message,
+		}
+		_, err := s.Aggregate(pick(psigs, []int{7, 8}))
+		assert.ErrorContains(t, err, "partial signature 1")
+	})
+}
@@ -0,0 +1,115 @@
+package musig2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
+)
+
+// Config contains the keys of an n-of-n MuSig2 signer group, from the perspective of a single participant.
+//
+// No key generation protocol is needed: each participant generates its own secret key,
+// and the participants only need to exchange their public keys.
+type Config struct {
+	// ID is the identifier for this participant.
+	ID party.ID
+	// SecretKey is the secret key of this participant.
+	SecretKey *curve.Secp256k1Scalar
+	// PublicKeys maps each participant to its public key, including this participant.
+	PublicKeys map[party.ID]*curve.Secp256k1Point
+	// Tweaks are applied in order to the aggregate public key.
+	Tweaks []Tweak
+}
+
+// Validate ensures that the config is consistent.
+func (c *Config) Validate() error {
+	if c == nil || c.SecretKey == nil || c.SecretKey.IsZero() {
+		return errors.New("musig2: config: secret key is missing")
+	}
+	publicKey, ok := c.PublicKeys[c.ID]
+	if !ok || publicKey == nil {
+		return fmt.Errorf("musig2: config: no public key for %v", c.ID)
+	}
+	if !c.SecretKey.ActOnBase().Equal(publicKey) {
+		return errors.New("musig2: config: public key does not match the secret key")
+	}
+	for id, publicKey := range c.PublicKeys {
+		if publicKey == nil || publicKey.IsIdentity() {
+			return fmt.Errorf("musig2: config: invalid public key for %v", id)
+		}
+	}
+	return nil
+}
+
+// PartyIDs returns the IDs of all participants, which must all sign together.
+func (c *Config) PartyIDs() party.IDSlice {
+	ids := make([]party.ID, 0, len(c.PublicKeys))
+	for id := range c.PublicKeys {
+		ids = append(ids, id)
+	}
+	return party.NewIDSlice(ids)
+}
+
+// plainPublicKeys returns the encoded public keys of the participants, sorted with KeySort.
+func (c *Config) plainPublicKeys() [][]byte {
+	publicKeys := make([][]byte, 0, len(c.PublicKeys))
+	for _, publicKey := range c.PublicKeys {
+		publicKeys = append(publicKeys, cbytes(publicKey))
+	}
+	return KeySort(publicKeys)
+}
+
+// KeyAggContext returns the aggregation of the sorted public keys of the participants, with the tweaks applied.
+func (c *Config) KeyAggContext() (*KeyAggContext, error) {
+	session := SessionContext{PublicKeys: c.plainPublicKeys(), Tweaks: c.Tweaks}
+	return session.KeyAggContext()
+}
+
+// PublicKey returns the x-only public key verifying the signatures produced with this config.
+func (c *Config) PublicKey() (taproot.PublicKey, error) {
+	keys, err := c.KeyAggContext()
+	if err != nil {
+		return nil, err
+	}
+	return keys.PublicKey(), nil
+}
+
+// ApplyTweak returns a copy of this config, whose public key is tweaked by tweak.
+func (c *Config) ApplyTweak(tweak Tweak) (*Config, error) {
+	tweaks := make([]Tweak, len(c.Tweaks), len(c.Tweaks)+1)
+	copy(tweaks, c.Tweaks)
+	tweaked := &Config{
+		ID:         c.ID,
+		SecretKey:  c.SecretKey,
+		PublicKeys: c.PublicKeys,
+		Tweaks:     append(tweaks, tweak),
+	}
+	if _, err := tweaked.KeyAggContext(); err != nil {
+		return nil, err
+	}
+	return tweaked, nil
+}
+
+// TweakForScriptTree returns a copy of this config for the BIP-341 output key which commits to
+// the script tree with the given merkle root, whose internal key is the current public key.
+//
+// An empty merkleRoot produces the output key of a script-less key path spend.
+//
+// The returned config does not record the internal key, nor the parity of the output key. To spend the output
+// through its script path, callers must keep the internal key, from which taproot.TweakPublicKey gives the parity
+// needed in the control block.
+func (c *Config) TweakForScriptTree(merkleRoot []byte) (*Config, error) {
+	internalKey, err := c.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	tweak, err := taproot.TapTweak(internalKey, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	tweakBytes, _ := tweak.MarshalBinary()
+	return c.ApplyTweak(Tweak{Tweak: tweakBytes, XOnly: true})
+}
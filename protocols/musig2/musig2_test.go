@@ -0,0 +1,183 @@
+package musig2
+
+import (
+	"crypto/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/internal/test"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/sample"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+	"github.com/taurusgroup/multi-party-sig/pkg/taproot"
+)
+
+func generateConfigs(partyIDs []party.ID) map[party.ID]*Config {
+	secretKeys := make(map[party.ID]*curve.Secp256k1Scalar, len(partyIDs))
+	publicKeys := make(map[party.ID]*curve.Secp256k1Point, len(partyIDs))
+	for _, id := range partyIDs {
+		secretKeys[id] = sample.Scalar(rand.Reader, curve.Secp256k1{}).(*curve.Secp256k1Scalar)
+		publicKeys[id] = secretKeys[id].ActOnBase().(*curve.Secp256k1Point)
+	}
+	configs := make(map[party.ID]*Config, len(partyIDs))
+	for _, id := range partyIDs {
+		configs[id] = &Config{
+			ID:         id,
+			SecretKey:  secretKeys[id],
+			PublicKeys: publicKeys,
+		}
+	}
+	return configs
+}
+
+// verifyScriptPathCommitment checks that outputKey commits to merkleRoot with internalKey,
+// as a BIP-341 verifier does for a script path spend, using the parity bit of the control block.
+func verifyScriptPathCommitment(t *testing.T, internalKey, outputKey taproot.PublicKey, merkleRoot []byte, odd bool) {
+	tweak, err := taproot.TapTweak(internalKey, merkleRoot)
+	require.NoError(t, err)
+	P, err := curve.Secp256k1{}.LiftX(internalKey)
+	require.NoError(t, err)
+	var Q curve.Point
+	Q, err = curve.Secp256k1{}.LiftX(outputKey)
+	require.NoError(t, err)
+	if odd {
+		Q = Q.Negate()
+	}
+	assert.True(t, Q.Equal(P.Add(tweak.ActOnBase())), "parity does not match the output key")
+}
+
+func do(t *testing.T, config *Config, message []byte, n *test.Network, wg *sync.WaitGroup) {
+	defer wg.Done()
+	publicKey, err := config.PublicKey()
+	require.NoError(t, err)
+
+	h, err := protocol.NewMultiHandler(Sign(config, message), nil)
+	require.NoError(t, err)
+	test.HandlerLoop(config.ID, h, n)
+	r, err := h.Result()
+	require.NoError(t, err)
+	require.IsType(t, taproot.Signature{}, r)
+	assert.True(t, publicKey.Verify(r.(taproot.Signature), message))
+
+	merkleRoot := taproot.TaggedHash("TapLeaf", []byte("recovery"))
+	outputKey, odd, err := taproot.TweakPublicKey(publicKey, merkleRoot)
+	require.NoError(t, err)
+	verifyScriptPathCommitment(t, publicKey, outputKey, merkleRoot, odd)
+	tweaked, err := config.TweakForScriptTree(merkleRoot)
+	require.NoError(t, err)
+	tweakedKey, err := tweaked.PublicKey()
+	require.NoError(t, err)
+	require.Equal(t, outputKey, tweakedKey)
+
+	h, err = protocol.NewMultiHandler(Sign(tweaked, message), nil)
+	require.NoError(t, err)
+	test.HandlerLoop(config.ID, h, n)
+	r, err = h.Result()
+	require.NoError(t, err)
+	require.IsType(t, taproot.Signature{}, r)
+	assert.True(t, outputKey.Verify(r.(taproot.Signature), message))
+}
+
+func TestMuSig2(t *testing.T) {
+	N := 4
+	message := []byte("hello")
+
+	partyIDs := test.PartyIDs(N)
+	configs := generateConfigs(partyIDs)
+
+	n := test.NewNetwork(partyIDs)
+
+	var wg sync.WaitGroup
+	wg.Add(N)
+	for _, id := range partyIDs {
+		go do(t, configs[id], message, n, &wg)
+	}
+	wg.Wait()
+}
+
+func TestSignTweaks(t *testing.T) {
+	N := 3
+	message := []byte("hello")
+
+	partyIDs := test.PartyIDs(N)
+	configs := generateConfigs(partyIDs)
+	tweaks := []Tweak{
+		{Tweak: fromHex("E8F791FF9225A2AF0102AFFF4A9A723D9612A682A25EBE79802B263CDFCD83BB"), XOnly: false},
+		{Tweak: fromHex("AE2EA797CC0FE72AC5B97B97F3C6957D7E4199A167A58EB08BCAFFDA70AC0455"), XOnly: true},
+		{Tweak: fromHex("F52ECBC565B3D8BEA2DFD5B75A4F457E54369809322E4120831626F290FA87E0"), XOnly: true},
+		{Tweak: fromHex("1969AD73CC177FA0B4FCED6DF1F7BF9907E665FDE9BA196A74FED0A3CF5AEF9D"), XOnly: false},
+	}
+
+	rounds := make([]round.Session, 0, N)
+	var publicKey taproot.PublicKey
+	for _, id := range partyIDs {
+		config := configs[id]
+		for _, tweak := range tweaks {
+			var err error
+			config, err = config.ApplyTweak(tweak)
+			require.NoError(t, err)
+		}
+		var err error
+		publicKey, err = config.PublicKey()
+		require.NoError(t, err)
+		r, err := Sign(config, message)(nil)
+		require.NoError(t, err)
+		rounds = append(rounds, r)
+	}
+
+	for {
+		err, done := test.Rounds(rounds, nil)
+		require.NoError(t, err, "failed to process round")
+		if done {
+			break
+		}
+	}
+
+	for _, r := range rounds {
+		require.IsType(t, &round.Output{}, r)
+		signature := r.(*round.Output).Result.(taproot.Signature)
+		assert.True(t, publicKey.Verify(signature, message))
+	}
+}
+
+// invalidPartialSignature modifies the partial signature broadcast by culprit.
+type invalidPartialSignature struct {
+	culprit party.ID
+}
+
+func (invalidPartialSignature) ModifyBefore(round.Session) {}
+func (invalidPartialSignature) ModifyAfter(round.Session)  {}
+func (rule invalidPartialSignature) ModifyContent(rNext round.Session, _ party.ID, content round.Content) {
+	if body, ok := content.(*broadcast3); ok && rNext.SelfID() == rule.culprit {
+		body.PartialSignature[31] ^= 1
+	}
+}
+
+func TestSignInvalidPartialSignature(t *testing.T) {
+	N := 3
+	message := []byte("hello")
+
+	partyIDs := test.PartyIDs(N)
+	configs := generateConfigs(partyIDs)
+	culprit := partyIDs[1]
+
+	rounds := make([]round.Session, 0, N)
+	for _, id := range partyIDs {
+		r, err := Sign(configs[id], message)(nil)
+		require.NoError(t, err)
+		rounds = append(rounds, r)
+	}
+
+	for {
+		err, done := test.Rounds(rounds, invalidPartialSignature{culprit: culprit})
+		if err != nil {
+			assert.ErrorContains(t, err, "partial signature from "+string(culprit))
+			return
+		}
+		require.False(t, done, "expected the partial signature to be rejected")
+	}
+}
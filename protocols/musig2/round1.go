@@ -0,0 +1,57 @@
+package musig2
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var _ round.Round = (*round1)(nil)
+
+type round1 struct {
+	*round.Helper
+
+	config *Config
+	// keys is the aggregation of the sorted public keys, with the tweaks of the config.
+	keys *KeyAggContext
+	// session is completed with the aggregate nonce in round 2.
+	session *SessionContext
+}
+
+// VerifyMessage implements round.Round.
+func (r *round1) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (r *round1) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - generate a secret nonce with BIP-327 NonceGen, bound to the SSID of this execution.
+// - broadcast the public nonce.
+func (r *round1) Finalize(out chan<- *round.Message) (round.Session, error) {
+	secretKey, err := r.config.SecretKey.MarshalBinary()
+	if err != nil {
+		return r, err
+	}
+	publicKey := cbytes(r.config.PublicKeys[r.SelfID()])
+	secNonce, pubNonce, err := NonceGen(r.Rand(), secretKey, publicKey, r.keys.PublicKey(), r.session.Message, r.SSID())
+	if err != nil {
+		return r, fmt.Errorf("musig2: %w", err)
+	}
+
+	if err = r.BroadcastMessage(out, &broadcast2{PubNonce: pubNonce}); err != nil {
+		return r, err
+	}
+	return &round2{
+		round1:    r,
+		secNonce:  secNonce,
+		PubNonces: map[party.ID][]byte{r.SelfID(): pubNonce},
+	}, nil
+}
+
+// MessageContent implements round.Round.
+func (round1) MessageContent() round.Content { return nil }
+
+// Number implements round.Round.
+func (round1) Number() round.Number { return 1 }
@@ -0,0 +1,100 @@
+package musig2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var _ round.Round = (*round2)(nil)
+
+type round2 struct {
+	*round1
+
+	// secNonce is the secret nonce of this participant, and is erased once used.
+	secNonce []byte
+	// PubNonces[j] is the public nonce of party j.
+	PubNonces map[party.ID][]byte
+}
+
+type broadcast2 struct {
+	// The nonces are reliably broadcast, so that a party sending an invalid partial signature
+	// cannot claim that it was computed for a different aggregate nonce.
+	round.ReliableBroadcastContent
+	// PubNonce is the BIP-327 public nonce of the sender.
+	PubNonce []byte
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - store the public nonce, if both of its points are valid.
+func (r *round2) StoreBroadcastMessage(msg round.Message) error {
+	body, ok := msg.Content.(*broadcast2)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.PubNonce == nil {
+		return round.ErrNilFields
+	}
+	if _, err := NonceAgg([][]byte{body.PubNonce}); err != nil {
+		return err
+	}
+	r.PubNonces[msg.From] = body.PubNonce
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round2) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round2) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - aggregate the public nonces.
+// - compute and broadcast the partial signature.
+func (r *round2) Finalize(out chan<- *round.Message) (round.Session, error) {
+	if len(r.PubNonces) != r.N() {
+		return r, errors.New("musig2: missing public nonces")
+	}
+	pubNonces := make([][]byte, 0, r.N())
+	for _, j := range r.PartyIDs() {
+		pubNonces = append(pubNonces, r.PubNonces[j])
+	}
+	aggNonce, err := NonceAgg(pubNonces)
+	if err != nil {
+		return r, err
+	}
+	r.session.AggNonce = aggNonce
+
+	secretKey, err := r.config.SecretKey.MarshalBinary()
+	if err != nil {
+		return r, err
+	}
+	partialSignature, err := r.session.Sign(r.secNonce, secretKey)
+	if err != nil {
+		return r, fmt.Errorf("musig2: %w", err)
+	}
+
+	if err = r.BroadcastMessage(out, &broadcast3{PartialSignature: partialSignature}); err != nil {
+		return r, err
+	}
+	return &round3{
+		round2:            r,
+		PartialSignatures: map[party.ID][]byte{r.SelfID(): partialSignature},
+	}, nil
+}
+
+// MessageContent implements round.Round.
+func (round2) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast2) RoundNumber() round.Number { return 2 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (round2) BroadcastContent() round.BroadcastContent { return &broadcast2{} }
+
+// Number implements round.Round.
+func (round2) Number() round.Number { return 2 }
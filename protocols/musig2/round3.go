@@ -0,0 +1,86 @@
+package musig2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/party"
+)
+
+var _ round.Round = (*round3)(nil)
+
+type round3 struct {
+	*round2
+
+	// PartialSignatures[j] is the partial signature of party j.
+	PartialSignatures map[party.ID][]byte
+}
+
+type broadcast3 struct {
+	round.NormalBroadcastContent
+	// PartialSignature is the BIP-327 partial signature of the sender.
+	PartialSignature []byte
+}
+
+// StoreBroadcastMessage implements round.BroadcastRound.
+//
+// - verify the partial signature against the public nonce and public key of the sender.
+func (r *round3) StoreBroadcastMessage(msg round.Message) error {
+	from := msg.From
+	body, ok := msg.Content.(*broadcast3)
+	if !ok || body == nil {
+		return round.ErrInvalidContent
+	}
+	if body.PartialSignature == nil {
+		return round.ErrNilFields
+	}
+	publicKey, ok := r.config.PublicKeys[from]
+	if !ok {
+		return fmt.Errorf("musig2: no public key for %v", from)
+	}
+	if !r.session.Verify(body.PartialSignature, r.PubNonces[from], cbytes(publicKey)) {
+		return fmt.Errorf("musig2: failed to verify partial signature from %v", from)
+	}
+	r.PartialSignatures[from] = body.PartialSignature
+	return nil
+}
+
+// VerifyMessage implements round.Round.
+func (round3) VerifyMessage(round.Message) error { return nil }
+
+// StoreMessage implements round.Round.
+func (round3) StoreMessage(round.Message) error { return nil }
+
+// Finalize implements round.Round
+//
+// - aggregate the partial signatures, and verify the resulting BIP-340 signature.
+func (r *round3) Finalize(chan<- *round.Message) (round.Session, error) {
+	if len(r.PartialSignatures) != r.N() {
+		return r, errors.New("musig2: missing partial signatures")
+	}
+	partialSignatures := make([][]byte, 0, r.N())
+	for _, j := range r.PartyIDs() {
+		partialSignatures = append(partialSignatures, r.PartialSignatures[j])
+	}
+	sig, err := r.session.Aggregate(partialSignatures)
+	if err != nil {
+		return r, err
+	}
+	if !r.keys.PublicKey().Verify(sig, r.session.Message) {
+		return r.AbortRound(errors.New("musig2: generated signature failed to verify")), nil
+	}
+	return r.ResultRound(sig), nil
+}
+
+// MessageContent implements round.Round.
+func (round3) MessageContent() round.Content { return nil }
+
+// RoundNumber implements round.Content.
+func (broadcast3) RoundNumber() round.Number { return 3 }
+
+// BroadcastContent implements round.BroadcastRound.
+func (round3) BroadcastContent() round.BroadcastContent { return &broadcast3{} }
+
+// Number implements round.Round.
+func (round3) Number() round.Number { return 3 }
@@ -0,0 +1,75 @@
+package musig2
+
+import (
+	"fmt"
+
+	"github.com/taurusgroup/multi-party-sig/internal/round"
+	"github.com/taurusgroup/multi-party-sig/pkg/hash"
+	"github.com/taurusgroup/multi-party-sig/pkg/math/curve"
+	"github.com/taurusgroup/multi-party-sig/pkg/protocol"
+)
+
+const (
+	// MuSig2 n-of-n signing.
+	protocolID = "musig2/sign"
+	// This protocol has 3 concrete rounds.
+	protocolRounds round.Number = 3
+)
+
+// Sign initiates the MuSig2 protocol for producing a BIP-340 signature of message,
+// with the aggregate public key given by config.PublicKey.
+//
+// All participants of the config must take part, and the signature is produced after two rounds of messages:
+// the participants first exchange public nonces, and then partial signatures.
+// A participant sending an invalid partial signature is reported as the culprit.
+//
+// The algorithms follow BIP-327, with the public keys sorted before aggregation:
+//
+//	https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki
+func Sign(config *Config, message []byte, opts ...protocol.Option) protocol.StartFunc {
+	return func(sessionID []byte) (round.Session, error) {
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("musig2.Sign: %w", err)
+		}
+		keys, err := config.KeyAggContext()
+		if err != nil {
+			return nil, fmt.Errorf("musig2.Sign: %w", err)
+		}
+
+		partyIDs := config.PartyIDs()
+		info := round.Info{
+			ProtocolID:       protocolID,
+			FinalRoundNumber: protocolRounds,
+			SelfID:           config.ID,
+			PartyIDs:         partyIDs,
+			Threshold:        len(partyIDs) - 1,
+			Group:            curve.Secp256k1{},
+		}
+		info.Apply(opts...)
+
+		helper, err := round.NewSession(info, sessionID, nil,
+			&hash.BytesWithDomain{
+				TheDomain: "Aggregate Public Key",
+				Bytes:     keys.PlainPublicKey(),
+			},
+			&hash.BytesWithDomain{
+				TheDomain: "Message",
+				Bytes:     message,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("musig2.Sign: %w", err)
+		}
+
+		return &round1{
+			Helper: helper,
+			config: config,
+			keys:   keys,
+			session: &SessionContext{
+				PublicKeys: config.plainPublicKeys(),
+				Tweaks:     config.Tweaks,
+				Message:    message,
+			},
+		}, nil
+	}
+}